@@ -2,38 +2,152 @@ package bank
 
 import (
 	"context"
+	"crypto/tls"
 	"encoding/json"
 	"fmt"
 	"math"
 	"net/http"
+	"net/url"
 	"payments_gateway/model"
 	"strings"
 	"time"
 
+	"github.com/hashicorp/go-cleanhttp"
 	"github.com/hashicorp/go-retryablehttp"
 )
 
+// DefaultBaseURL is the address of the bank simulator started by docker-compose
+const DefaultBaseURL = "http://0.0.0.0:1080/api/v1"
+
+// endpoints of the acquiring bank, relative to the base URL
 const (
-	_validateURL  = "http://0.0.0.0:1080/api/v1/validate"
-	_authorizeURL = "http://0.0.0.0:1080/api/v1/authorize"
-	_submitURL    = "http://0.0.0.0:1080/api/v1/submit"
+	_validatePath  = "/validate"
+	_authorizePath = "/authorize"
+	_submitPath    = "/submit"
+	_capturePath   = "/capture"
+	_voidPath      = "/void"
+	_refundPath    = "/refund"
+	_inquirePath   = "/inquire"
+	_reversePath   = "/reverse"
 )
 
 type Client interface {
 	Validate(context.Context, model.Card) (bool, error)                      // validate card info
 	Authorize(context.Context, model.Transaction) (string, string, error)    // response code(approved denied)
 	Submit(context.Context, []*model.Transaction) (map[string]string, error) // submit approved auths for settlement/payments
+	Capture(context.Context, model.Modification) (string, string, error)     // capture (part of) an authorized amount
+	Void(context.Context, model.Modification) (string, string, error)        // cancel an authorization before capture
+	Refund(context.Context, model.Modification) (string, string, error)      // refund (part of) a captured amount
+	Inquire(context.Context, string) (string, string, error)                 // response code of an authorization left pending
+	Reverse(context.Context, model.Modification) (string, string, error)     // release the hold of an authorization with an unknown outcome
+}
+
+// DeclinedError is returned by Validate when the acquiring bank declines the card details,
+// as opposed to the request failing
+type DeclinedError struct {
+	Reason string
+}
+
+func (e *DeclinedError) Error() string {
+	return e.Reason
 }
 
 type Bank struct {
 	httpClient *retryablehttp.Client
+	baseURL    string
+	headers    http.Header
+}
+
+// Options configures the client of the acquiring bank
+type Options struct {
+	// BaseURL is prefixed to the path of every endpoint, e.g. https://acquirer.example.com/api/v1
+	BaseURL string
+	// Timeout bounds every attempt of a request, including reading the response
+	Timeout time.Duration
+	// RetryMax is the number of times a failed request is retried
+	RetryMax int
+	// RetryWaitMin and RetryWaitMax bound the exponential backoff between retries
+	RetryWaitMin time.Duration
+	RetryWaitMax time.Duration
+	// TLS is used for https base URLs, the system roots are trusted when nil
+	TLS *tls.Config
+	// Headers are added to every request, e.g. credentials issued by the acquirer
+	Headers http.Header
+}
+
+// DefaultOptions are the options of a client for the bank simulator
+func DefaultOptions() Options {
+	return Options{
+		BaseURL:      DefaultBaseURL,
+		Timeout:      5 * time.Second,
+		RetryMax:     3,
+		RetryWaitMin: 1 * time.Second,
+		RetryWaitMax: 30 * time.Second,
+	}
+}
+
+// transaction is the acquiring bank representation of a model.Transaction,
+// the bank expects amounts in the major unit of the currency
+type transaction struct {
+	RefID    string
+	Card     model.Card
+	Amount   float64
+	Currency string
+}
+
+// modification is the acquiring bank representation of a model.Modification
+type modification struct {
+	RefID    string
+	Amount   float64
+	Currency string
+}
+
+// inquiry asks the acquiring bank for the outcome of an authorization
+type inquiry struct {
+	RefID string
+}
+
+func toTransaction(t model.Transaction) transaction {
+	return transaction{
+		RefID:    t.RefID,
+		Card:     t.Card,
+		Amount:   t.Amount.Major(),
+		Currency: t.Amount.Currency,
+	}
+}
+
+func toModification(m model.Modification) modification {
+	return modification{
+		RefID:    m.RefID,
+		Amount:   m.Amount.Major(),
+		Currency: m.Amount.Currency,
+	}
 }
 
 // New creates a new client for the acquiring bank service
-func New() Client {
+func New(opts Options) (Client, error) {
+	base, err := url.Parse(opts.BaseURL)
+	if err != nil || (base.Scheme != "http" && base.Scheme != "https") || base.Host == "" {
+		return nil, fmt.Errorf("invalid acquiring bank base url %q", opts.BaseURL)
+	}
+
+	if opts.RetryMax < 0 || opts.RetryWaitMin < 0 || opts.RetryWaitMax < opts.RetryWaitMin {
+		return nil, fmt.Errorf("invalid acquiring bank retry options")
+	}
+
 	retryClient := retryablehttp.NewClient()
-	retryClient.RetryMax = 3
-	retryClient.HTTPClient.Timeout = 5 * time.Second
+	retryClient.Logger = &retryLogger{}
+	retryClient.RetryMax = opts.RetryMax
+	retryClient.RetryWaitMin = opts.RetryWaitMin
+	retryClient.RetryWaitMax = opts.RetryWaitMax
+	retryClient.HTTPClient.Timeout = opts.Timeout
+
+	if opts.TLS != nil {
+		transport := cleanhttp.DefaultPooledTransport()
+		transport.TLSClientConfig = opts.TLS
+		retryClient.HTTPClient.Transport = transport
+	}
+
 	retryClient.Backoff = func(min, max time.Duration, attemptNum int, resp *http.Response) time.Duration {
 		// too many requests
 		if resp != nil {
@@ -54,7 +168,25 @@ func New() Client {
 
 	return &Bank{
 		httpClient: retryClient,
+		baseURL:    strings.TrimSuffix(opts.BaseURL, "/"),
+		headers:    opts.Headers.Clone(),
+	}, nil
+}
+
+// newRequest creates a POST request to an endpoint of the acquiring bank
+func (b *Bank) newRequest(ctx context.Context, path string, body []byte) (*retryablehttp.Request, error) {
+	req, err := retryablehttp.NewRequest("POST", b.baseURL+path, body)
+	if err != nil {
+		return nil, err
 	}
+
+	for name, values := range b.headers {
+		for _, value := range values {
+			req.Header.Add(name, value)
+		}
+	}
+
+	return req.WithContext(ctx), nil
 }
 
 // Validate calls the acquiring banks validate endpoint
@@ -64,14 +196,11 @@ func (b *Bank) Validate(ctx context.Context, card model.Card) (bool, error) {
 		return false, err
 	}
 
-	req, err := retryablehttp.NewRequest("POST", _validateURL, body)
-
+	req, err := b.newRequest(ctx, _validatePath, body)
 	if err != nil {
 		return false, err
 	}
 
-	req = req.WithContext(ctx)
-
 	resp, err := b.httpClient.Do(req)
 	if err != nil {
 		return false, fmt.Errorf("error performing validation request: %s", err)
@@ -96,7 +225,7 @@ func (b *Bank) Validate(ctx context.Context, card model.Card) (bool, error) {
 	}
 
 	if val, ok := status["error"]; ok {
-		return false, fmt.Errorf("%s", val)
+		return false, &DeclinedError{Reason: val}
 	}
 
 	return false, nil
@@ -104,19 +233,16 @@ func (b *Bank) Validate(ctx context.Context, card model.Card) (bool, error) {
 
 // Authorize calls the acquiring banks authorize endpoint
 func (b *Bank) Authorize(ctx context.Context, transaction model.Transaction) (string, string, error) {
-	body, err := json.Marshal(transaction)
+	body, err := json.Marshal(toTransaction(transaction))
 	if err != nil {
 		return "", "", err
 	}
 
-	req, err := retryablehttp.NewRequest("POST", _authorizeURL, body)
-
+	req, err := b.newRequest(ctx, _authorizePath, body)
 	if err != nil {
 		return "", "", err
 	}
 
-	req = req.WithContext(ctx)
-
 	resp, err := b.httpClient.Do(req)
 	if err != nil {
 		return "", "", fmt.Errorf("error performing authorization request: %s", err)
@@ -148,22 +274,24 @@ func (b *Bank) Authorize(ctx context.Context, transaction model.Transaction) (st
 }
 
 // Submit submits all transactions from the day for payment
-func (b *Bank) Submit(ctx context.Context, transaction []*model.Transaction) (map[string]string, error) {
+func (b *Bank) Submit(ctx context.Context, transactions []*model.Transaction) (map[string]string, error) {
 	out := make(map[string]string, 0)
 
-	body, err := json.Marshal(transaction)
+	batch := make([]transaction, 0, len(transactions))
+	for _, t := range transactions {
+		batch = append(batch, toTransaction(*t))
+	}
+
+	body, err := json.Marshal(batch)
 	if err != nil {
 		return out, err
 	}
 
-	req, err := retryablehttp.NewRequest("POST", _submitURL, body)
-
+	req, err := b.newRequest(ctx, _submitPath, body)
 	if err != nil {
 		return out, err
 	}
 
-	req = req.WithContext(ctx)
-
 	resp, err := b.httpClient.Do(req)
 	if err != nil {
 		return out, fmt.Errorf("error performing submit request: %s", err)
@@ -199,3 +327,71 @@ func (b *Bank) Submit(ctx context.Context, transaction []*model.Transaction) (ma
 
 	return out, nil
 }
+
+// Capture calls the acquiring banks capture endpoint
+func (b *Bank) Capture(ctx context.Context, modification model.Modification) (string, string, error) {
+	return b.modify(ctx, _capturePath, "capture", modification)
+}
+
+// Void calls the acquiring banks void endpoint
+func (b *Bank) Void(ctx context.Context, modification model.Modification) (string, string, error) {
+	return b.modify(ctx, _voidPath, "void", modification)
+}
+
+// Refund calls the acquiring banks refund endpoint
+func (b *Bank) Refund(ctx context.Context, modification model.Modification) (string, string, error) {
+	return b.modify(ctx, _refundPath, "refund", modification)
+}
+
+// Reverse calls the acquiring banks reverse endpoint, releasing the funds held by an authorization
+// the outcome of which was not received. An authorization the bank never received is not an error
+func (b *Bank) Reverse(ctx context.Context, modification model.Modification) (string, string, error) {
+	return b.modify(ctx, _reversePath, "reversal", modification)
+}
+
+// Inquire calls the acquiring banks inquire endpoint for the outcome of an authorization it
+// responded pending to
+func (b *Bank) Inquire(ctx context.Context, refID string) (string, string, error) {
+	return b.respond(ctx, _inquirePath, "inquiry", inquiry{RefID: refID})
+}
+
+// modify sends a modification of a previous authorization to the acquiring bank
+// and returns the response code and reason
+func (b *Bank) modify(ctx context.Context, path, operation string, m model.Modification) (string, string, error) {
+	return b.respond(ctx, path, operation, toModification(m))
+}
+
+// respond sends a request about a previous authorization to the acquiring bank
+// and returns the response code and reason
+func (b *Bank) respond(ctx context.Context, path, operation string, payload interface{}) (string, string, error) {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return "", "", err
+	}
+
+	req, err := b.newRequest(ctx, path, body)
+	if err != nil {
+		return "", "", err
+	}
+
+	resp, err := b.httpClient.Do(req)
+	if err != nil {
+		return "", "", fmt.Errorf("error performing %s request: %s", operation, err)
+	}
+
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusTooManyRequests {
+		return "", "", fmt.Errorf("unexpected response status code: %d", resp.StatusCode)
+	}
+
+	var status map[string]string
+	if err := json.NewDecoder(resp.Body).Decode(&status); err != nil {
+
+		return "", "", err
+	}
+
+	return status["code"], status["reason"], nil
+}
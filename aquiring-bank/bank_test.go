@@ -9,14 +9,14 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"payments_gateway/model"
+	"payments_gateway/money"
 	protos "payments_gateway/protos"
 	"strings"
 	"testing"
 	"time"
 )
 
-// Also I would use httpTest package, but I didn't expose the URL when creating a new client, so
-// I decided to check the httpTransport layer instead
+// The tests below check the httpTransport layer, TestNew sends requests to an httptest server
 type RoundTripFunc struct {
 	r   func(req *http.Request) *http.Response
 	err error
@@ -74,10 +74,10 @@ func TestBank_Validate(t *testing.T) {
 				Name:     "Bruce",
 				Surname:  "Wayne",
 				Postcode: "G15 2DN",
-				CardType: protos.CardType_VISA.String(),
+				CardType: protos.CardType_AMERICAN_EXPRESS.String(),
 				CardNum:  "378282246310005",
-				Expiry:   "23/4",
-				Cvv:      342,
+				Expiry:   "04/30",
+				Cvv:      3421,
 			},
 			expected: true,
 			err:      nil,
@@ -114,10 +114,10 @@ func TestBank_Validate(t *testing.T) {
 				Name:     "Bruce",
 				Surname:  "Wayne",
 				Postcode: "G15 2DN",
-				CardType: protos.CardType_VISA.String(),
+				CardType: protos.CardType_AMERICAN_EXPRESS.String(),
 				CardNum:  "378282246310005",
-				Expiry:   "23/4",
-				Cvv:      342,
+				Expiry:   "04/30",
+				Cvv:      3421,
 			},
 			expected: false,
 			err:      fmt.Errorf("error performing validation request: POST http://0.0.0.0:1080/api/v1/validate giving up after 2 attempt(s)"),
@@ -131,7 +131,7 @@ func TestBank_Validate(t *testing.T) {
 			retryClient.HTTPClient.Timeout = 5 * time.Second
 			retryClient.HTTPClient.Transport = tt.transport
 
-			b := Bank{httpClient: retryClient}
+			b := Bank{httpClient: retryClient, baseURL: DefaultBaseURL}
 
 			got, err := b.Validate(ctx, tt.card)
 			if err != nil {
@@ -164,13 +164,12 @@ func TestBank_Authorize(t *testing.T) {
 					Name:     "Bruce",
 					Surname:  "Wayne",
 					Postcode: "G15 2DN",
-					CardType: protos.CardType_VISA.String(),
+					CardType: protos.CardType_AMERICAN_EXPRESS.String(),
 					CardNum:  "378282246310005",
-					Expiry:   "23/4",
-					Cvv:      342,
+					Expiry:   "04/30",
+					Cvv:      3421,
 				},
-				Amount:   20.5,
-				Currency: "GBP",
+				Amount: money.New(2050, "GBP"),
 			},
 			transport: RoundTripFunc{
 				r: func(req *http.Request) *http.Response {
@@ -191,6 +190,12 @@ func TestBank_Authorize(t *testing.T) {
 						t.FailNow()
 					}
 
+					body, _ := ioutil.ReadAll(req.Body)
+					if !strings.Contains(string(body), `"Amount":20.5,"Currency":"GBP"`) {
+						t.Error("amount not sent in major units")
+						t.FailNow()
+					}
+
 					resp.StatusCode = 200
 
 					resp.Body = ioutil.NopCloser(strings.NewReader(`{"code":"00","reason": "approved and completed successfully"}`))
@@ -210,13 +215,12 @@ func TestBank_Authorize(t *testing.T) {
 					Name:     "Bruce",
 					Surname:  "Wayne",
 					Postcode: "G15 2DN",
-					CardType: protos.CardType_VISA.String(),
+					CardType: protos.CardType_AMERICAN_EXPRESS.String(),
 					CardNum:  "378282246310005",
-					Expiry:   "23/4",
-					Cvv:      342,
+					Expiry:   "04/30",
+					Cvv:      3421,
 				},
-				Amount:   20.5,
-				Currency: "GBP",
+				Amount: money.New(2050, "GBP"),
 			},
 			transport: RoundTripFunc{
 				r: func(req *http.Request) *http.Response {
@@ -256,13 +260,12 @@ func TestBank_Authorize(t *testing.T) {
 					Name:     "Bruce",
 					Surname:  "Wayne",
 					Postcode: "G15 2DN",
-					CardType: protos.CardType_VISA.String(),
+					CardType: protos.CardType_AMERICAN_EXPRESS.String(),
 					CardNum:  "378282246310005",
-					Expiry:   "23/4",
-					Cvv:      342,
+					Expiry:   "04/30",
+					Cvv:      3421,
 				},
-				Amount:   20.5,
-				Currency: "GBP",
+				Amount: money.New(2050, "GBP"),
 			},
 			transport: RoundTripFunc{
 				r: func(req *http.Request) *http.Response {
@@ -300,7 +303,7 @@ func TestBank_Authorize(t *testing.T) {
 			retryClient.HTTPClient.Timeout = 5 * time.Second
 			retryClient.HTTPClient.Transport = tt.transport
 
-			b := Bank{httpClient: retryClient}
+			b := Bank{httpClient: retryClient, baseURL: DefaultBaseURL}
 
 			got, got1, err := b.Authorize(ctx, tt.transaction)
 			if err != nil {
@@ -315,3 +318,170 @@ func TestBank_Authorize(t *testing.T) {
 		})
 	}
 }
+
+func TestBank_Modifications(t *testing.T) {
+	ctx := context.Background()
+
+	modification := model.Modification{
+		RefID:  "825ca1787c9d4672991848a5bfbc1057",
+		Amount: money.New(2050, "GBP"),
+	}
+
+	transport := func(path string, status int, body string) RoundTripFunc {
+		return RoundTripFunc{
+			r: func(req *http.Request) *http.Response {
+				if req.Method != http.MethodPost {
+					t.Error("wrong method")
+					t.FailNow()
+				}
+
+				if req.URL.Host != "0.0.0.0:1080" {
+					t.Error("wrong url")
+					t.FailNow()
+				}
+
+				if req.URL.Path != path {
+					t.Error("wrong path")
+					t.FailNow()
+				}
+
+				return &http.Response{
+					StatusCode: status,
+					Body:       ioutil.NopCloser(strings.NewReader(body)),
+				}
+			},
+		}
+	}
+
+	tests := []struct {
+		name           string
+		call           func(b *Bank) (string, string, error)
+		transport      RoundTripFunc
+		expectedCode   string
+		expectedReason string
+		err            error
+	}{
+		{
+			name: "captures the payment",
+			call: func(b *Bank) (string, string, error) {
+				return b.Capture(ctx, modification)
+			},
+			transport:      transport("/api/v1/capture", 200, `{"code":"00","reason":"captured"}`),
+			expectedCode:   "00",
+			expectedReason: "captured",
+		},
+		{
+			name: "voids the payment",
+			call: func(b *Bank) (string, string, error) {
+				return b.Void(ctx, modification)
+			},
+			transport:      transport("/api/v1/void", 200, `{"code":"00","reason":"voided"}`),
+			expectedCode:   "00",
+			expectedReason: "voided",
+		},
+		{
+			name: "refunds the payment",
+			call: func(b *Bank) (string, string, error) {
+				return b.Refund(ctx, modification)
+			},
+			transport:      transport("/api/v1/refund", 200, `{"code":"12","reason":"refund exceeds captured amount"}`),
+			expectedCode:   "12",
+			expectedReason: "refund exceeds captured amount",
+		},
+		{
+			name: "reverses the authorization",
+			call: func(b *Bank) (string, string, error) {
+				return b.Reverse(ctx, modification)
+			},
+			transport:      transport("/api/v1/reverse", 200, `{"code":"00","reason":"reversed"}`),
+			expectedCode:   "00",
+			expectedReason: "reversed",
+		},
+		{
+			name: "inquires the outcome of the payment",
+			call: func(b *Bank) (string, string, error) {
+				return b.Inquire(ctx, modification.RefID)
+			},
+			transport:      transport("/api/v1/inquire", 200, `{"code":"00","reason":"approved"}`),
+			expectedCode:   "00",
+			expectedReason: "approved",
+		},
+		{
+			name: "returns error",
+			call: func(b *Bank) (string, string, error) {
+				return b.Refund(ctx, modification)
+			},
+			transport: transport("/api/v1/refund", 500, `{"error":"new error"}`),
+			err:       fmt.Errorf("error performing refund request: POST http://0.0.0.0:1080/api/v1/refund giving up after 2 attempt(s)"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			retryClient := retryablehttp.NewClient()
+			retryClient.RetryMax = 1
+			retryClient.HTTPClient.Timeout = 5 * time.Second
+			retryClient.HTTPClient.Transport = tt.transport
+
+			b := &Bank{httpClient: retryClient, baseURL: DefaultBaseURL}
+
+			code, reason, err := tt.call(b)
+			if err != nil {
+				assert.Equal(t, tt.err.Error(), err.Error())
+
+				return
+			}
+
+			assert.Equal(t, tt.expectedCode, code)
+			assert.Equal(t, tt.expectedReason, reason)
+		})
+	}
+}
+
+func TestNew(t *testing.T) {
+	ctx := context.Background()
+
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/acquirer/v2/authorize" {
+			w.WriteHeader(http.StatusNotFound)
+
+			return
+		}
+
+		if r.Header.Get("X-Api-Key") != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+
+			return
+		}
+
+		fmt.Fprint(w, `{"code":"00","reason":"approved"}`)
+	}))
+	defer svr.Close()
+
+	opts := DefaultOptions()
+	opts.BaseURL = svr.URL + "/acquirer/v2/"
+	opts.RetryMax = 0
+	opts.Headers = http.Header{"X-Api-Key": []string{"secret"}}
+
+	b, err := New(opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	code, reason, err := b.Authorize(ctx, model.Transaction{RefID: "ref", Amount: money.New(2050, "GBP")})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "00", code)
+	assert.Equal(t, "approved", reason)
+
+	invalid := []Options{
+		{BaseURL: "0.0.0.0:1080/api/v1"},
+		{BaseURL: "ftp://0.0.0.0:1080"},
+		{BaseURL: DefaultBaseURL, RetryMax: -1},
+		{BaseURL: DefaultBaseURL, RetryWaitMin: time.Second, RetryWaitMax: time.Millisecond},
+	}
+
+	for _, opts := range invalid {
+		_, err := New(opts)
+		assert.Error(t, err, opts.BaseURL)
+	}
+}
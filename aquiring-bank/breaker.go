@@ -0,0 +1,151 @@
+package bank
+
+import (
+	"context"
+	"errors"
+
+	log "github.com/sirupsen/logrus"
+
+	"payments_gateway/breaker"
+	"payments_gateway/model"
+)
+
+// BreakerClient guards every call to an acquirer with a circuit breaker. Calls fail with
+// breaker.ErrOpen without reaching the acquirer while the breaker is open
+type BreakerClient struct {
+	client  Client
+	breaker *breaker.Breaker
+}
+
+var _ Client = (*BreakerClient)(nil)
+
+// WithBreaker wraps the client of the named acquirer in a circuit breaker, state changes are logged
+func WithBreaker(name string, client Client, opts breaker.Options) *BreakerClient {
+	return &BreakerClient{
+		client: client,
+		breaker: breaker.New(opts, func(from, to breaker.State) {
+			log.WithField("acquirer", name).
+				WithField("from", from.String()).
+				WithField("to", to.String()).
+				Warn("acquirer circuit breaker changed state")
+		}),
+	}
+}
+
+// Stats returns the state of the circuit breaker of the acquirer
+func (c *BreakerClient) Stats() breaker.Stats {
+	return c.breaker.Stats()
+}
+
+// Validate calls Validate of the acquirer unless its breaker is open
+func (c *BreakerClient) Validate(ctx context.Context, card model.Card) (ok bool, err error) {
+	err = c.do(func() error {
+		ok, err = c.client.Validate(ctx, card)
+
+		return err
+	})
+
+	return ok, err
+}
+
+// Authorize calls Authorize of the acquirer unless its breaker is open
+func (c *BreakerClient) Authorize(ctx context.Context, transaction model.Transaction) (code, reason string, err error) {
+	err = c.do(func() error {
+		code, reason, err = c.client.Authorize(ctx, transaction)
+
+		return err
+	})
+
+	return code, reason, err
+}
+
+// Submit calls Submit of the acquirer unless its breaker is open
+func (c *BreakerClient) Submit(ctx context.Context, transactions []*model.Transaction) (failures map[string]string, err error) {
+	err = c.do(func() error {
+		failures, err = c.client.Submit(ctx, transactions)
+
+		return err
+	})
+
+	return failures, err
+}
+
+// Capture calls Capture of the acquirer unless its breaker is open
+func (c *BreakerClient) Capture(ctx context.Context, m model.Modification) (code, reason string, err error) {
+	err = c.do(func() error {
+		code, reason, err = c.client.Capture(ctx, m)
+
+		return err
+	})
+
+	return code, reason, err
+}
+
+// Void calls Void of the acquirer unless its breaker is open
+func (c *BreakerClient) Void(ctx context.Context, m model.Modification) (code, reason string, err error) {
+	err = c.do(func() error {
+		code, reason, err = c.client.Void(ctx, m)
+
+		return err
+	})
+
+	return code, reason, err
+}
+
+// Refund calls Refund of the acquirer unless its breaker is open
+func (c *BreakerClient) Refund(ctx context.Context, m model.Modification) (code, reason string, err error) {
+	err = c.do(func() error {
+		code, reason, err = c.client.Refund(ctx, m)
+
+		return err
+	})
+
+	return code, reason, err
+}
+
+// Inquire calls Inquire of the acquirer unless its breaker is open
+func (c *BreakerClient) Inquire(ctx context.Context, refID string) (code, reason string, err error) {
+	err = c.do(func() error {
+		code, reason, err = c.client.Inquire(ctx, refID)
+
+		return err
+	})
+
+	return code, reason, err
+}
+
+// Reverse calls Reverse of the acquirer unless its breaker is open
+func (c *BreakerClient) Reverse(ctx context.Context, m model.Modification) (code, reason string, err error) {
+	err = c.do(func() error {
+		code, reason, err = c.client.Reverse(ctx, m)
+
+		return err
+	})
+
+	return code, reason, err
+}
+
+func (c *BreakerClient) do(call func() error) error {
+	done, err := c.breaker.Allow()
+	if err != nil {
+		return err
+	}
+
+	err = call()
+	done(failed(err))
+
+	return err
+}
+
+// failed reports whether an error means the acquirer is unhealthy. Card details the acquirer
+// declined and calls cancelled by the caller say nothing about its health
+func failed(err error) bool {
+	var declined *DeclinedError
+
+	switch {
+	case err == nil, errors.As(err, &declined), errors.Is(err, context.Canceled):
+		return false
+	default:
+		return true
+	}
+}
@@ -0,0 +1,93 @@
+package bank
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"payments_gateway/breaker"
+	"payments_gateway/model"
+)
+
+// stubClient returns err from every call it receives and counts them
+type stubClient struct {
+	Client
+	err   error
+	calls int
+}
+
+func (s *stubClient) Validate(context.Context, model.Card) (bool, error) {
+	s.calls++
+
+	return s.err == nil, s.err
+}
+
+func (s *stubClient) Authorize(context.Context, model.Transaction) (string, string, error) {
+	s.calls++
+
+	if s.err != nil {
+		return "", "", s.err
+	}
+
+	return "00", "approved", nil
+}
+
+func TestBreakerClient(t *testing.T) {
+	opts := breaker.Options{
+		Window:        time.Minute,
+		MinCalls:      2,
+		ErrorRate:     0.5,
+		OpenTimeout:   time.Minute,
+		HalfOpenCalls: 1,
+	}
+
+	tests := []struct {
+		name      string
+		err       error
+		wantState breaker.State
+	}{
+		{
+			name:      "acquirer is down",
+			err:       errors.New("error performing validation request: giving up after 4 attempt(s)"),
+			wantState: breaker.Open,
+		},
+		{
+			name:      "card declined",
+			err:       &DeclinedError{Reason: "invalid card number"},
+			wantState: breaker.Closed,
+		},
+		{
+			name:      "caller cancelled",
+			err:       context.Canceled,
+			wantState: breaker.Closed,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stub := &stubClient{err: tt.err}
+			c := WithBreaker("acquirer", stub, opts)
+
+			for i := 0; i < 2; i++ {
+				_, err := c.Validate(context.Background(), model.Card{})
+				assert.Equal(t, tt.err, err)
+			}
+
+			assert.Equal(t, tt.wantState, c.Stats().State)
+
+			_, _, err := c.Authorize(context.Background(), model.Transaction{})
+
+			if tt.wantState == breaker.Open {
+				assert.Equal(t, breaker.ErrOpen, err)
+				assert.Equal(t, 2, stub.calls)
+
+				return
+			}
+
+			assert.Equal(t, tt.err, err)
+			assert.Equal(t, 3, stub.calls)
+		})
+	}
+}
@@ -0,0 +1,224 @@
+package bank
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Config is how Options are written in a config file, the environment and flags.
+// Durations are strings such as "5s", empty fields keep the default option
+type Config struct {
+	BaseURL      string            `json:"base_url"`
+	Timeout      string            `json:"timeout"`
+	RetryMax     *int              `json:"retry_max"`
+	RetryWaitMin string            `json:"retry_wait_min"`
+	RetryWaitMax string            `json:"retry_wait_max"`
+	CAFile       string            `json:"ca_file"`
+	CertFile     string            `json:"cert_file"`
+	KeyFile      string            `json:"key_file"`
+	Headers      map[string]string `json:"headers"`
+}
+
+// LoadConfig reads a JSON config file such as config/bank.json
+func LoadConfig(path string) (Config, error) {
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		return Config{}, fmt.Errorf("reading acquiring bank config: %w", err)
+	}
+
+	var c Config
+	if err := json.Unmarshal(contents, &c); err != nil {
+		return Config{}, fmt.Errorf("parsing acquiring bank config: %w", err)
+	}
+
+	return c, nil
+}
+
+// ConfigFromEnv reads the BANK_* environment variables. BANK_HEADERS is a comma separated
+// list of Name=Value pairs
+func ConfigFromEnv(lookup func(string) (string, bool)) (Config, error) {
+	get := func(key string) string {
+		value, _ := lookup(key)
+
+		return strings.TrimSpace(value)
+	}
+
+	c := Config{
+		BaseURL:      get("BANK_BASE_URL"),
+		Timeout:      get("BANK_TIMEOUT"),
+		RetryWaitMin: get("BANK_RETRY_WAIT_MIN"),
+		RetryWaitMax: get("BANK_RETRY_WAIT_MAX"),
+		CAFile:       get("BANK_CA_FILE"),
+		CertFile:     get("BANK_CERT_FILE"),
+		KeyFile:      get("BANK_KEY_FILE"),
+	}
+
+	if retryMax := get("BANK_RETRY_MAX"); retryMax != "" {
+		n, err := strconv.Atoi(retryMax)
+		if err != nil {
+			return Config{}, fmt.Errorf("invalid BANK_RETRY_MAX %q", retryMax)
+		}
+
+		c.RetryMax = &n
+	}
+
+	if headers := get("BANK_HEADERS"); headers != "" {
+		c.Headers = map[string]string{}
+
+		for _, header := range strings.Split(headers, ",") {
+			if err := c.AddHeader(header); err != nil {
+				return Config{}, err
+			}
+		}
+	}
+
+	return c, nil
+}
+
+// AddHeader adds a header written as Name=Value
+func (c *Config) AddHeader(header string) error {
+	parts := strings.SplitN(header, "=", 2)
+	if len(parts) != 2 || strings.TrimSpace(parts[0]) == "" {
+		return fmt.Errorf("invalid acquiring bank header %q, expected Name=Value", header)
+	}
+
+	if c.Headers == nil {
+		c.Headers = map[string]string{}
+	}
+
+	c.Headers[strings.TrimSpace(parts[0])] = strings.TrimSpace(parts[1])
+
+	return nil
+}
+
+// Merge returns c with every field set in override replacing its own
+func (c Config) Merge(override Config) Config {
+	merge := func(value *string, with string) {
+		if with != "" {
+			*value = with
+		}
+	}
+
+	merge(&c.BaseURL, override.BaseURL)
+	merge(&c.Timeout, override.Timeout)
+	merge(&c.RetryWaitMin, override.RetryWaitMin)
+	merge(&c.RetryWaitMax, override.RetryWaitMax)
+	merge(&c.CAFile, override.CAFile)
+	merge(&c.CertFile, override.CertFile)
+	merge(&c.KeyFile, override.KeyFile)
+
+	if override.RetryMax != nil {
+		c.RetryMax = override.RetryMax
+	}
+
+	if len(override.Headers) > 0 {
+		headers := make(map[string]string, len(c.Headers)+len(override.Headers))
+		for name, value := range c.Headers {
+			headers[name] = value
+		}
+
+		for name, value := range override.Headers {
+			headers[name] = value
+		}
+
+		c.Headers = headers
+	}
+
+	return c
+}
+
+// Options converts the config to client options, fields that are not set keep their default
+func (c Config) Options() (Options, error) {
+	opts := DefaultOptions()
+
+	if c.BaseURL != "" {
+		opts.BaseURL = c.BaseURL
+	}
+
+	durations := []struct {
+		name  string
+		value string
+		out   *time.Duration
+	}{
+		{name: "timeout", value: c.Timeout, out: &opts.Timeout},
+		{name: "retry_wait_min", value: c.RetryWaitMin, out: &opts.RetryWaitMin},
+		{name: "retry_wait_max", value: c.RetryWaitMax, out: &opts.RetryWaitMax},
+	}
+
+	for _, d := range durations {
+		if d.value == "" {
+			continue
+		}
+
+		parsed, err := time.ParseDuration(d.value)
+		if err != nil {
+			return Options{}, fmt.Errorf("invalid acquiring bank %s %q", d.name, d.value)
+		}
+
+		*d.out = parsed
+	}
+
+	if c.RetryMax != nil {
+		opts.RetryMax = *c.RetryMax
+	}
+
+	if len(c.Headers) > 0 {
+		opts.Headers = http.Header{}
+		for name, value := range c.Headers {
+			opts.Headers.Set(name, value)
+		}
+	}
+
+	if c.CAFile != "" || c.CertFile != "" || c.KeyFile != "" {
+		tlsConfig, err := TLSConfig(c.CAFile, c.CertFile, c.KeyFile)
+		if err != nil {
+			return Options{}, err
+		}
+
+		opts.TLS = tlsConfig
+	}
+
+	return opts, nil
+}
+
+// TLSConfig creates the TLS config of the client. caFile replaces the system roots when set
+// and certFile and keyFile are the client certificate of acquirers that require mutual TLS
+func TLSConfig(caFile, certFile, keyFile string) (*tls.Config, error) {
+	tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+
+	if caFile != "" {
+		pem, err := os.ReadFile(caFile)
+		if err != nil {
+			return nil, fmt.Errorf("reading acquiring bank CA: %w", err)
+		}
+
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificates found in %s", caFile)
+		}
+
+		tlsConfig.RootCAs = pool
+	}
+
+	if (certFile == "") != (keyFile == "") {
+		return nil, fmt.Errorf("acquiring bank client certificate needs both a cert and a key file")
+	}
+
+	if certFile != "" {
+		cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+		if err != nil {
+			return nil, fmt.Errorf("loading acquiring bank client certificate: %w", err)
+		}
+
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	return tlsConfig, nil
+}
@@ -0,0 +1,93 @@
+package bank
+
+import (
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfig_Options(t *testing.T) {
+	dir := t.TempDir()
+
+	path := filepath.Join(dir, "bank.json")
+	if err := os.WriteFile(path, []byte(`{
+		"base_url": "https://staging.acquirer.example.com/api/v1",
+		"timeout": "10s",
+		"retry_max": 5,
+		"headers": {"X-Api-Key": "from-file", "X-Merchant": "gateway"}
+	}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	file, err := LoadConfig(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	env, err := ConfigFromEnv(func(key string) (string, bool) {
+		values := map[string]string{
+			"BANK_BASE_URL":       "https://acquirer.example.com/api/v1",
+			"BANK_RETRY_MAX":      "0",
+			"BANK_RETRY_WAIT_MIN": "100ms",
+			"BANK_HEADERS":        "X-Api-Key=from-env",
+		}
+		value, ok := values[key]
+
+		return value, ok
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var flags Config
+	flags.Timeout = "2s"
+
+	// the environment overrides the file and flags override both
+	opts, err := file.Merge(env).Merge(flags).Options()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, Options{
+		BaseURL:      "https://acquirer.example.com/api/v1",
+		Timeout:      2 * time.Second,
+		RetryMax:     0,
+		RetryWaitMin: 100 * time.Millisecond,
+		RetryWaitMax: 30 * time.Second,
+		Headers: http.Header{
+			"X-Api-Key":  []string{"from-env"},
+			"X-Merchant": []string{"gateway"},
+		},
+	}, opts)
+}
+
+func TestConfig_Defaults(t *testing.T) {
+	opts, err := Config{}.Options()
+
+	assert.NoError(t, err)
+	assert.Equal(t, DefaultOptions(), opts)
+}
+
+func TestConfig_Invalid(t *testing.T) {
+	_, err := Config{Timeout: "5"}.Options()
+	assert.EqualError(t, err, `invalid acquiring bank timeout "5"`)
+
+	_, err = Config{CertFile: "client.pem"}.Options()
+	assert.EqualError(t, err, "acquiring bank client certificate needs both a cert and a key file")
+
+	_, err = Config{CAFile: filepath.Join(t.TempDir(), "missing.pem")}.Options()
+	assert.Error(t, err)
+
+	_, err = ConfigFromEnv(func(key string) (string, bool) {
+		if key == "BANK_HEADERS" {
+			return "X-Api-Key", true
+		}
+
+		return "", false
+	})
+	assert.EqualError(t, err, `invalid acquiring bank header "X-Api-Key", expected Name=Value`)
+}
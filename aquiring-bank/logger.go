@@ -0,0 +1,37 @@
+package bank
+
+import (
+	"fmt"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// retryLogger logs the requests of the retryablehttp client with logrus, so they
+// go through the same hooks (e.g. redaction) as the rest of the service logs
+type retryLogger struct{}
+
+func (l *retryLogger) Error(msg string, keysAndValues ...interface{}) {
+	l.entry(keysAndValues).Error(msg)
+}
+
+func (l *retryLogger) Info(msg string, keysAndValues ...interface{}) {
+	l.entry(keysAndValues).Info(msg)
+}
+
+func (l *retryLogger) Debug(msg string, keysAndValues ...interface{}) {
+	l.entry(keysAndValues).Debug(msg)
+}
+
+func (l *retryLogger) Warn(msg string, keysAndValues ...interface{}) {
+	l.entry(keysAndValues).Warn(msg)
+}
+
+func (l *retryLogger) entry(keysAndValues []interface{}) *log.Entry {
+	fields := log.Fields{"AcquiringBank": "retryablehttp"}
+
+	for i := 0; i+1 < len(keysAndValues); i += 2 {
+		fields[fmt.Sprint(keysAndValues[i])] = keysAndValues[i+1]
+	}
+
+	return log.WithFields(fields)
+}
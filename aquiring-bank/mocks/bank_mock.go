@@ -51,6 +51,70 @@ func (mr *MockClientMockRecorder) Authorize(arg0, arg1 interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Authorize", reflect.TypeOf((*MockClient)(nil).Authorize), arg0, arg1)
 }
 
+// Capture mocks base method.
+func (m *MockClient) Capture(arg0 context.Context, arg1 model.Modification) (string, string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Capture", arg0, arg1)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Capture indicates an expected call of Capture.
+func (mr *MockClientMockRecorder) Capture(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Capture", reflect.TypeOf((*MockClient)(nil).Capture), arg0, arg1)
+}
+
+// Inquire mocks base method.
+func (m *MockClient) Inquire(arg0 context.Context, arg1 string) (string, string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Inquire", arg0, arg1)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Inquire indicates an expected call of Inquire.
+func (mr *MockClientMockRecorder) Inquire(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Inquire", reflect.TypeOf((*MockClient)(nil).Inquire), arg0, arg1)
+}
+
+// Refund mocks base method.
+func (m *MockClient) Refund(arg0 context.Context, arg1 model.Modification) (string, string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Refund", arg0, arg1)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Refund indicates an expected call of Refund.
+func (mr *MockClientMockRecorder) Refund(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Refund", reflect.TypeOf((*MockClient)(nil).Refund), arg0, arg1)
+}
+
+// Reverse mocks base method.
+func (m *MockClient) Reverse(arg0 context.Context, arg1 model.Modification) (string, string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reverse", arg0, arg1)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Reverse indicates an expected call of Reverse.
+func (mr *MockClientMockRecorder) Reverse(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reverse", reflect.TypeOf((*MockClient)(nil).Reverse), arg0, arg1)
+}
+
 // Submit mocks base method.
 func (m *MockClient) Submit(arg0 context.Context, arg1 []*model.Transaction) (map[string]string, error) {
 	m.ctrl.T.Helper()
@@ -80,3 +144,19 @@ func (mr *MockClientMockRecorder) Validate(arg0, arg1 interface{}) *gomock.Call
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Validate", reflect.TypeOf((*MockClient)(nil).Validate), arg0, arg1)
 }
+
+// Void mocks base method.
+func (m *MockClient) Void(arg0 context.Context, arg1 model.Modification) (string, string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Void", arg0, arg1)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Void indicates an expected call of Void.
+func (mr *MockClientMockRecorder) Void(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Void", reflect.TypeOf((*MockClient)(nil).Void), arg0, arg1)
+}
@@ -0,0 +1,53 @@
+package bank
+
+import (
+	"fmt"
+	"sort"
+)
+
+// DefaultAcquirer is the name of the acquirer payments are routed to when no routing rule matches
+const DefaultAcquirer = "default"
+
+// Registry holds the clients of every acquiring bank the gateway can route payments to, by name
+type Registry struct {
+	clients map[string]Client
+}
+
+// NewRegistry creates an empty registry
+func NewRegistry() *Registry {
+	return &Registry{clients: map[string]Client{}}
+}
+
+// Register adds the client of an acquirer, names must be unique
+func (r *Registry) Register(name string, client Client) error {
+	if name == "" {
+		return fmt.Errorf("acquirer name must be set")
+	}
+
+	if _, ok := r.clients[name]; ok {
+		return fmt.Errorf("acquirer %q is already registered", name)
+	}
+
+	r.clients[name] = client
+
+	return nil
+}
+
+// Get returns the client of an acquirer
+func (r *Registry) Get(name string) (Client, bool) {
+	client, ok := r.clients[name]
+
+	return client, ok
+}
+
+// Names returns the names of every registered acquirer in alphabetical order
+func (r *Registry) Names() []string {
+	names := make([]string, 0, len(r.clients))
+	for name := range r.clients {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
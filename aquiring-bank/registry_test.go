@@ -0,0 +1,28 @@
+package bank
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRegistry(t *testing.T) {
+	r := NewRegistry()
+
+	primary := &Bank{baseURL: "http://primary"}
+	secondary := &Bank{baseURL: "http://secondary"}
+
+	assert.NoError(t, r.Register("secondary", secondary))
+	assert.NoError(t, r.Register(DefaultAcquirer, primary))
+	assert.EqualError(t, r.Register(DefaultAcquirer, secondary), `acquirer "default" is already registered`)
+	assert.EqualError(t, r.Register("", secondary), "acquirer name must be set")
+
+	got, ok := r.Get(DefaultAcquirer)
+	assert.True(t, ok)
+	assert.Equal(t, primary, got)
+
+	_, ok = r.Get("unknown")
+	assert.False(t, ok)
+
+	assert.Equal(t, []string{DefaultAcquirer, "secondary"}, r.Names())
+}
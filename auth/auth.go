@@ -0,0 +1,245 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+
+	"payments_gateway/errcodes"
+	"payments_gateway/model"
+)
+
+// _keyPrefix makes API keys recognisable, e.g. by secret scanners
+const _keyPrefix = "pgk_"
+
+var (
+	_errUnauthenticated  = errcodes.Error(codes.Unauthenticated, errcodes.Unauthenticated, "missing or invalid API key")
+	_errUnknownClient    = errcodes.Error(codes.Unauthenticated, errcodes.Unauthenticated, "missing or unknown client certificate")
+	_errPermissionDenied = errcodes.Error(codes.PermissionDenied, errcodes.PermissionDenied, "only operators may call the method")
+	_errAuthenticating   = errcodes.Error(codes.Internal, errcodes.Internal, "error authenticating")
+)
+
+// Store looks up merchants by API key hash or by ID, nil is returned when there is no merchant
+type Store interface {
+	GetMerchantByAPIKey(ctx context.Context, keyHash string) (*model.Merchant, error)
+	GetMerchant(ctx context.Context, merchantID string) (*model.Merchant, error)
+}
+
+type merchantKey struct{}
+
+// NewContext returns a context carrying the authenticated merchant
+func NewContext(ctx context.Context, merchant *model.Merchant) context.Context {
+	return context.WithValue(ctx, merchantKey{}, merchant)
+}
+
+// FromContext returns the authenticated merchant of a context
+func FromContext(ctx context.Context) (*model.Merchant, bool) {
+	merchant, ok := ctx.Value(merchantKey{}).(*model.Merchant)
+
+	return merchant, ok && merchant != nil
+}
+
+// MerchantID returns the ID of the authenticated merchant of a context, or the empty ID of payments
+// made before merchants existed when the context has none
+func MerchantID(ctx context.Context) string {
+	if merchant, ok := FromContext(ctx); ok {
+		return merchant.ID
+	}
+
+	return ""
+}
+
+// GenerateKey returns a new random API key, only its hash is stored
+func GenerateKey() (string, error) {
+	key := make([]byte, 32)
+	if _, err := io.ReadFull(rand.Reader, key); err != nil {
+		return "", err
+	}
+
+	return _keyPrefix + hex.EncodeToString(key), nil
+}
+
+// HashKey returns the hash an API key is stored and looked up by. Keys are 256 random bits so a
+// fast unsalted hash cannot be brute forced, unlike passwords
+func HashKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+
+	return hex.EncodeToString(sum[:])
+}
+
+// Authenticator resolves the merchant of every call from the API key sent in the authorization
+// metadata as "Bearer <key>", or from the client certificate of the call
+type Authenticator struct {
+	store             Store
+	operatorMethods   map[string]bool
+	clientCertificate bool
+}
+
+// New creates an authenticator, only operators may call operatorMethods, which are full gRPC method
+// names such as /payments.Payments/ListAcquirers
+func New(store Store, operatorMethods ...string) *Authenticator {
+	methods := make(map[string]bool, len(operatorMethods))
+	for _, method := range operatorMethods {
+		methods[method] = true
+	}
+
+	return &Authenticator{store: store, operatorMethods: methods}
+}
+
+// NewClientCertificate creates an authenticator taking the merchant ID from the common name of the
+// subject of the verified client certificate instead of an API key, the server must require and
+// verify client certificates
+func NewClientCertificate(store Store, operatorMethods ...string) *Authenticator {
+	a := New(store, operatorMethods...)
+	a.clientCertificate = true
+
+	return a
+}
+
+// UnaryInterceptor authenticates unary calls
+func (a *Authenticator) UnaryInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	ctx, err := a.authenticate(ctx, info.FullMethod)
+	if err != nil {
+		return nil, err
+	}
+
+	return handler(ctx, req)
+}
+
+// StreamInterceptor authenticates streaming calls
+func (a *Authenticator) StreamInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	ctx, err := a.authenticate(ss.Context(), info.FullMethod)
+	if err != nil {
+		return err
+	}
+
+	return handler(srv, &serverStream{ServerStream: ss, ctx: ctx})
+}
+
+// authenticate returns the context of a call carrying its merchant
+func (a *Authenticator) authenticate(ctx context.Context, method string) (context.Context, error) {
+	var (
+		merchant *model.Merchant
+		err      error
+	)
+
+	if a.clientCertificate {
+		merchant, err = a.certificateMerchant(ctx, method)
+	} else {
+		merchant, err = a.keyMerchant(ctx, method)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if a.operatorMethods[method] && !merchant.Operator {
+		log.WithField("method", method).WithField("merchant_id", merchant.ID).Warn("operator method called by a merchant")
+
+		return nil, _errPermissionDenied
+	}
+
+	return NewContext(ctx, merchant), nil
+}
+
+// keyMerchant returns the merchant of the API key of a call
+func (a *Authenticator) keyMerchant(ctx context.Context, method string) (*model.Merchant, error) {
+	key := apiKey(ctx)
+	if key == "" {
+		return nil, _errUnauthenticated
+	}
+
+	merchant, err := a.store.GetMerchantByAPIKey(ctx, HashKey(key))
+	if err != nil {
+		log.WithField("method", method).WithError(err).Error("authenticating merchant")
+
+		return nil, _errAuthenticating
+	}
+
+	if merchant == nil {
+		log.WithField("method", method).Warn("call made with an invalid API key")
+
+		return nil, _errUnauthenticated
+	}
+
+	return merchant, nil
+}
+
+// certificateMerchant returns the merchant named by the client certificate of a call
+func (a *Authenticator) certificateMerchant(ctx context.Context, method string) (*model.Merchant, error) {
+	merchantID := certificateSubject(ctx)
+	if merchantID == "" {
+		return nil, _errUnknownClient
+	}
+
+	merchant, err := a.store.GetMerchant(ctx, merchantID)
+	if err != nil {
+		log.WithField("method", method).WithError(err).Error("authenticating merchant")
+
+		return nil, _errAuthenticating
+	}
+
+	if merchant == nil {
+		log.WithField("method", method).WithField("merchant_id", merchantID).Warn("call made with the certificate of an unknown merchant")
+
+		return nil, _errUnknownClient
+	}
+
+	return merchant, nil
+}
+
+// certificateSubject returns the subject common name of the verified client certificate of a call
+func certificateSubject(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return ""
+	}
+
+	tlsInfo, ok := p.AuthInfo.(credentials.TLSInfo)
+	if !ok {
+		return ""
+	}
+
+	// only chains verified against the client CAs are trusted, PeerCertificates may be self-signed
+	if len(tlsInfo.State.VerifiedChains) == 0 || len(tlsInfo.State.VerifiedChains[0]) == 0 {
+		return ""
+	}
+
+	return tlsInfo.State.VerifiedChains[0][0].Subject.CommonName
+}
+
+// apiKey returns the API key of the authorization metadata of a call
+func apiKey(ctx context.Context) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+
+	for _, value := range md.Get("authorization") {
+		if len(value) > len("bearer ") && strings.EqualFold(value[:len("bearer ")], "bearer ") {
+			return strings.TrimSpace(value[len("bearer "):])
+		}
+	}
+
+	return ""
+}
+
+// serverStream replaces the context of a stream with the one carrying its merchant
+type serverStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *serverStream) Context() context.Context {
+	return s.ctx
+}
@@ -0,0 +1,279 @@
+package auth
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+
+	"payments_gateway/model"
+)
+
+type fakeStore map[string]*model.Merchant
+
+func (f fakeStore) GetMerchantByAPIKey(_ context.Context, keyHash string) (*model.Merchant, error) {
+	if keyHash == HashKey("pgk_broken") {
+		return nil, errors.New("connection refused")
+	}
+
+	return f[keyHash], nil
+}
+
+func (f fakeStore) GetMerchant(_ context.Context, merchantID string) (*model.Merchant, error) {
+	if merchantID == "broken" {
+		return nil, errors.New("connection refused")
+	}
+
+	for _, merchant := range f {
+		if merchant.ID == merchantID {
+			return merchant, nil
+		}
+	}
+
+	return nil, nil
+}
+
+func TestAuthenticator_UnaryInterceptor(t *testing.T) {
+	merchant := &model.Merchant{ID: "merchant-1", Name: "Wayne Enterprises"}
+	operator := &model.Merchant{ID: "operator-1", Name: "Gateway", Operator: true}
+
+	store := fakeStore{
+		HashKey("pgk_merchant"): merchant,
+		HashKey("pgk_operator"): operator,
+	}
+
+	tests := []struct {
+		name   string
+		method string
+		md     metadata.MD
+		want   *model.Merchant
+		err    error
+	}{
+		{
+			name:   "merchant key",
+			method: "/payments.Payments/GetPayment",
+			md:     metadata.Pairs("authorization", "Bearer pgk_merchant"),
+			want:   merchant,
+		},
+		{
+			name:   "scheme is case insensitive",
+			method: "/payments.Payments/GetPayment",
+			md:     metadata.Pairs("authorization", "bearer pgk_merchant"),
+			want:   merchant,
+		},
+		{
+			name:   "operator calls an operator method",
+			method: "/payments.Payments/ListAcquirers",
+			md:     metadata.Pairs("authorization", "Bearer pgk_operator"),
+			want:   operator,
+		},
+		{
+			name:   "merchant calls an operator method",
+			method: "/payments.Payments/ListAcquirers",
+			md:     metadata.Pairs("authorization", "Bearer pgk_merchant"),
+			err:    fmt.Errorf("rpc error: code = PermissionDenied desc = only operators may call the method"),
+		},
+		{
+			name:   "missing metadata",
+			method: "/payments.Payments/GetPayment",
+			err:    fmt.Errorf("rpc error: code = Unauthenticated desc = missing or invalid API key"),
+		},
+		{
+			name:   "missing bearer scheme",
+			method: "/payments.Payments/GetPayment",
+			md:     metadata.Pairs("authorization", "pgk_merchant"),
+			err:    fmt.Errorf("rpc error: code = Unauthenticated desc = missing or invalid API key"),
+		},
+		{
+			name:   "unknown key",
+			method: "/payments.Payments/GetPayment",
+			md:     metadata.Pairs("authorization", "Bearer pgk_unknown"),
+			err:    fmt.Errorf("rpc error: code = Unauthenticated desc = missing or invalid API key"),
+		},
+		{
+			name:   "store error",
+			method: "/payments.Payments/GetPayment",
+			md:     metadata.Pairs("authorization", "Bearer pgk_broken"),
+			err:    fmt.Errorf("rpc error: code = Internal desc = error authenticating"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := New(store, "/payments.Payments/ListAcquirers")
+
+			ctx := context.Background()
+			if tt.md != nil {
+				ctx = metadata.NewIncomingContext(ctx, tt.md)
+			}
+
+			var got *model.Merchant
+
+			_, err := a.UnaryInterceptor(ctx, nil, &grpc.UnaryServerInfo{FullMethod: tt.method}, func(ctx context.Context, req interface{}) (interface{}, error) {
+				got, _ = FromContext(ctx)
+
+				return nil, nil
+			})
+			if tt.err != nil {
+				assert.Equal(t, tt.err.Error(), err.Error())
+				assert.Nil(t, got)
+
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+// peerContext returns the context of a call made over TLS with a client certificate of commonName,
+// which is verified unless verified is false
+func peerContext(commonName string, verified bool) context.Context {
+	cert := &x509.Certificate{Subject: pkix.Name{CommonName: commonName}}
+
+	state := tls.ConnectionState{PeerCertificates: []*x509.Certificate{cert}}
+	if verified {
+		state.VerifiedChains = [][]*x509.Certificate{{cert}}
+	}
+
+	return peer.NewContext(context.Background(), &peer.Peer{AuthInfo: credentials.TLSInfo{State: state}})
+}
+
+func TestAuthenticator_ClientCertificate(t *testing.T) {
+	merchant := &model.Merchant{ID: "merchant-1"}
+	operator := &model.Merchant{ID: "operator-1", Operator: true}
+
+	a := NewClientCertificate(fakeStore{"merchant": merchant, "operator": operator}, "/payments.Payments/ListAcquirers")
+
+	tests := []struct {
+		name   string
+		ctx    context.Context
+		method string
+		want   *model.Merchant
+		err    error
+	}{
+		{
+			name:   "merchant certificate",
+			ctx:    peerContext("merchant-1", true),
+			method: "/payments.Payments/GetPayment",
+			want:   merchant,
+		},
+		{
+			name:   "operator certificate",
+			ctx:    peerContext("operator-1", true),
+			method: "/payments.Payments/ListAcquirers",
+			want:   operator,
+		},
+		{
+			name:   "merchant calls an operator method",
+			ctx:    peerContext("merchant-1", true),
+			method: "/payments.Payments/ListAcquirers",
+			err:    fmt.Errorf("rpc error: code = PermissionDenied desc = only operators may call the method"),
+		},
+		{
+			name:   "unverified certificate",
+			ctx:    peerContext("merchant-1", false),
+			method: "/payments.Payments/GetPayment",
+			err:    fmt.Errorf("rpc error: code = Unauthenticated desc = missing or unknown client certificate"),
+		},
+		{
+			name:   "API keys are not accepted",
+			ctx:    metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer pgk_merchant")),
+			method: "/payments.Payments/GetPayment",
+			err:    fmt.Errorf("rpc error: code = Unauthenticated desc = missing or unknown client certificate"),
+		},
+		{
+			name:   "unknown merchant",
+			ctx:    peerContext("merchant-2", true),
+			method: "/payments.Payments/GetPayment",
+			err:    fmt.Errorf("rpc error: code = Unauthenticated desc = missing or unknown client certificate"),
+		},
+		{
+			name:   "store error",
+			ctx:    peerContext("broken", true),
+			method: "/payments.Payments/GetPayment",
+			err:    fmt.Errorf("rpc error: code = Internal desc = error authenticating"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got *model.Merchant
+
+			_, err := a.UnaryInterceptor(tt.ctx, nil, &grpc.UnaryServerInfo{FullMethod: tt.method}, func(ctx context.Context, req interface{}) (interface{}, error) {
+				got, _ = FromContext(ctx)
+
+				return nil, nil
+			})
+			if tt.err != nil {
+				assert.Equal(t, tt.err.Error(), err.Error())
+				assert.Nil(t, got)
+
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+type fakeServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (f *fakeServerStream) Context() context.Context {
+	return f.ctx
+}
+
+func TestAuthenticator_StreamInterceptor(t *testing.T) {
+	merchant := &model.Merchant{ID: "merchant-1"}
+
+	a := New(fakeStore{HashKey("pgk_merchant"): merchant})
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer pgk_merchant"))
+
+	err := a.StreamInterceptor(nil, &fakeServerStream{ctx: ctx}, &grpc.StreamServerInfo{FullMethod: "/payments.Payments/WatchPayments"}, func(srv interface{}, stream grpc.ServerStream) error {
+		assert.Equal(t, "merchant-1", MerchantID(stream.Context()))
+
+		return nil
+	})
+	assert.NoError(t, err)
+
+	err = a.StreamInterceptor(nil, &fakeServerStream{ctx: context.Background()}, &grpc.StreamServerInfo{FullMethod: "/payments.Payments/WatchPayments"}, func(srv interface{}, stream grpc.ServerStream) error {
+		t.Fatal("handler called without a merchant")
+
+		return nil
+	})
+	assert.Equal(t, "rpc error: code = Unauthenticated desc = missing or invalid API key", err.Error())
+}
+
+func TestGenerateKey(t *testing.T) {
+	key, err := GenerateKey()
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(key, _keyPrefix))
+	assert.Len(t, key, len(_keyPrefix)+64)
+
+	other, err := GenerateKey()
+	assert.NoError(t, err)
+	assert.NotEqual(t, key, other)
+
+	assert.Len(t, HashKey(key), 64)
+	assert.Equal(t, HashKey(key), HashKey(key))
+	assert.NotEqual(t, HashKey(key), HashKey(other))
+}
+
+func TestMerchantID(t *testing.T) {
+	assert.Equal(t, "", MerchantID(context.Background()))
+	assert.Equal(t, "merchant-1", MerchantID(NewContext(context.Background(), &model.Merchant{ID: "merchant-1"})))
+}
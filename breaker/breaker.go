@@ -0,0 +1,258 @@
+package breaker
+
+import (
+	"errors"
+	"flag"
+	"sync"
+	"time"
+)
+
+// ErrOpen is returned instead of making a call while the breaker is open
+var ErrOpen = errors.New("circuit breaker is open")
+
+// State of a circuit breaker
+type State int
+
+const (
+	// Closed breakers let every call through and count their outcomes
+	Closed State = iota
+	// Open breakers fail every call until OpenTimeout has passed
+	Open
+	// HalfOpen breakers let HalfOpenCalls trial calls through to decide whether to close again
+	HalfOpen
+)
+
+func (s State) String() string {
+	switch s {
+	case Closed:
+		return "CLOSED"
+	case Open:
+		return "OPEN"
+	case HalfOpen:
+		return "HALF_OPEN"
+	default:
+		return "UNKNOWN"
+	}
+}
+
+// Options configures when a breaker opens and how it recovers
+type Options struct {
+	// Window is how long outcomes are counted for before the counts are reset
+	Window time.Duration
+	// MinCalls is the number of calls in a window before the breaker may open
+	MinCalls int
+	// ErrorRate is the fraction of failed calls in a window that opens the breaker
+	ErrorRate float64
+	// SlowCall is the duration above which a successful call is counted as slow
+	SlowCall time.Duration
+	// SlowCallRate is the fraction of slow calls in a window that opens the breaker
+	SlowCallRate float64
+	// OpenTimeout is how long the breaker stays open before trial calls are let through
+	OpenTimeout time.Duration
+	// HalfOpenCalls is the number of successful trial calls that close the breaker again
+	HalfOpenCalls int
+}
+
+// DefaultOptions open a breaker when half the calls of a 30 second window fail or take longer
+// than 10 seconds, and let trial calls through after 30 seconds
+func DefaultOptions() Options {
+	return Options{
+		Window:        30 * time.Second,
+		MinCalls:      10,
+		ErrorRate:     0.5,
+		SlowCall:      10 * time.Second,
+		SlowCallRate:  0.5,
+		OpenTimeout:   30 * time.Second,
+		HalfOpenCalls: 3,
+	}
+}
+
+// RegisterFlags defines the -breaker-* flags setting the options, their defaults are the current values
+func (o *Options) RegisterFlags(fs *flag.FlagSet) {
+	fs.DurationVar(&o.Window, "breaker-window", o.Window, "how long acquirer call outcomes are counted for by the circuit breakers")
+	fs.IntVar(&o.MinCalls, "breaker-min-calls", o.MinCalls, "number of calls to an acquirer in a window before its circuit breaker may open")
+	fs.Float64Var(&o.ErrorRate, "breaker-error-rate", o.ErrorRate, "fraction of failed acquirer calls in a window that opens its circuit breaker, 0 disables")
+	fs.DurationVar(&o.SlowCall, "breaker-slow-call", o.SlowCall, "duration above which an acquirer call is counted as slow")
+	fs.Float64Var(&o.SlowCallRate, "breaker-slow-call-rate", o.SlowCallRate, "fraction of slow acquirer calls in a window that opens its circuit breaker, 0 disables")
+	fs.DurationVar(&o.OpenTimeout, "breaker-open-timeout", o.OpenTimeout, "how long an open circuit breaker fails calls before letting trial calls through")
+	fs.IntVar(&o.HalfOpenCalls, "breaker-half-open-calls", o.HalfOpenCalls, "number of successful trial calls that close a circuit breaker")
+}
+
+// Stats is a snapshot of a breaker for monitoring
+type Stats struct {
+	State State
+	// Calls, Failures and SlowCalls are counted since the current window started
+	Calls     int
+	Failures  int
+	SlowCalls int
+	// OpenedAt is when the breaker last opened, zero if it never has
+	OpenedAt time.Time
+}
+
+// Breaker is a circuit breaker, it is safe for concurrent use
+type Breaker struct {
+	opts Options
+	now  func() time.Time
+	// onChange is called with the lock held whenever the state changes
+	onChange func(from, to State)
+
+	mu          sync.Mutex
+	state       State
+	windowStart time.Time
+	openedAt    time.Time
+	calls       int
+	failures    int
+	slowCalls   int
+	// trials counts the trial calls made since the breaker became half-open
+	trials    int
+	successes int
+}
+
+// New creates a closed breaker, onChange is optional and is called on every state change
+func New(opts Options, onChange func(from, to State)) *Breaker {
+	return &Breaker{
+		opts:     opts,
+		now:      time.Now,
+		onChange: onChange,
+	}
+}
+
+// Allow returns ErrOpen when a call must not be made. Otherwise the call is made and done
+// is called with whether it failed, its duration is measured from when Allow returned
+func (b *Breaker) Allow() (done func(failed bool), err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := b.now()
+
+	switch b.state {
+	case Open:
+		if now.Sub(b.openedAt) < b.opts.OpenTimeout {
+			return nil, ErrOpen
+		}
+
+		b.setState(HalfOpen, now)
+	case Closed:
+		if now.Sub(b.windowStart) >= b.opts.Window {
+			b.resetWindow(now)
+		}
+	}
+
+	if b.state == HalfOpen {
+		if b.trials >= b.opts.HalfOpenCalls {
+			return nil, ErrOpen
+		}
+
+		b.trials++
+	}
+
+	state := b.state
+
+	return func(failed bool) {
+		b.record(state, failed, b.now().Sub(now))
+	}, nil
+}
+
+// Do calls fn unless the breaker is open, fn failed if it returned an error
+func (b *Breaker) Do(fn func() error) error {
+	done, err := b.Allow()
+	if err != nil {
+		return err
+	}
+
+	err = fn()
+	done(err != nil)
+
+	return err
+}
+
+// Stats returns a snapshot of the breaker
+func (b *Breaker) Stats() Stats {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return Stats{
+		State:     b.state,
+		Calls:     b.calls,
+		Failures:  b.failures,
+		SlowCalls: b.slowCalls,
+		OpenedAt:  b.openedAt,
+	}
+}
+
+func (b *Breaker) record(state State, failed bool, took time.Duration) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	// the outcome of a call made before the state changed says nothing about the new state
+	if state != b.state {
+		return
+	}
+
+	slow := !failed && b.opts.SlowCall > 0 && took > b.opts.SlowCall
+	now := b.now()
+
+	if b.state == HalfOpen {
+		if failed || slow {
+			b.setState(Open, now)
+
+			return
+		}
+
+		b.successes++
+		if b.successes >= b.opts.HalfOpenCalls {
+			b.setState(Closed, now)
+		}
+
+		return
+	}
+
+	b.calls++
+
+	if failed {
+		b.failures++
+	}
+
+	if slow {
+		b.slowCalls++
+	}
+
+	if b.calls < b.opts.MinCalls {
+		return
+	}
+
+	if (b.opts.ErrorRate > 0 && rate(b.failures, b.calls) >= b.opts.ErrorRate) ||
+		(b.opts.SlowCallRate > 0 && rate(b.slowCalls, b.calls) >= b.opts.SlowCallRate) {
+		b.setState(Open, now)
+	}
+}
+
+func (b *Breaker) setState(state State, now time.Time) {
+	from := b.state
+	b.state = state
+
+	switch state {
+	case Open:
+		b.openedAt = now
+	case HalfOpen:
+		b.trials = 0
+		b.successes = 0
+	case Closed:
+		b.resetWindow(now)
+	}
+
+	if b.onChange != nil {
+		b.onChange(from, state)
+	}
+}
+
+func (b *Breaker) resetWindow(now time.Time) {
+	b.windowStart = now
+	b.calls = 0
+	b.failures = 0
+	b.slowCalls = 0
+}
+
+func rate(n, total int) float64 {
+	return float64(n) / float64(total)
+}
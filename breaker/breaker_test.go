@@ -0,0 +1,159 @@
+package breaker
+
+import (
+	"errors"
+	"flag"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var _errFailed = errors.New("failed")
+
+// clock is a fake time source the tests move forward by hand
+type clock struct {
+	now time.Time
+}
+
+func (c *clock) Now() time.Time {
+	return c.now
+}
+
+func (c *clock) Advance(d time.Duration) {
+	c.now = c.now.Add(d)
+}
+
+func newBreaker(opts Options) (*Breaker, *clock, *[]string) {
+	c := &clock{now: time.Date(2021, 10, 1, 12, 0, 0, 0, time.UTC)}
+
+	var changes []string
+
+	b := New(opts, func(from, to State) {
+		changes = append(changes, from.String()+"->"+to.String())
+	})
+	b.now = c.Now
+
+	return b, c, &changes
+}
+
+func testOptions() Options {
+	return Options{
+		Window:        time.Minute,
+		MinCalls:      4,
+		ErrorRate:     0.5,
+		SlowCall:      time.Second,
+		SlowCallRate:  0.75,
+		OpenTimeout:   30 * time.Second,
+		HalfOpenCalls: 2,
+	}
+}
+
+func TestBreaker_ErrorRate(t *testing.T) {
+	b, c, changes := newBreaker(testOptions())
+
+	// too few calls to open the breaker
+	for i := 0; i < 3; i++ {
+		assert.Equal(t, _errFailed, b.Do(func() error { return _errFailed }))
+	}
+
+	assert.Equal(t, Closed, b.Stats().State)
+
+	assert.Equal(t, _errFailed, b.Do(func() error { return _errFailed }))
+	assert.Equal(t, Open, b.Stats().State)
+	assert.Equal(t, c.now, b.Stats().OpenedAt)
+
+	called := false
+	assert.Equal(t, ErrOpen, b.Do(func() error {
+		called = true
+
+		return nil
+	}))
+	assert.False(t, called)
+
+	c.Advance(30 * time.Second)
+
+	// only HalfOpenCalls trial calls are let through
+	done1, err := b.Allow()
+	assert.NoError(t, err)
+	done2, err := b.Allow()
+	assert.NoError(t, err)
+	_, err = b.Allow()
+	assert.Equal(t, ErrOpen, err)
+	assert.Equal(t, HalfOpen, b.Stats().State)
+
+	done1(false)
+	done2(false)
+
+	assert.Equal(t, Stats{State: Closed, OpenedAt: c.now.Add(-30 * time.Second)}, b.Stats())
+	assert.Equal(t, []string{"CLOSED->OPEN", "OPEN->HALF_OPEN", "HALF_OPEN->CLOSED"}, *changes)
+}
+
+func TestBreaker_FailedTrialCall(t *testing.T) {
+	opts := testOptions()
+	opts.MinCalls = 1
+
+	b, c, changes := newBreaker(opts)
+
+	assert.Equal(t, _errFailed, b.Do(func() error { return _errFailed }))
+
+	c.Advance(30 * time.Second)
+
+	assert.Equal(t, _errFailed, b.Do(func() error { return _errFailed }))
+	assert.Equal(t, Open, b.Stats().State)
+	assert.Equal(t, c.now, b.Stats().OpenedAt)
+	assert.Equal(t, []string{"CLOSED->OPEN", "OPEN->HALF_OPEN", "HALF_OPEN->OPEN"}, *changes)
+}
+
+func TestBreaker_SlowCalls(t *testing.T) {
+	b, c, _ := newBreaker(testOptions())
+
+	slow := func() error {
+		c.Advance(2 * time.Second)
+
+		return nil
+	}
+
+	assert.NoError(t, b.Do(func() error { return nil }))
+
+	for i := 0; i < 2; i++ {
+		assert.NoError(t, b.Do(slow))
+	}
+
+	assert.Equal(t, Stats{State: Closed, Calls: 3, SlowCalls: 2}, b.Stats())
+
+	assert.NoError(t, b.Do(slow))
+	assert.Equal(t, Open, b.Stats().State)
+}
+
+func TestBreaker_Window(t *testing.T) {
+	b, c, _ := newBreaker(testOptions())
+
+	for i := 0; i < 3; i++ {
+		assert.Equal(t, _errFailed, b.Do(func() error { return _errFailed }))
+	}
+
+	// the failures of the previous window are forgotten
+	c.Advance(time.Minute)
+
+	assert.NoError(t, b.Do(func() error { return nil }))
+	assert.Equal(t, Stats{State: Closed, Calls: 1}, b.Stats())
+}
+
+func TestOptions_RegisterFlags(t *testing.T) {
+	opts := DefaultOptions()
+
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	opts.RegisterFlags(fs)
+
+	err := fs.Parse([]string{"-breaker-window", "1m", "-breaker-error-rate", "0.25", "-breaker-half-open-calls", "1"})
+
+	assert.NoError(t, err)
+
+	want := DefaultOptions()
+	want.Window = time.Minute
+	want.ErrorRate = 0.25
+	want.HalfOpenCalls = 1
+
+	assert.Equal(t, want, opts)
+}
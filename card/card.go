@@ -0,0 +1,220 @@
+package card
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"payments_gateway/model"
+	protos "payments_gateway/protos"
+)
+
+// FieldError describes why a field of the card details is invalid, Field is the name
+// of the field in the ProcessPaymentRequest
+type FieldError struct {
+	Field       string
+	Description string
+}
+
+func (e FieldError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Field, e.Description)
+}
+
+// Errors is every field error found when validating card details
+type Errors []FieldError
+
+func (e Errors) Error() string {
+	out := make([]string, 0, len(e))
+	for _, fieldErr := range e {
+		out = append(out, fieldErr.Error())
+	}
+
+	return strings.Join(out, "; ")
+}
+
+// brand holds the card number rules of a card scheme
+type brand struct {
+	prefixes   [][2]int // inclusive ranges of the leading digits
+	lengths    []int
+	cvvDigits  int
+	prefixSize int
+}
+
+var _brands = map[protos.CardType]brand{
+	protos.CardType_VISA: {
+		prefixes:   [][2]int{{4, 4}},
+		prefixSize: 1,
+		lengths:    []int{13, 16, 19},
+		cvvDigits:  3,
+	},
+	protos.CardType_MASTERCARD: {
+		prefixes:   [][2]int{{2221, 2720}, {5100, 5599}},
+		prefixSize: 4,
+		lengths:    []int{16},
+		cvvDigits:  3,
+	},
+	protos.CardType_AMERICAN_EXPRESS: {
+		prefixes:   [][2]int{{34, 34}, {37, 37}},
+		prefixSize: 2,
+		lengths:    []int{15},
+		cvvDigits:  4,
+	},
+}
+
+// Validate checks the card details before they are sent to the acquiring bank.
+// A card is valid until the end of its expiry month. The CVV is an integer in the API so
+// its leading zeros are lost, e.g. 042 arrives as 42: only CVVs with more digits than the
+// brand uses are rejected, shorter ones are left to the acquirer
+func Validate(card model.Card, now time.Time) error {
+	var errs Errors
+
+	declared, knownType := protos.CardType_value[card.CardType]
+	if !knownType {
+		errs = append(errs, FieldError{Field: "card_type", Description: "unsupported card type"})
+	}
+
+	switch {
+	case !isDigits(card.CardNum):
+		errs = append(errs, FieldError{Field: "card_number", Description: "must only contain digits"})
+	case !Luhn(card.CardNum):
+		errs = append(errs, FieldError{Field: "card_number", Description: "failed the Luhn check"})
+	default:
+		detected, ok := Brand(card.CardNum)
+		if !ok {
+			errs = append(errs, FieldError{Field: "card_number", Description: "card brand is not supported"})
+
+			break
+		}
+
+		if !validLength(_brands[detected], len(card.CardNum)) {
+			errs = append(errs, FieldError{Field: "card_number", Description: fmt.Sprintf("invalid length for %s", detected)})
+		}
+
+		if knownType && detected != protos.CardType(declared) {
+			errs = append(errs, FieldError{Field: "card_type", Description: fmt.Sprintf("card number belongs to %s", detected)})
+		}
+
+		if max := pow10(_brands[detected].cvvDigits); card.Cvv < 0 || card.Cvv >= max {
+			errs = append(errs, FieldError{Field: "cvv", Description: fmt.Sprintf("must be %d digits for %s", _brands[detected].cvvDigits, detected)})
+		}
+	}
+
+	if err := validExpiry(card.Expiry, now); err != nil {
+		errs = append(errs, *err)
+	}
+
+	if len(errs) > 0 {
+		return errs
+	}
+
+	return nil
+}
+
+// Luhn reports whether the check digit of a card number is valid
+func Luhn(number string) bool {
+	if len(number) < 2 || !isDigits(number) {
+		return false
+	}
+
+	sum := 0
+	double := false
+
+	for i := len(number) - 1; i >= 0; i-- {
+		digit := int(number[i] - '0')
+
+		if double {
+			digit *= 2
+			if digit > 9 {
+				digit -= 9
+			}
+		}
+
+		sum += digit
+		double = !double
+	}
+
+	return sum%10 == 0
+}
+
+// Brand detects the card scheme from the leading digits (BIN) of a card number
+func Brand(number string) (protos.CardType, bool) {
+	for cardType, b := range _brands {
+		if len(number) < b.prefixSize {
+			continue
+		}
+
+		prefix, err := strconv.Atoi(number[:b.prefixSize])
+		if err != nil {
+			continue
+		}
+
+		for _, r := range b.prefixes {
+			if prefix >= r[0] && prefix <= r[1] {
+				return cardType, true
+			}
+		}
+	}
+
+	return 0, false
+}
+
+// validExpiry checks the expiry is formatted as MM/YY or MM/YYYY and has not passed
+func validExpiry(expiry string, now time.Time) *FieldError {
+	parts := strings.Split(expiry, "/")
+	if len(parts) != 2 || len(parts[0]) != 2 || (len(parts[1]) != 2 && len(parts[1]) != 4) || !isDigits(parts[0]+parts[1]) {
+		return &FieldError{Field: "expiry", Description: "must be formatted as MM/YY"}
+	}
+
+	month, _ := strconv.Atoi(parts[0])
+	year, _ := strconv.Atoi(parts[1])
+
+	if month < 1 || month > 12 {
+		return &FieldError{Field: "expiry", Description: "invalid month"}
+	}
+
+	if len(parts[1]) == 2 {
+		year += 2000
+	}
+
+	// the first instant after the card expires
+	expires := time.Date(year, time.Month(month)+1, 1, 0, 0, 0, 0, time.UTC)
+	if !now.Before(expires) {
+		return &FieldError{Field: "expiry", Description: "card has expired"}
+	}
+
+	return nil
+}
+
+func validLength(b brand, length int) bool {
+	for _, l := range b.lengths {
+		if l == length {
+			return true
+		}
+	}
+
+	return false
+}
+
+func isDigits(in string) bool {
+	if in == "" {
+		return false
+	}
+
+	for _, r := range in {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+
+	return true
+}
+
+func pow10(n int) int32 {
+	out := int32(1)
+	for i := 0; i < n; i++ {
+		out *= 10
+	}
+
+	return out
+}
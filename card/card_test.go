@@ -0,0 +1,152 @@
+package card
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"payments_gateway/model"
+	protos "payments_gateway/protos"
+)
+
+func TestLuhn(t *testing.T) {
+	assert.True(t, Luhn("378282246310005"))
+	assert.True(t, Luhn("4111111111111111"))
+	assert.True(t, Luhn("5555555555554444"))
+	assert.False(t, Luhn("4111111111111112"))
+	assert.False(t, Luhn("41111111a1111111"))
+	assert.False(t, Luhn(""))
+}
+
+func TestBrand(t *testing.T) {
+	tests := []struct {
+		number string
+		want   protos.CardType
+		ok     bool
+	}{
+		{number: "4111111111111111", want: protos.CardType_VISA, ok: true},
+		{number: "5555555555554444", want: protos.CardType_MASTERCARD, ok: true},
+		{number: "2223003122003222", want: protos.CardType_MASTERCARD, ok: true},
+		{number: "378282246310005", want: protos.CardType_AMERICAN_EXPRESS, ok: true},
+		{number: "341111111111111", want: protos.CardType_AMERICAN_EXPRESS, ok: true},
+		{number: "6011111111111117", ok: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.number, func(t *testing.T) {
+			got, ok := Brand(tt.number)
+			assert.Equal(t, tt.ok, ok)
+			if tt.ok {
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	now := time.Date(2026, time.October, 17, 12, 0, 0, 0, time.UTC)
+
+	amex := model.Card{
+		Name:     "Bruce",
+		Surname:  "Wayne",
+		CardType: protos.CardType_AMERICAN_EXPRESS.String(),
+		CardNum:  "378282246310005",
+		Expiry:   "04/30",
+		Cvv:      3421,
+	}
+
+	with := func(change func(c *model.Card)) model.Card {
+		c := amex
+		change(&c)
+
+		return c
+	}
+
+	tests := []struct {
+		name string
+		card model.Card
+		err  string
+	}{
+		{
+			name: "valid american express card",
+			card: amex,
+		},
+		{
+			name: "valid visa card with four digit expiry year",
+			card: model.Card{CardType: "VISA", CardNum: "4111111111111111", Expiry: "10/2026", Cvv: 123},
+		},
+		{
+			name: "failed luhn check",
+			card: with(func(c *model.Card) { c.CardNum = "378282246310006" }),
+			err:  "card_number: failed the Luhn check",
+		},
+		{
+			name: "card number with spaces",
+			card: with(func(c *model.Card) { c.CardNum = "3782 822463 10005" }),
+			err:  "card_number: must only contain digits",
+		},
+		{
+			name: "card type does not match the BIN",
+			card: with(func(c *model.Card) { c.CardType = "VISA" }),
+			err:  "card_type: card number belongs to AMERICAN_EXPRESS",
+		},
+		{
+			name: "visa number of the wrong length",
+			card: model.Card{CardType: "VISA", CardNum: "41111111111114", Expiry: "04/30", Cvv: 123},
+			err:  "card_number: invalid length for VISA",
+		},
+		{
+			name: "unsupported brand",
+			card: with(func(c *model.Card) { c.CardNum = "6011111111111117" }),
+			err:  "card_number: card brand is not supported",
+		},
+		{
+			name: "cvv too long for visa",
+			card: model.Card{CardType: "VISA", CardNum: "4111111111111111", Expiry: "04/30", Cvv: 1234},
+			err:  "cvv: must be 3 digits for VISA",
+		},
+		{
+			name: "short visa cvv may have lost its leading zeros",
+			card: model.Card{CardType: "VISA", CardNum: "4111111111111111", Expiry: "04/30", Cvv: 42},
+		},
+		{
+			name: "short american express cvv may have lost its leading zeros",
+			card: with(func(c *model.Card) { c.Cvv = 123 }),
+		},
+		{
+			name: "expired card",
+			card: with(func(c *model.Card) { c.Expiry = "09/26" }),
+			err:  "expiry: card has expired",
+		},
+		{
+			name: "invalid expiry format",
+			card: with(func(c *model.Card) { c.Expiry = "23/4" }),
+			err:  "expiry: must be formatted as MM/YY",
+		},
+		{
+			name: "invalid expiry month",
+			card: with(func(c *model.Card) { c.Expiry = "13/30" }),
+			err:  "expiry: invalid month",
+		},
+		{
+			name: "every invalid field is reported",
+			card: with(func(c *model.Card) {
+				c.CardType = "VISA"
+				c.Expiry = "01/20"
+			}),
+			err: "card_type: card number belongs to AMERICAN_EXPRESS; expiry: card has expired",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Validate(tt.card, now)
+			if tt.err != "" {
+				assert.EqualError(t, err, tt.err)
+
+				return
+			}
+
+			assert.NoError(t, err)
+		})
+	}
+}
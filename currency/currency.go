@@ -0,0 +1,133 @@
+package currency
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// NoMinorUnit is the exponent of ISO 4217 codes such as precious metals and
+// bond market units that have no minor unit and cannot be used for payments
+const NoMinorUnit = -1
+
+var (
+	ErrUnknownCurrency = errors.New("unknown currency")
+	ErrNotPayable      = errors.New("currency cannot be used for payments")
+	ErrNotAccepted     = errors.New("currency is not accepted")
+)
+
+// Currency is an ISO 4217 currency
+type Currency struct {
+	Code     string
+	Numeric  string
+	Exponent int
+	Name     string
+}
+
+// Lookup finds a currency by its alphabetic code
+func Lookup(code string) (Currency, bool) {
+	c, ok := _iso4217[code]
+
+	return c, ok
+}
+
+// Normalize trims and upper cases a currency code and checks that it is an
+// ISO 4217 currency that can be used for payments
+func Normalize(code string) (string, error) {
+	code = strings.ToUpper(strings.TrimSpace(code))
+
+	c, ok := Lookup(code)
+	if !ok {
+		return "", fmt.Errorf("%w: %q", ErrUnknownCurrency, code)
+	}
+
+	if c.Exponent == NoMinorUnit {
+		return "", fmt.Errorf("%w: %s", ErrNotPayable, code)
+	}
+
+	return code, nil
+}
+
+// Rule limits the amount in minor units of payments made in a currency, zero means no limit
+type Rule struct {
+	MinAmount int64 `json:"min_amount_minor_units"`
+	MaxAmount int64 `json:"max_amount_minor_units"`
+}
+
+// AmountError is returned when an amount is outside the limits of its currency
+type AmountError struct {
+	Currency string
+	Amount   int64
+	Rule     Rule
+}
+
+func (e *AmountError) Error() string {
+	if e.Rule.MinAmount != 0 && e.Amount < e.Rule.MinAmount {
+		return fmt.Sprintf("amount is below the minimum of %d %s minor units", e.Rule.MinAmount, e.Currency)
+	}
+
+	return fmt.Sprintf("amount is above the maximum of %d %s minor units", e.Rule.MaxAmount, e.Currency)
+}
+
+// Policy holds the currencies accepted by the gateway and their limits.
+// A nil Policy accepts every payable currency without limits
+type Policy struct {
+	rules map[string]Rule
+}
+
+// NewPolicy creates a policy that only accepts the currencies in rules
+func NewPolicy(rules map[string]Rule) (*Policy, error) {
+	normalized := make(map[string]Rule, len(rules))
+
+	for code, rule := range rules {
+		c, err := Normalize(code)
+		if err != nil {
+			return nil, err
+		}
+
+		if rule.MinAmount < 0 || rule.MaxAmount < 0 || (rule.MaxAmount != 0 && rule.MinAmount > rule.MaxAmount) {
+			return nil, fmt.Errorf("invalid limits for %s", c)
+		}
+
+		normalized[c] = rule
+	}
+
+	return &Policy{rules: normalized}, nil
+}
+
+// LoadPolicy reads a policy from a JSON file of currency codes to rules, e.g.
+// {"GBP": {"min_amount_minor_units": 100, "max_amount_minor_units": 500000}}
+func LoadPolicy(path string) (*Policy, error) {
+	body, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var rules map[string]Rule
+	if err := json.Unmarshal(body, &rules); err != nil {
+		return nil, fmt.Errorf("error parsing currency policy %s: %w", path, err)
+	}
+
+	return NewPolicy(rules)
+}
+
+// Check returns an error when the currency is not accepted or the amount is outside its limits.
+// The currency must already be normalized
+func (p *Policy) Check(code string, amount int64) error {
+	if p == nil {
+		return nil
+	}
+
+	rule, ok := p.rules[code]
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrNotAccepted, code)
+	}
+
+	if (rule.MinAmount != 0 && amount < rule.MinAmount) || (rule.MaxAmount != 0 && amount > rule.MaxAmount) {
+		return &AmountError{Currency: code, Amount: amount, Rule: rule}
+	}
+
+	return nil
+}
@@ -0,0 +1,153 @@
+package currency
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalize(t *testing.T) {
+	tests := []struct {
+		name string
+		code string
+		want string
+		err  error
+	}{
+		{
+			name: "valid currency",
+			code: "GBP",
+			want: "GBP",
+		},
+		{
+			name: "lower case with whitespace",
+			code: "gbp ",
+			want: "GBP",
+		},
+		{
+			name: "unknown currency",
+			code: "XYZ",
+			err:  ErrUnknownCurrency,
+		},
+		{
+			name: "precious metal",
+			code: "XAU",
+			err:  ErrNotPayable,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Normalize(tt.code)
+			if tt.err != nil {
+				assert.True(t, errors.Is(err, tt.err))
+
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestLookup(t *testing.T) {
+	c, ok := Lookup("JPY")
+	assert.True(t, ok)
+	assert.Equal(t, Currency{Code: "JPY", Numeric: "392", Exponent: 0, Name: "Yen"}, c)
+
+	c, ok = Lookup("KWD")
+	assert.True(t, ok)
+	assert.Equal(t, 3, c.Exponent)
+
+	_, ok = Lookup("gbp")
+	assert.False(t, ok)
+}
+
+func TestPolicy_Check(t *testing.T) {
+	policy, err := NewPolicy(map[string]Rule{
+		"gbp": {MinAmount: 100, MaxAmount: 500000},
+		"EUR": {},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		policy   *Policy
+		currency string
+		amount   int64
+		err      string
+	}{
+		{
+			name:     "within limits",
+			policy:   policy,
+			currency: "GBP",
+			amount:   2050,
+		},
+		{
+			name:     "currency without limits",
+			policy:   policy,
+			currency: "EUR",
+			amount:   100000000,
+		},
+		{
+			name:     "below the minimum",
+			policy:   policy,
+			currency: "GBP",
+			amount:   99,
+			err:      "amount is below the minimum of 100 GBP minor units",
+		},
+		{
+			name:     "above the maximum",
+			policy:   policy,
+			currency: "GBP",
+			amount:   500001,
+			err:      "amount is above the maximum of 500000 GBP minor units",
+		},
+		{
+			name:     "currency not accepted",
+			policy:   policy,
+			currency: "USD",
+			amount:   2050,
+			err:      "currency is not accepted: USD",
+		},
+		{
+			name:     "no policy accepts everything",
+			currency: "USD",
+			amount:   2050,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.policy.Check(tt.currency, tt.amount)
+			if tt.err != "" {
+				assert.EqualError(t, err, tt.err)
+
+				return
+			}
+
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestLoadPolicy(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "currencies.json")
+
+	if err := os.WriteFile(path, []byte(`{"GBP": {"min_amount_minor_units": 100, "max_amount_minor_units": 50}}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err := LoadPolicy(path)
+	assert.EqualError(t, err, "invalid limits for GBP")
+
+	if err := os.WriteFile(path, []byte(`{"GBP": {"max_amount_minor_units": 500000}, "XYZ": {}}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = LoadPolicy(path)
+	assert.True(t, errors.Is(err, ErrUnknownCurrency))
+}
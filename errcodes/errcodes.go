@@ -0,0 +1,179 @@
+package errcodes
+
+import (
+	"github.com/golang/protobuf/proto"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Domain identifies the payments gateway as the source of an error in google.rpc.ErrorInfo
+const Domain = "payments-gateway"
+
+// Code is a stable machine-readable error code. Codes are returned in protos.Error.code and
+// as the reason of the google.rpc.ErrorInfo attached to gRPC errors. Codes must never be
+// renamed or reused as clients branch on them
+type Code string
+
+const (
+	MissingParameter        Code = "MISSING_PARAMETER"
+	InvalidCardDetails      Code = "INVALID_CARD_DETAILS"
+	InvalidAmount           Code = "INVALID_AMOUNT"
+	AmountOutOfRange        Code = "AMOUNT_OUT_OF_RANGE"
+	UnsupportedCurrency     Code = "UNSUPPORTED_CURRENCY"
+	CurrencyNotAccepted     Code = "CURRENCY_NOT_ACCEPTED"
+	CardVerificationFailed  Code = "CARD_VERIFICATION_FAILED"
+	AuthorizationDeclined   Code = "AUTHORIZATION_DECLINED"
+	UnknownResponseCode     Code = "UNKNOWN_RESPONSE_CODE"
+	ModificationDeclined    Code = "MODIFICATION_DECLINED"
+	RefundInProgress        Code = "REFUND_IN_PROGRESS"
+	PaymentNotFound         Code = "PAYMENT_NOT_FOUND"
+	IllegalStatusTransition Code = "ILLEGAL_STATUS_TRANSITION"
+	IdempotencyKeyReused    Code = "IDEMPOTENCY_KEY_REUSED"
+	IdempotencyKeyInUse     Code = "IDEMPOTENCY_KEY_IN_USE"
+	BankUnavailable         Code = "BANK_UNAVAILABLE"
+	CardTokenNotFound       Code = "CARD_TOKEN_NOT_FOUND"
+	VaultNotConfigured      Code = "VAULT_NOT_CONFIGURED"
+	ReportNotFound          Code = "RECONCILIATION_REPORT_NOT_FOUND"
+	InvalidFilter           Code = "INVALID_FILTER"
+	InvalidPageToken        Code = "INVALID_PAGE_TOKEN"
+	InvalidMetadata         Code = "INVALID_METADATA"
+	MerchantReferenceInUse  Code = "MERCHANT_REFERENCE_IN_USE"
+	InvalidWebhookEndpoint  Code = "INVALID_WEBHOOK_ENDPOINT"
+	WebhookEndpointNotFound Code = "WEBHOOK_ENDPOINT_NOT_FOUND"
+	WebhookDeliveryNotFound Code = "WEBHOOK_DELIVERY_NOT_FOUND"
+	WatchUnavailable        Code = "WATCH_UNAVAILABLE"
+	Unauthenticated         Code = "UNAUTHENTICATED"
+	PermissionDenied        Code = "PERMISSION_DENIED"
+	Internal                Code = "INTERNAL"
+)
+
+// Catalogue describes every error code
+var Catalogue = map[Code]string{
+	MissingParameter:        "a required field is missing, the field violations name it",
+	InvalidCardDetails:      "the card details failed validation, the field violations name each invalid field",
+	InvalidAmount:           "the amount is negative, has fractions of a minor unit or exceeds the payment amount",
+	AmountOutOfRange:        "the amount is outside the limits accepted for the currency",
+	UnsupportedCurrency:     "the currency is not an ISO 4217 currency that can be used for payments",
+	CurrencyNotAccepted:     "the currency is not accepted by the gateway",
+	CardVerificationFailed:  "the acquiring bank could not verify the card",
+	AuthorizationDeclined:   "the acquiring bank declined the authorization",
+	UnknownResponseCode:     "the acquiring bank returned a response code the gateway cannot map, the payment is not authorized until it is resolved",
+	ModificationDeclined:    "the acquiring bank declined the capture, void or refund",
+	RefundInProgress:        "another refund of the payment is being sent to the acquiring bank",
+	PaymentNotFound:         "no payment exists with the reference",
+	IllegalStatusTransition: "the payment status does not allow the operation",
+	IdempotencyKeyReused:    "the idempotency key was used with a different request",
+	IdempotencyKeyInUse:     "a request with the idempotency key is still being processed",
+	BankUnavailable:         "the acquiring bank could not be reached",
+	CardTokenNotFound:       "no card is stored in the vault with the token",
+	VaultNotConfigured:      "the gateway was started without a card vault",
+	ReportNotFound:          "no reconciliation report exists with the ID",
+	InvalidFilter:           "a ListPayments filter is invalid, the field violations name each invalid field",
+	InvalidPageToken:        "the page token is malformed or was issued for different filters",
+	InvalidMetadata:         "the merchant reference or metadata exceed their limits, the field violations name each invalid field",
+	MerchantReferenceInUse:  "the merchant reference was used by another payment and the gateway enforces unique references",
+	InvalidWebhookEndpoint:  "the webhook endpoint URL or statuses are invalid, the field violations name each invalid field",
+	WebhookEndpointNotFound: "the merchant has no webhook endpoint with the ID",
+	WebhookDeliveryNotFound: "the merchant has no webhook delivery with the ID, or its endpoint was deleted",
+	WatchUnavailable:        "the payment status changes cannot be watched right now or the watch was interrupted, changes may have been missed and the watch should be started again",
+	Unauthenticated:         "the API key is missing, unknown or revoked",
+	PermissionDenied:        "the merchant is not allowed to call the method",
+	Internal:                "an unexpected error occurred",
+}
+
+// FieldViolation creates a google.rpc.BadRequest field violation, field is the name of the
+// field in the request
+func FieldViolation(field, description string) *errdetails.BadRequest_FieldViolation {
+	return &errdetails.BadRequest_FieldViolation{Field: field, Description: description}
+}
+
+// Status creates a gRPC status with the code attached as google.rpc.ErrorInfo and any field
+// violations attached as google.rpc.BadRequest
+func Status(c codes.Code, code Code, msg string, violations ...*errdetails.BadRequest_FieldViolation) *status.Status {
+	st := status.New(c, msg)
+
+	details := []proto.Message{&errdetails.ErrorInfo{Reason: string(code), Domain: Domain}}
+	if len(violations) > 0 {
+		details = append(details, &errdetails.BadRequest{FieldViolations: violations})
+	}
+
+	withDetails, err := st.WithDetails(details...)
+	if err != nil {
+		return st
+	}
+
+	return withDetails
+}
+
+// Error is Status returned as an error
+func Error(c codes.Code, code Code, msg string, violations ...*errdetails.BadRequest_FieldViolation) error {
+	return Status(c, code, msg, violations...).Err()
+}
+
+// FromError returns the code attached to a gRPC error, or an empty code when there is none
+func FromError(err error) Code {
+	st, ok := status.FromError(err)
+	if !ok {
+		return ""
+	}
+
+	for _, detail := range st.Details() {
+		if info, ok := detail.(*errdetails.ErrorInfo); ok && info.GetDomain() == Domain {
+			return Code(info.GetReason())
+		}
+	}
+
+	return ""
+}
+
+// FieldViolations returns the google.rpc.BadRequest field violations attached to a gRPC error
+func FieldViolations(err error) []*errdetails.BadRequest_FieldViolation {
+	st, ok := status.FromError(err)
+	if !ok {
+		return nil
+	}
+
+	for _, detail := range st.Details() {
+		if badRequest, ok := detail.(*errdetails.BadRequest); ok {
+			return badRequest.GetFieldViolations()
+		}
+	}
+
+	return nil
+}
+
+// _paymentResource is the resource type of the google.rpc.ResourceInfo naming the payment an error is about
+const _paymentResource = "payment"
+
+// WithPayment attaches the ref of the payment an error is about as google.rpc.ResourceInfo, so the
+// merchant can look up a payment that was stored although the request failed
+func WithPayment(err error, ref string) error {
+	st, ok := status.FromError(err)
+	if !ok {
+		return err
+	}
+
+	withDetails, detailErr := st.WithDetails(&errdetails.ResourceInfo{ResourceType: _paymentResource, ResourceName: ref})
+	if detailErr != nil {
+		return err
+	}
+
+	return withDetails.Err()
+}
+
+// PaymentRef returns the ref of the payment attached to a gRPC error, or an empty ref when there is none
+func PaymentRef(err error) string {
+	st, ok := status.FromError(err)
+	if !ok {
+		return ""
+	}
+
+	for _, detail := range st.Details() {
+		if info, ok := detail.(*errdetails.ResourceInfo); ok && info.GetResourceType() == _paymentResource {
+			return info.GetResourceName()
+		}
+	}
+
+	return ""
+}
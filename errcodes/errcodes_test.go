@@ -0,0 +1,54 @@
+package errcodes
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestError(t *testing.T) {
+	err := Error(codes.InvalidArgument, MissingParameter, "missing parameter", FieldViolation("ref", "must be set"))
+
+	assert.Equal(t, "rpc error: code = InvalidArgument desc = missing parameter", err.Error())
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	assert.Equal(t, MissingParameter, FromError(err))
+
+	violations := FieldViolations(err)
+	if assert.Len(t, violations, 1) {
+		assert.Equal(t, "ref", violations[0].GetField())
+		assert.Equal(t, "must be set", violations[0].GetDescription())
+	}
+}
+
+func TestFromError_WithoutCode(t *testing.T) {
+	assert.Equal(t, Code(""), FromError(status.Error(codes.Internal, "internal")))
+	assert.Equal(t, Code(""), FromError(errors.New("not a status")))
+	assert.Nil(t, FieldViolations(Error(codes.NotFound, PaymentNotFound, "payment not found")))
+}
+
+func TestWithPayment(t *testing.T) {
+	err := WithPayment(Error(codes.Internal, BankUnavailable, "authorize transaction"), "ref1")
+
+	assert.Equal(t, "rpc error: code = Internal desc = authorize transaction", err.Error())
+	assert.Equal(t, BankUnavailable, FromError(err))
+	assert.Equal(t, "ref1", PaymentRef(err))
+
+	assert.Equal(t, "", PaymentRef(Error(codes.Internal, BankUnavailable, "authorize transaction")))
+	assert.Equal(t, "", PaymentRef(errors.New("not a status")))
+}
+
+func TestCatalogue(t *testing.T) {
+	for _, code := range []Code{
+		MissingParameter, InvalidCardDetails, InvalidAmount, AmountOutOfRange, UnsupportedCurrency,
+		CurrencyNotAccepted, CardVerificationFailed, AuthorizationDeclined, UnknownResponseCode, ModificationDeclined,
+		RefundInProgress, PaymentNotFound, IllegalStatusTransition, IdempotencyKeyReused, IdempotencyKeyInUse,
+		BankUnavailable, CardTokenNotFound, VaultNotConfigured, ReportNotFound, InvalidFilter, InvalidPageToken,
+		InvalidMetadata, MerchantReferenceInUse, InvalidWebhookEndpoint, WebhookEndpointNotFound, WebhookDeliveryNotFound,
+		WatchUnavailable, Unauthenticated, PermissionDenied, Internal,
+	} {
+		assert.NotEmpty(t, Catalogue[code], code)
+	}
+}
@@ -1,6 +1,11 @@
 package model
 
-import protos "payments_gateway/protos"
+import (
+	"time"
+
+	"payments_gateway/money"
+	protos "payments_gateway/protos"
+)
 
 type Card struct {
 	Name     string
@@ -13,10 +18,213 @@ type Card struct {
 }
 
 type Transaction struct {
+	RefID  string
+	Card   Card
+	Amount money.Money
+}
+
+// Modification is a change made to a previously authorized transaction
+// such as a capture, void or refund
+type Modification struct {
+	RefID  string
+	Amount money.Money
+}
+
+// StatusUpdate describes a change to the status of a stored payment.
+// CapturedAmount and RefundedAmount are minor units added to the running totals of the payment
+type StatusUpdate struct {
+	RefID          string
+	Status         protos.Status
+	Reason         string
+	Source         protos.StatusSource
+	CapturedAmount int64
+	RefundedAmount int64
+}
+
+// StatusChangeEvent is a stored change to the status of a payment. ID orders the changes of a payment
+type StatusChangeEvent struct {
+	ID                int64
+	RefID             string
+	MerchantID        string
+	MerchantReference string
+	Change            *protos.PaymentStatusChange
+}
+
+// PendingPayment is a PENDING or UNKNOWN_RESPONSE_CODE payment claimed to be inquired about with its
+// acquirer, or an AUTHORIZING payment claimed to have its authorization reversed. Attempts counts the
+// inquiries or reversals made, including this one. Expired payments reached their maximum age unresolved
+type PendingPayment struct {
 	RefID    string
-	Card     Card
-	Amount   float64
-	Currency string
+	Acquirer string
+	Status   protos.Status
+	Amount   money.Money
+	Attempts int
+	Expired  bool
+}
+
+// Merchant is a caller of the gateway authenticated with one of its API keys. Operators may also
+// call the RPCs that are not scoped to a merchant, such as ListAcquirers
+type Merchant struct {
+	ID       string
+	Name     string
+	Operator bool
+}
+
+// Authorization is the outcome of authorizing a new payment with the acquirer it was routed to.
+// ResponseCode is the raw code the acquirer returned, Status is what it maps to. Source is who set
+// the status, the acquirer when it is not set
+type Authorization struct {
+	Acquirer     string
+	Status       protos.Status
+	Reason       string
+	ResponseCode string
+	Source       protos.StatusSource
+}
+
+// SettlementBatch is a batch of captured payments submitted to their acquirer for settlement,
+// the amount of each transaction is the captured amount less any refunds
+type SettlementBatch struct {
+	ID           string
+	Acquirer     string
+	Transactions []*Transaction
+}
+
+// SettlementResult counts the payments of a submitted batch that were settled and that failed
+type SettlementResult struct {
+	Settled int
+	Failed  int
+}
+
+// ReconciliationRecord compares a payment settled by the gateway to the settlement file of its
+// acquirer. The amount of the side the payment is missing from is zero
+type ReconciliationRecord struct {
+	RefID         string
+	Result        protos.ReconciliationResult
+	GatewayAmount money.Money
+	BankAmount    money.Money
+}
+
+// ReconciliationReport is the outcome of reconciling a settlement file of an acquirer
+type ReconciliationReport struct {
+	ID        string
+	Acquirer  string
+	FileName  string
+	CreatedAt time.Time
+	Records   []ReconciliationRecord
+}
+
+// PaymentFilter selects the payments of a merchant returned by ListPayments. Zero fields match every
+// payment. Payments are ordered by insert time and ref, After is the last payment of the previous page
+type PaymentFilter struct {
+	Statuses     []protos.Status
+	From         time.Time
+	To           time.Time
+	Currency     string
+	MinAmount    int64
+	MaxAmount    int64
+	CardLastFour string
+	Email        string
+	Reference    string
+	Metadata     map[string]string
+	OldestFirst  bool
+	After        *PaymentCursor
+	Limit        int
+}
+
+// PaymentCursor is the position of a payment in the ListPayments order
+type PaymentCursor struct {
+	InsertTime time.Time
+	RefID      string
+}
+
+// WebhookEndpoint is a URL the payment status changes of a merchant are POSTed to, signed with its
+// secret. It is sent changes to any of the statuses, or every change when there are none
+type WebhookEndpoint struct {
+	ID         string
+	MerchantID string
+	URL        string
+	Secret     string
+	Statuses   []protos.Status
+}
+
+// WebhookDelivery is a payment status change claimed to be sent to a webhook endpoint. EventID is the
+// ID of the change in the payment history, Attempts counts the attempts made before this one
+type WebhookDelivery struct {
+	ID                int64
+	EndpointID        string
+	URL               string
+	Secret            string
+	Attempts          int
+	EventID           int64
+	RefID             string
+	MerchantReference string
+	OldStatus         protos.Status
+	NewStatus         protos.Status
+	Reason            string
+	Source            protos.StatusSource
+	Time              time.Time
+}
+
+// WebhookAttempt is the outcome of attempting a webhook delivery. A delivery that is still
+// DELIVERY_PENDING is attempted again after RetryAfter, ResponseCode is zero when there was no response
+type WebhookAttempt struct {
+	DeliveryID   int64
+	Status       protos.WebhookDeliveryStatus
+	ResponseCode int
+	Error        string
+	RetryAfter   time.Duration
+}
+
+// WebhookDeliveryFilter selects the webhook deliveries of a merchant returned by ListWebhookDeliveries,
+// newest first. Zero fields match every delivery, Before is the last delivery of the previous page
+type WebhookDeliveryFilter struct {
+	Status     protos.WebhookDeliveryStatus
+	EndpointID string
+	RefID      string
+	Before     int64
+	Limit      int
+}
+
+// Types of the payment events written to the outbox
+const (
+	EventPaymentCreated       = "payment.created"
+	EventPaymentStatusChanged = "payment.status_changed"
+)
+
+// OutboxEvent is a payment event written to the outbox in the transaction that changed the payment.
+// Key is the ref of the payment, the events of a key are published in order. Payload is JSON
+type OutboxEvent struct {
+	ID      int64
+	Key     string
+	Type    string
+	Payload []byte
+	Time    time.Time
+}
+
+// IdempotencyRecord is a ProcessPayment request that was made with an idempotency key.
+// Response is nil while the original request is still being processed
+type IdempotencyRecord struct {
+	MerchantID  string
+	Key         string
+	RequestHash string
+	Response    *protos.ProcessPaymentResponse
+}
+
+// VaultedCard is a card stored in the vault. The card number is only kept encrypted with a
+// data key, which is itself encrypted (wrapped) with the key encryption key identified by KeyID.
+// The CVV is never stored
+type VaultedCard struct {
+	Token               string
+	MerchantID          string
+	Name                string
+	Surname             string
+	Postcode            string
+	CardType            string
+	Expiry              string
+	MaskedCardNumber    string
+	EncryptedCardNumber []byte
+	WrappedKey          []byte
+	KeyID               string
 }
 
 func ConvertToCardDetails(request *protos.ProcessPaymentRequest) Card {
@@ -31,11 +239,10 @@ func ConvertToCardDetails(request *protos.ProcessPaymentRequest) Card {
 	}
 }
 
-func ConvertToTransaction(refID string, request *protos.ProcessPaymentRequest) Transaction {
+func ConvertToTransaction(refID string, card Card, amount money.Money) Transaction {
 	return Transaction{
-		RefID:    refID,
-		Card:     ConvertToCardDetails(request),
-		Amount:   request.GetAmount(),
-		Currency: request.GetCurrency(),
+		RefID:  refID,
+		Card:   card,
+		Amount: amount,
 	}
 }
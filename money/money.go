@@ -0,0 +1,97 @@
+package money
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+
+	"payments_gateway/currency"
+)
+
+var (
+	ErrNegativeAmount = errors.New("amount cannot be negative")
+	ErrInvalidAmount  = errors.New("amount is not a number")
+	ErrPrecision      = errors.New("amount has more decimal places than the currency allows")
+)
+
+// _defaultExponent is the number of minor unit digits assumed for codes that are not
+// ISO 4217 currencies with a minor unit
+const _defaultExponent = 2
+
+// Money is an amount in the minor unit of its currency, e.g. pence for GBP
+type Money struct {
+	Amount   int64
+	Currency string
+}
+
+// New creates an amount of money from minor units
+func New(amount int64, currency string) Money {
+	return Money{Amount: amount, Currency: currency}
+}
+
+// Exponent returns the number of digits after the decimal point of the currency major unit
+func Exponent(code string) int {
+	if c, ok := currency.Lookup(strings.ToUpper(code)); ok && c.Exponent != currency.NoMinorUnit {
+		return c.Exponent
+	}
+
+	return _defaultExponent
+}
+
+// FromMajor converts a major unit amount such as 20.5 GBP into minor units.
+// Amounts with fractions of a minor unit are rejected rather than rounded
+func FromMajor(amount float64, currency string) (Money, error) {
+	if math.IsNaN(amount) || math.IsInf(amount, 0) {
+		return Money{}, ErrInvalidAmount
+	}
+
+	if amount < 0 {
+		return Money{}, ErrNegativeAmount
+	}
+
+	// float64(math.MaxInt64) rounds up to 2^63, which no longer fits an int64
+	minor := amount * math.Pow10(Exponent(currency))
+	if minor >= math.MaxInt64 {
+		return Money{}, ErrInvalidAmount
+	}
+
+	rounded := math.Round(minor)
+
+	// floats cannot represent most decimals exactly so allow for representation error
+	if math.Abs(minor-rounded) > 1e-6 {
+		return Money{}, ErrPrecision
+	}
+
+	return Money{Amount: int64(rounded), Currency: currency}, nil
+}
+
+// FromMinorOrMajor returns the minor unit amount when set, otherwise it converts the
+// major unit amount that older clients send
+func FromMinorOrMajor(minor int64, major float64, currency string) (Money, error) {
+	if minor < 0 {
+		return Money{}, ErrNegativeAmount
+	}
+
+	if minor != 0 {
+		return Money{Amount: minor, Currency: currency}, nil
+	}
+
+	return FromMajor(major, currency)
+}
+
+// Major returns the amount in the major unit of the currency, for clients and acquirers
+// that do not support minor units
+func (m Money) Major() float64 {
+	return float64(m.Amount) / math.Pow10(Exponent(m.Currency))
+}
+
+// String formats the amount with the number of decimals of its currency, e.g. "20.50 GBP"
+func (m Money) String() string {
+	exp := Exponent(m.Currency)
+	if exp == 0 {
+		return fmt.Sprintf("%d %s", m.Amount, m.Currency)
+	}
+
+	return fmt.Sprintf("%.*f %s", exp, m.Major(), m.Currency)
+}
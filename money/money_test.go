@@ -0,0 +1,117 @@
+package money
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFromMajor(t *testing.T) {
+	tests := []struct {
+		name     string
+		amount   float64
+		currency string
+		want     Money
+		err      error
+	}{
+		{
+			name:     "two decimal currency",
+			amount:   20.5,
+			currency: "GBP",
+			want:     Money{Amount: 2050, Currency: "GBP"},
+		},
+		{
+			name:     "amount that floats cannot represent exactly",
+			amount:   0.29,
+			currency: "EUR",
+			want:     Money{Amount: 29, Currency: "EUR"},
+		},
+		{
+			name:     "zero decimal currency",
+			amount:   1500,
+			currency: "JPY",
+			want:     Money{Amount: 1500, Currency: "JPY"},
+		},
+		{
+			name:     "three decimal currency",
+			amount:   1.234,
+			currency: "KWD",
+			want:     Money{Amount: 1234, Currency: "KWD"},
+		},
+		{
+			name:     "fraction of a penny",
+			amount:   20.505,
+			currency: "GBP",
+			err:      ErrPrecision,
+		},
+		{
+			name:     "fraction of a yen",
+			amount:   10.5,
+			currency: "JPY",
+			err:      ErrPrecision,
+		},
+		{
+			name:     "negative amount",
+			amount:   -1,
+			currency: "GBP",
+			err:      ErrNegativeAmount,
+		},
+		{
+			name:     "largest amount below 2^63",
+			amount:   math.Nextafter(1<<63, 0),
+			currency: "JPY",
+			want:     Money{Amount: 1<<63 - 1024, Currency: "JPY"},
+		},
+		{
+			name:     "2^63 overflows int64",
+			amount:   1 << 63,
+			currency: "JPY",
+			err:      ErrInvalidAmount,
+		},
+		{
+			name:     "not a number",
+			amount:   math.NaN(),
+			currency: "GBP",
+			err:      ErrInvalidAmount,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := FromMajor(tt.amount, tt.currency)
+			if tt.err != nil {
+				assert.Equal(t, tt.err, err)
+
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestFromMinorOrMajor(t *testing.T) {
+	got, err := FromMinorOrMajor(2050, 0, "GBP")
+	assert.NoError(t, err)
+	assert.Equal(t, Money{Amount: 2050, Currency: "GBP"}, got)
+
+	got, err = FromMinorOrMajor(0, 20.5, "GBP")
+	assert.NoError(t, err)
+	assert.Equal(t, Money{Amount: 2050, Currency: "GBP"}, got)
+
+	_, err = FromMinorOrMajor(-2050, 0, "GBP")
+	assert.Equal(t, ErrNegativeAmount, err)
+}
+
+func TestMoney_Major(t *testing.T) {
+	assert.Equal(t, 20.5, New(2050, "GBP").Major())
+	assert.Equal(t, 1500.0, New(1500, "JPY").Major())
+	assert.Equal(t, 1.234, New(1234, "KWD").Major())
+}
+
+func TestMoney_String(t *testing.T) {
+	assert.Equal(t, "20.50 GBP", New(2050, "GBP").String())
+	assert.Equal(t, "1500 JPY", New(1500, "JPY").String())
+	assert.Equal(t, "1.234 KWD", New(1234, "KWD").String())
+}
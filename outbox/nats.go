@@ -0,0 +1,132 @@
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/nats-io/nats.go"
+
+	"payments_gateway/model"
+)
+
+// NATS publishes events to a NATS server, to the subject "<prefix>.<event type>". Every event carries
+// its ID as the Nats-Msg-Id header so JetStream drops the events published again within its duplicate
+// window. With JetStream acknowledgements an event is only published once a stream stored it,
+// otherwise once the server accepted it
+type NATS struct {
+	url       string
+	prefix    string
+	jetStream bool
+	timeout   time.Duration
+
+	mu   sync.Mutex
+	conn *nats.Conn
+	js   nats.JetStreamContext
+}
+
+// NewNATS creates a NATS publisher for a nats://[user:password@]host:port URL, it connects when the
+// first event is published and again once the client gave up reconnecting
+func NewNATS(rawURL, subjectPrefix string, jetStream bool, timeout time.Duration) (*NATS, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, err
+	}
+
+	if u.Scheme != "nats" || u.Host == "" {
+		return nil, fmt.Errorf("NATS URL %q must be nats://host:port", rawURL)
+	}
+
+	if subjectPrefix == "" {
+		return nil, errors.New("NATS subject prefix must be set")
+	}
+
+	return &NATS{
+		url:       rawURL,
+		prefix:    subjectPrefix,
+		jetStream: jetStream,
+		timeout:   timeout,
+	}, nil
+}
+
+// Publish sends an event and waits for the server, or the stream with JetStream, to accept it
+func (n *NATS) Publish(ctx context.Context, event *model.OutboxEvent) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	if n.conn == nil || n.conn.IsClosed() {
+		if err := n.connect(); err != nil {
+			return fmt.Errorf("connecting to NATS: %w", err)
+		}
+	}
+
+	data, err := json.Marshal(NewMessage(event))
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, n.timeout)
+	defer cancel()
+
+	msg := nats.NewMsg(n.prefix + "." + event.Type)
+	msg.Data = data
+
+	if n.jetStream {
+		_, err := n.js.PublishMsg(msg, nats.MsgId(strconv.FormatInt(event.ID, 10)), nats.Context(ctx))
+		if err != nil {
+			return fmt.Errorf("stream rejected event: %w", err)
+		}
+
+		return nil
+	}
+
+	if n.conn.HeadersSupported() {
+		msg.Header.Set(nats.MsgIdHdr, strconv.FormatInt(event.ID, 10))
+	}
+
+	if err := n.conn.PublishMsg(msg); err != nil {
+		return err
+	}
+
+	return n.conn.FlushWithContext(ctx)
+}
+
+// Close closes the connection to the server
+func (n *NATS) Close() error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	if n.conn != nil {
+		n.conn.Close()
+		n.conn = nil
+	}
+
+	return nil
+}
+
+func (n *NATS) connect() error {
+	conn, err := nats.Connect(n.url, nats.Name("payments-gateway-relay"), nats.Timeout(n.timeout))
+	if err != nil {
+		return err
+	}
+
+	if n.jetStream {
+		js, err := conn.JetStream()
+		if err != nil {
+			conn.Close()
+
+			return err
+		}
+
+		n.js = js
+	}
+
+	n.conn = conn
+
+	return nil
+}
@@ -0,0 +1,86 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	log "github.com/sirupsen/logrus"
+
+	"payments_gateway/model"
+	"payments_gateway/storage"
+)
+
+// DefaultBatchSize is the number of events relayed in one transaction
+const DefaultBatchSize = 100
+
+// Publisher sends outbox events downstream. Publish returns once the event is accepted, events are
+// published one at a time in the order they are relayed. An event may be published more than once so
+// consumers must drop the events they already handled, by their ID
+type Publisher interface {
+	Publish(ctx context.Context, event *model.OutboxEvent) error
+	Close() error
+}
+
+// Relay publishes the payment events written to the outbox
+type Relay struct {
+	store     storage.Client
+	publisher Publisher
+	batchSize int
+}
+
+// NewRelay creates a relay, batchSize defaults to DefaultBatchSize when not positive
+func NewRelay(store storage.Client, publisher Publisher, batchSize int) *Relay {
+	if batchSize <= 0 {
+		batchSize = DefaultBatchSize
+	}
+
+	return &Relay{
+		store:     store,
+		publisher: publisher,
+		batchSize: batchSize,
+	}
+}
+
+// Run publishes the unpublished events until none are left and returns how many it published. It
+// stops at the first event that fails to publish so no later event of its payment overtakes it, the
+// event is published again by the next run. Nothing is published while another relay is running
+func (r *Relay) Run(ctx context.Context) (int, error) {
+	var total int
+
+	for {
+		var publishErr error
+
+		published, err := r.store.RelayOutbox(ctx, r.batchSize, func(events []*model.OutboxEvent) int {
+			for i, event := range events {
+				if err := r.publisher.Publish(ctx, event); err != nil {
+					publishErr = fmt.Errorf("publishing outbox event %d: %w", event.ID, err)
+
+					return i
+				}
+			}
+
+			return len(events)
+		})
+
+		if errors.Is(err, storage.ErrOutboxLocked) {
+			log.Debug("outbox is being relayed by another relay")
+
+			return total, nil
+		}
+
+		if err != nil {
+			return total, fmt.Errorf("relaying outbox: %w", err)
+		}
+
+		total += published
+
+		if publishErr != nil {
+			return total, publishErr
+		}
+
+		if published < r.batchSize {
+			return total, nil
+		}
+	}
+}
@@ -0,0 +1,137 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"payments_gateway/model"
+	"payments_gateway/storage"
+	"payments_gateway/storage/mocks"
+)
+
+// fakePublisher records the IDs of the events it publishes and fails on the event failOn
+type fakePublisher struct {
+	failOn    int64
+	published []int64
+}
+
+func (f *fakePublisher) Publish(_ context.Context, event *model.OutboxEvent) error {
+	if event.ID == f.failOn {
+		return errors.New("broker unavailable")
+	}
+
+	f.published = append(f.published, event.ID)
+
+	return nil
+}
+
+func (f *fakePublisher) Close() error {
+	return nil
+}
+
+func TestRelay_Run(t *testing.T) {
+	events := func(ids ...int64) []*model.OutboxEvent {
+		var out []*model.OutboxEvent
+
+		for _, id := range ids {
+			out = append(out, &model.OutboxEvent{
+				ID:      id,
+				Key:     "ref1",
+				Type:    model.EventPaymentStatusChanged,
+				Payload: []byte(`{}`),
+				Time:    time.Date(2022, 5, 1, 6, 0, 0, 0, time.UTC),
+			})
+		}
+
+		return out
+	}
+
+	tests := []struct {
+		name      string
+		batches   [][]*model.OutboxEvent
+		relayErr  error
+		failOn    int64
+		want      int
+		published []int64
+		marked    []int
+		err       error
+	}{
+		{
+			name:      "nothing to publish",
+			batches:   [][]*model.OutboxEvent{nil},
+			published: nil,
+			marked:    []int{0},
+		},
+		{
+			name:      "keeps relaying full batches",
+			batches:   [][]*model.OutboxEvent{events(1, 2), events(3, 4), events(5)},
+			want:      5,
+			published: []int64{1, 2, 3, 4, 5},
+			marked:    []int{2, 2, 1},
+		},
+		{
+			name:      "stops at the first failed event",
+			batches:   [][]*model.OutboxEvent{events(1, 2)},
+			failOn:    2,
+			want:      1,
+			published: []int64{1},
+			marked:    []int{1},
+			err:       errors.New("publishing outbox event 2: broker unavailable"),
+		},
+		{
+			name:     "another relay is running",
+			relayErr: storage.ErrOutboxLocked,
+		},
+		{
+			name:     "storage error",
+			relayErr: errors.New("connection refused"),
+			err:      errors.New("relaying outbox: connection refused"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockController := gomock.NewController(t)
+
+			storageMock := mock_storage.NewMockClient(mockController)
+
+			defer mockController.Finish()
+
+			publisher := &fakePublisher{failOn: tt.failOn}
+
+			if tt.relayErr != nil {
+				storageMock.EXPECT().RelayOutbox(gomock.Any(), 2, gomock.Any()).Return(0, tt.relayErr)
+			}
+
+			var marked []int
+
+			for _, batch := range tt.batches {
+				batch := batch
+
+				storageMock.EXPECT().RelayOutbox(gomock.Any(), 2, gomock.Any()).
+					DoAndReturn(func(_ context.Context, _ int, publish func([]*model.OutboxEvent) int) (int, error) {
+						n := publish(batch)
+						marked = append(marked, n)
+
+						return n, nil
+					})
+			}
+
+			got, err := NewRelay(storageMock, publisher, 2).Run(context.Background())
+
+			if tt.err != nil {
+				assert.EqualError(t, err, tt.err.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.published, publisher.published)
+			assert.Equal(t, tt.marked, marked)
+		})
+	}
+}
@@ -0,0 +1,101 @@
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"sync"
+	"time"
+
+	"payments_gateway/model"
+)
+
+// Message is the JSON an event is published as by the file and NATS publishers
+type Message struct {
+	ID      int64           `json:"id"`
+	Key     string          `json:"key"`
+	Type    string          `json:"type"`
+	Time    time.Time       `json:"time"`
+	Payload json.RawMessage `json:"payload"`
+}
+
+// NewMessage returns the message of an event
+func NewMessage(event *model.OutboxEvent) Message {
+	return Message{
+		ID:      event.ID,
+		Key:     event.Key,
+		Type:    event.Type,
+		Time:    event.Time.UTC(),
+		Payload: json.RawMessage(event.Payload),
+	}
+}
+
+// Channel publishes events to a channel, for consumers running in the same process
+type Channel struct {
+	events chan *model.OutboxEvent
+}
+
+// NewChannel creates a channel publisher buffering up to size events, Publish blocks while the buffer is full
+func NewChannel(size int) *Channel {
+	return &Channel{events: make(chan *model.OutboxEvent, size)}
+}
+
+// Events returns the channel the events are published to, it is closed by Close
+func (c *Channel) Events() <-chan *model.OutboxEvent {
+	return c.events
+}
+
+// Publish sends an event to the channel
+func (c *Channel) Publish(ctx context.Context, event *model.OutboxEvent) error {
+	select {
+	case c.events <- event:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Close closes the channel, nothing may be published after it
+func (c *Channel) Close() error {
+	close(c.events)
+
+	return nil
+}
+
+// File appends events to a file as JSON lines, see Message
+type File struct {
+	mu   sync.Mutex
+	file *os.File
+}
+
+// NewFile opens a file publisher, creating the file when it does not exist
+func NewFile(path string) (*File, error) {
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return nil, err
+	}
+
+	return &File{file: file}, nil
+}
+
+// Publish appends an event and syncs the file so the event is not lost once it is marked published
+func (f *File) Publish(_ context.Context, event *model.OutboxEvent) error {
+	line, err := json.Marshal(NewMessage(event))
+	if err != nil {
+		return err
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if _, err := f.file.Write(append(line, '\n')); err != nil {
+		return err
+	}
+
+	return f.file.Sync()
+}
+
+// Close closes the file
+func (f *File) Close() error {
+	return f.file.Close()
+}
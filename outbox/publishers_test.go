@@ -0,0 +1,67 @@
+package outbox
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"payments_gateway/model"
+)
+
+func testEvent(id int64) *model.OutboxEvent {
+	return &model.OutboxEvent{
+		ID:      id,
+		Key:     "ref1",
+		Type:    model.EventPaymentCreated,
+		Payload: []byte(`{"ref_id":"ref1","status":"APPROVED"}`),
+		Time:    time.Date(2022, 5, 1, 6, 0, 0, 0, time.UTC),
+	}
+}
+
+func TestChannel(t *testing.T) {
+	channel := NewChannel(1)
+
+	assert.NoError(t, channel.Publish(context.Background(), testEvent(1)))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	assert.ErrorIs(t, channel.Publish(ctx, testEvent(2)), context.Canceled)
+
+	assert.NoError(t, channel.Close())
+
+	var got []int64
+	for event := range channel.Events() {
+		got = append(got, event.ID)
+	}
+
+	assert.Equal(t, []int64{1}, got)
+}
+
+func TestFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "events.jsonl")
+
+	for _, id := range []int64{1, 2} {
+		file, err := NewFile(path)
+		if !assert.NoError(t, err) {
+			return
+		}
+
+		assert.NoError(t, file.Publish(context.Background(), testEvent(id)))
+		assert.NoError(t, file.Close())
+	}
+
+	content, err := os.ReadFile(path)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	want := `{"id":1,"key":"ref1","type":"payment.created","time":"2022-05-01T06:00:00Z","payload":{"ref_id":"ref1","status":"APPROVED"}}` + "\n" +
+		`{"id":2,"key":"ref1","type":"payment.created","time":"2022-05-01T06:00:00Z","payload":{"ref_id":"ref1","status":"APPROVED"}}` + "\n"
+
+	assert.Equal(t, want, string(content))
+}
@@ -0,0 +1,271 @@
+package pending
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	bank "payments_gateway/aquiring-bank"
+	"payments_gateway/model"
+	protos "payments_gateway/protos"
+	"payments_gateway/responsecodes"
+	"payments_gateway/statemachine"
+	"payments_gateway/storage"
+)
+
+// Options configures which pending payments are inquired about and when they expire
+type Options struct {
+	// MinAge is how long a payment stays PENDING before its acquirer is first asked for the outcome
+	MinAge time.Duration
+	// ReverseAfter is how long a payment stays AUTHORIZING before its authorization is reversed. It must
+	// be longer than the gateway can take to authorize a payment, or approved payments are reversed
+	ReverseAfter time.Duration
+	// InquiryInterval is the wait between two inquiries or reversals of the same payment
+	InquiryInterval time.Duration
+	// MaxAge is the age of a payment after which it is EXPIRED when the acquirer still has no outcome
+	MaxAge time.Duration
+	// BatchSize is the number of pending payments claimed at a time
+	BatchSize int
+}
+
+// DefaultOptions inquire about a payment pending for a minute every 5 minutes, for up to a day, and
+// reverse the authorizations left unfinished for 10 minutes
+func DefaultOptions() Options {
+	return Options{
+		MinAge:          time.Minute,
+		ReverseAfter:    10 * time.Minute,
+		InquiryInterval: 5 * time.Minute,
+		MaxAge:          24 * time.Hour,
+		BatchSize:       100,
+	}
+}
+
+// Summary counts what a resolver run did
+type Summary struct {
+	Resolved     int
+	StillPending int
+	Expired      int
+	Reversed     int
+	// Failed counts the inquiries and reversals that returned an error, they are made again later
+	Failed int
+}
+
+// Resolver asks the acquirers of PENDING and UNKNOWN_RESPONSE_CODE payments for their outcome and updates
+// their status, and reverses the authorizations of the AUTHORIZING payments the gateway did not store the
+// outcome of
+type Resolver struct {
+	store     storage.Client
+	acquirers *bank.Registry
+	mapper    *responsecodes.Mapper
+	opts      Options
+}
+
+// New creates a resolver mapping the response codes of the acquirers with mapper. Options that are
+// not positive are taken from DefaultOptions
+func New(store storage.Client, acquirers *bank.Registry, mapper *responsecodes.Mapper, opts Options) *Resolver {
+	defaults := DefaultOptions()
+
+	if opts.MinAge <= 0 {
+		opts.MinAge = defaults.MinAge
+	}
+
+	if opts.ReverseAfter <= 0 {
+		opts.ReverseAfter = defaults.ReverseAfter
+	}
+
+	if opts.InquiryInterval <= 0 {
+		opts.InquiryInterval = defaults.InquiryInterval
+	}
+
+	if opts.MaxAge <= 0 {
+		opts.MaxAge = defaults.MaxAge
+	}
+
+	if opts.BatchSize <= 0 {
+		opts.BatchSize = defaults.BatchSize
+	}
+
+	return &Resolver{
+		store:     store,
+		acquirers: acquirers,
+		mapper:    mapper,
+		opts:      opts,
+	}
+}
+
+// Run inquires about the pending payments and reverses the unfinished authorizations that are due until
+// none are left. A payment is not claimed again before the inquiry interval passed, so every payment
+// is inquired about or reversed once per run
+func (r *Resolver) Run(ctx context.Context) (Summary, error) {
+	var summary Summary
+
+	for {
+		payments, err := r.store.ClaimPendingPayments(ctx, r.opts.MinAge, r.opts.ReverseAfter, r.opts.InquiryInterval, r.opts.MaxAge, r.opts.BatchSize)
+		if err != nil {
+			return summary, fmt.Errorf("claiming pending payments: %w", err)
+		}
+
+		for _, payment := range payments {
+			resolve := r.resolve
+			if payment.Status == protos.Status_AUTHORIZING {
+				resolve = r.reverse
+			}
+
+			if err := resolve(ctx, payment, &summary); err != nil {
+				return summary, err
+			}
+		}
+
+		if len(payments) < r.opts.BatchSize {
+			return summary, nil
+		}
+	}
+}
+
+// resolve moves a payment to the final status its acquirer responds with. When it has none and the
+// payment reached its maximum age a PENDING payment becomes EXPIRED, while the authorization of an
+// UNKNOWN_RESPONSE_CODE payment, which the issuer may have approved, is reversed. Otherwise the payment
+// is left as it is
+func (r *Resolver) resolve(ctx context.Context, payment *model.PendingPayment, summary *Summary) error {
+	logger := log.WithField("ref", payment.RefID).WithField("acquirer", payment.Acquirer).WithField("attempts", payment.Attempts)
+
+	code, reason, err := r.inquire(ctx, payment)
+	if err != nil {
+		logger.WithError(err).Error("inquiring about pending payment")
+
+		summary.Failed++
+	}
+
+	if err == nil {
+		mapping := r.mapper.Map(payment.Acquirer, code)
+
+		if mapping.Reason != "" {
+			reason = mapping.Reason
+		}
+
+		if statemachine.CanTransition(payment.Status, mapping.Status) {
+			update := model.StatusUpdate{RefID: payment.RefID, Status: mapping.Status, Reason: reason, Source: protos.StatusSource_BANK}
+
+			return r.update(ctx, update, &summary.Resolved)
+		}
+
+		if mapping.Status == protos.Status_UNKNOWN_RESPONSE_CODE {
+			logger.WithField("code", code).Warn("acquirer returned an unknown response code for pending payment")
+		}
+	}
+
+	if payment.Expired && payment.Status == protos.Status_UNKNOWN_RESPONSE_CODE {
+		return r.reverse(ctx, payment, summary)
+	}
+
+	if payment.Expired {
+		update := model.StatusUpdate{
+			RefID:  payment.RefID,
+			Status: protos.Status_EXPIRED,
+			Reason: fmt.Sprintf("no final outcome from the acquirer after %d inquiries", payment.Attempts),
+			Source: protos.StatusSource_GATEWAY,
+		}
+
+		return r.update(ctx, update, &summary.Expired)
+	}
+
+	if err == nil {
+		summary.StillPending++
+	}
+
+	return nil
+}
+
+// reverse releases the funds an authorization the gateway did not store or know the outcome of may hold.
+// The payment keeps its status until the acquirer approves the reversal, it never expires so no hold is
+// left behind
+func (r *Resolver) reverse(ctx context.Context, payment *model.PendingPayment, summary *Summary) error {
+	logger := log.WithField("ref", payment.RefID).WithField("acquirer", payment.Acquirer).WithField("attempts", payment.Attempts)
+
+	code, reason, err := r.reverseAuthorization(ctx, payment)
+	if err != nil {
+		logger.WithError(err).Error("reversing unfinished authorization")
+
+		summary.Failed++
+
+		return nil
+	}
+
+	logger = logger.WithField("code", code).WithField("reason", reason)
+
+	if r.mapper.Map(payment.Acquirer, code).Status != protos.Status_APPROVED {
+		logger.Error("acquirer did not approve the reversal of unfinished authorization")
+
+		summary.Failed++
+
+		return nil
+	}
+
+	logger.Info("reversed unfinished authorization")
+
+	update := model.StatusUpdate{
+		RefID:  payment.RefID,
+		Status: protos.Status_REVERSED,
+		Reason: "authorization was not completed",
+		Source: protos.StatusSource_GATEWAY,
+	}
+
+	if payment.Status == protos.Status_UNKNOWN_RESPONSE_CODE {
+		update.Reason = fmt.Sprintf("no final outcome from the acquirer after %d inquiries", payment.Attempts)
+	}
+
+	return r.update(ctx, update, &summary.Reversed)
+}
+
+func (r *Resolver) reverseAuthorization(ctx context.Context, payment *model.PendingPayment) (string, string, error) {
+	client, err := r.client(payment.Acquirer)
+	if err != nil {
+		return "", "", err
+	}
+
+	return client.Reverse(ctx, model.Modification{RefID: payment.RefID, Amount: payment.Amount})
+}
+
+func (r *Resolver) inquire(ctx context.Context, payment *model.PendingPayment) (string, string, error) {
+	client, err := r.client(payment.Acquirer)
+	if err != nil {
+		return "", "", err
+	}
+
+	return client.Inquire(ctx, payment.RefID)
+}
+
+func (r *Resolver) client(acquirer string) (bank.Client, error) {
+	client, ok := r.acquirers.Get(acquirer)
+	if !ok {
+		return nil, fmt.Errorf("acquirer %s is not configured", acquirer)
+	}
+
+	return client, nil
+}
+
+// update stores the new status of a payment and counts it. A payment whose status changed since it
+// was claimed, e.g. it was resolved by another gateway, is left as it is
+func (r *Resolver) update(ctx context.Context, update model.StatusUpdate, count *int) error {
+	err := r.store.UpdatePaymentStatus(ctx, update)
+
+	var transitionErr *statemachine.TransitionError
+	if errors.As(err, &transitionErr) {
+		log.WithField("ref", update.RefID).WithError(err).Warn("pending payment was resolved meanwhile")
+
+		return nil
+	}
+
+	if err != nil {
+		return fmt.Errorf("updating status of payment %s: %w", update.RefID, err)
+	}
+
+	*count++
+
+	log.WithField("ref", update.RefID).WithField("status", update.Status).Info("resolved pending payment")
+
+	return nil
+}
@@ -0,0 +1,297 @@
+package pending
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	bank "payments_gateway/aquiring-bank"
+	"payments_gateway/aquiring-bank/mocks"
+	"payments_gateway/model"
+	"payments_gateway/money"
+	protos "payments_gateway/protos"
+	"payments_gateway/responsecodes"
+	"payments_gateway/statemachine"
+	"payments_gateway/storage/mocks"
+)
+
+var testOptions = Options{
+	MinAge:          time.Minute,
+	ReverseAfter:    10 * time.Minute,
+	InquiryInterval: 5 * time.Minute,
+	MaxAge:          time.Hour,
+	BatchSize:       2,
+}
+
+func claim(store *mock_storage.MockClient, payments ...*model.PendingPayment) *gomock.Call {
+	return store.EXPECT().ClaimPendingPayments(gomock.Any(), time.Minute, 10*time.Minute, 5*time.Minute, time.Hour, 2).Return(payments, nil)
+}
+
+func pendingPayment(refID string, expired bool) *model.PendingPayment {
+	return &model.PendingPayment{RefID: refID, Acquirer: bank.DefaultAcquirer, Status: protos.Status_PENDING, Attempts: 3, Expired: expired}
+}
+
+func unknownCodePayment(refID string, expired bool) *model.PendingPayment {
+	return &model.PendingPayment{RefID: refID, Acquirer: bank.DefaultAcquirer, Status: protos.Status_UNKNOWN_RESPONSE_CODE, Amount: money.New(2050, "GBP"), Attempts: 3, Expired: expired}
+}
+
+func authorizingPayment(refID string) *model.PendingPayment {
+	return &model.PendingPayment{RefID: refID, Acquirer: bank.DefaultAcquirer, Status: protos.Status_AUTHORIZING, Amount: money.New(2050, "GBP"), Attempts: 1, Expired: true}
+}
+
+func TestResolver_Run(t *testing.T) {
+	tests := []struct {
+		name    string
+		prepare func(store *mock_storage.MockClient, client *mock_bank.MockClient)
+		want    Summary
+		err     error
+	}{
+		{
+			name: "nothing pending",
+			prepare: func(store *mock_storage.MockClient, client *mock_bank.MockClient) {
+				claim(store)
+			},
+		},
+		{
+			name: "acquirer resolves the payments",
+			prepare: func(store *mock_storage.MockClient, client *mock_bank.MockClient) {
+				gomock.InOrder(
+					claim(store, pendingPayment("ref1", false), pendingPayment("ref2", true)),
+					client.EXPECT().Inquire(gomock.Any(), "ref1").Return("00", "approved", nil),
+					store.EXPECT().UpdatePaymentStatus(gomock.Any(), model.StatusUpdate{
+						RefID:  "ref1",
+						Status: protos.Status_APPROVED,
+						Reason: "approved",
+						Source: protos.StatusSource_BANK,
+					}).Return(nil),
+					// an outcome is taken even when the payment reached its maximum age
+					client.EXPECT().Inquire(gomock.Any(), "ref2").Return("12", "invalid transaction", nil),
+					store.EXPECT().UpdatePaymentStatus(gomock.Any(), model.StatusUpdate{
+						RefID:  "ref2",
+						Status: protos.Status_REJECTED,
+						Reason: "invalid transaction",
+						Source: protos.StatusSource_BANK,
+					}).Return(nil),
+					claim(store),
+				)
+			},
+			want: Summary{Resolved: 2},
+		},
+		{
+			name: "payments without an outcome stay pending until they expire",
+			prepare: func(store *mock_storage.MockClient, client *mock_bank.MockClient) {
+				gomock.InOrder(
+					claim(store, pendingPayment("ref1", false)),
+					client.EXPECT().Inquire(gomock.Any(), "ref1").Return("19", "re-enter transaction", nil),
+				)
+
+				store.EXPECT().UpdatePaymentStatus(gomock.Any(), gomock.Any()).Times(0)
+			},
+			want: Summary{StillPending: 1},
+		},
+		{
+			name: "payment without an outcome expires",
+			prepare: func(store *mock_storage.MockClient, client *mock_bank.MockClient) {
+				gomock.InOrder(
+					claim(store, pendingPayment("ref1", true)),
+					client.EXPECT().Inquire(gomock.Any(), "ref1").Return("19", "re-enter transaction", nil),
+					store.EXPECT().UpdatePaymentStatus(gomock.Any(), model.StatusUpdate{
+						RefID:  "ref1",
+						Status: protos.Status_EXPIRED,
+						Reason: "no final outcome from the acquirer after 3 inquiries",
+						Source: protos.StatusSource_GATEWAY,
+					}).Return(nil),
+				)
+			},
+			want: Summary{Expired: 1},
+		},
+		{
+			name: "unknown response code leaves the payment pending",
+			prepare: func(store *mock_storage.MockClient, client *mock_bank.MockClient) {
+				gomock.InOrder(
+					claim(store, pendingPayment("ref1", false)),
+					client.EXPECT().Inquire(gomock.Any(), "ref1").Return("XX", "", nil),
+				)
+			},
+			want: Summary{StillPending: 1},
+		},
+		{
+			name: "payment with an unknown response code is resolved",
+			prepare: func(store *mock_storage.MockClient, client *mock_bank.MockClient) {
+				gomock.InOrder(
+					claim(store, unknownCodePayment("ref1", false), unknownCodePayment("ref2", false)),
+					client.EXPECT().Inquire(gomock.Any(), "ref1").Return("12", "invalid transaction", nil),
+					store.EXPECT().UpdatePaymentStatus(gomock.Any(), model.StatusUpdate{
+						RefID:  "ref1",
+						Status: protos.Status_REJECTED,
+						Reason: "invalid transaction",
+						Source: protos.StatusSource_BANK,
+					}).Return(nil),
+					client.EXPECT().Inquire(gomock.Any(), "ref2").Return("XX", "", nil),
+					claim(store),
+				)
+			},
+			want: Summary{Resolved: 1, StillPending: 1},
+		},
+		{
+			name: "payment with an unknown response code is reversed once it expires",
+			prepare: func(store *mock_storage.MockClient, client *mock_bank.MockClient) {
+				gomock.InOrder(
+					claim(store, unknownCodePayment("ref1", true), unknownCodePayment("ref2", true)),
+					client.EXPECT().Inquire(gomock.Any(), "ref1").Return("19", "re-enter transaction", nil),
+					client.EXPECT().Reverse(gomock.Any(), model.Modification{RefID: "ref1", Amount: money.New(2050, "GBP")}).
+						Return("00", "authorization reversed", nil),
+					store.EXPECT().UpdatePaymentStatus(gomock.Any(), model.StatusUpdate{
+						RefID:  "ref1",
+						Status: protos.Status_REVERSED,
+						Reason: "no final outcome from the acquirer after 3 inquiries",
+						Source: protos.StatusSource_GATEWAY,
+					}).Return(nil),
+					// a declined reversal leaves the payment to be reversed again later
+					client.EXPECT().Inquire(gomock.Any(), "ref2").Return("XX", "", nil),
+					client.EXPECT().Reverse(gomock.Any(), gomock.Any()).Return("12", "invalid transaction", nil),
+					claim(store),
+				)
+			},
+			want: Summary{Reversed: 1, Failed: 1},
+		},
+		{
+			name: "failed inquiry",
+			prepare: func(store *mock_storage.MockClient, client *mock_bank.MockClient) {
+				gomock.InOrder(
+					claim(store, pendingPayment("ref1", false), pendingPayment("ref2", true)),
+					client.EXPECT().Inquire(gomock.Any(), "ref1").Return("", "", errors.New("unexpected response status code: 500")),
+					client.EXPECT().Inquire(gomock.Any(), "ref2").Return("", "", errors.New("unexpected response status code: 500")),
+					store.EXPECT().UpdatePaymentStatus(gomock.Any(), gomock.Any()).Return(nil),
+					claim(store),
+				)
+			},
+			want: Summary{Failed: 2, Expired: 1},
+		},
+		{
+			name: "acquirer not configured",
+			prepare: func(store *mock_storage.MockClient, client *mock_bank.MockClient) {
+				claim(store, &model.PendingPayment{RefID: "ref1", Acquirer: "removed"})
+			},
+			want: Summary{Failed: 1},
+		},
+		{
+			name: "payment resolved meanwhile",
+			prepare: func(store *mock_storage.MockClient, client *mock_bank.MockClient) {
+				gomock.InOrder(
+					claim(store, pendingPayment("ref1", false)),
+					client.EXPECT().Inquire(gomock.Any(), "ref1").Return("00", "approved", nil),
+					store.EXPECT().UpdatePaymentStatus(gomock.Any(), gomock.Any()).
+						Return(&statemachine.TransitionError{From: protos.Status_APPROVED, To: protos.Status_APPROVED}),
+				)
+			},
+		},
+		{
+			name: "unfinished authorization is reversed",
+			prepare: func(store *mock_storage.MockClient, client *mock_bank.MockClient) {
+				gomock.InOrder(
+					claim(store, authorizingPayment("ref1")),
+					client.EXPECT().Reverse(gomock.Any(), model.Modification{RefID: "ref1", Amount: money.New(2050, "GBP")}).
+						Return("00", "authorization reversed", nil),
+					store.EXPECT().UpdatePaymentStatus(gomock.Any(), model.StatusUpdate{
+						RefID:  "ref1",
+						Status: protos.Status_REVERSED,
+						Reason: "authorization was not completed",
+						Source: protos.StatusSource_GATEWAY,
+					}).Return(nil),
+				)
+			},
+			want: Summary{Reversed: 1},
+		},
+		{
+			name: "failed reversal is made again later, even after the maximum age",
+			prepare: func(store *mock_storage.MockClient, client *mock_bank.MockClient) {
+				gomock.InOrder(
+					claim(store, authorizingPayment("ref1")),
+					client.EXPECT().Reverse(gomock.Any(), gomock.Any()).Return("", "", errors.New("unexpected response status code: 503")),
+				)
+
+				store.EXPECT().UpdatePaymentStatus(gomock.Any(), gomock.Any()).Times(0)
+			},
+			want: Summary{Failed: 1},
+		},
+		{
+			name: "declined reversal is made again later",
+			prepare: func(store *mock_storage.MockClient, client *mock_bank.MockClient) {
+				gomock.InOrder(
+					claim(store, authorizingPayment("ref1"), authorizingPayment("ref2")),
+					client.EXPECT().Reverse(gomock.Any(), gomock.Any()).Return("12", "invalid transaction", nil),
+					client.EXPECT().Reverse(gomock.Any(), gomock.Any()).Return("XX", "", nil),
+					claim(store),
+				)
+
+				store.EXPECT().UpdatePaymentStatus(gomock.Any(), gomock.Any()).Times(0)
+			},
+			want: Summary{Failed: 2},
+		},
+		{
+			name: "storage error claiming",
+			prepare: func(store *mock_storage.MockClient, client *mock_bank.MockClient) {
+				store.EXPECT().ClaimPendingPayments(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil, errors.New("connection refused"))
+			},
+			err: errors.New("claiming pending payments: connection refused"),
+		},
+		{
+			name: "storage error updating",
+			prepare: func(store *mock_storage.MockClient, client *mock_bank.MockClient) {
+				gomock.InOrder(
+					claim(store, pendingPayment("ref1", false)),
+					client.EXPECT().Inquire(gomock.Any(), "ref1").Return("00", "approved", nil),
+					store.EXPECT().UpdatePaymentStatus(gomock.Any(), gomock.Any()).Return(errors.New("connection refused")),
+				)
+			},
+			err: errors.New("updating status of payment ref1: connection refused"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockController := gomock.NewController(t)
+
+			defer mockController.Finish()
+
+			store := mock_storage.NewMockClient(mockController)
+			client := mock_bank.NewMockClient(mockController)
+
+			tt.prepare(store, client)
+
+			acquirers := bank.NewRegistry()
+			if err := acquirers.Register(bank.DefaultAcquirer, client); err != nil {
+				t.Fatal(err)
+			}
+
+			r := New(store, acquirers, responsecodes.NewMapper(nil), testOptions)
+
+			got, err := r.Run(context.Background())
+			if tt.err != nil {
+				assert.Equal(t, tt.err.Error(), err.Error())
+
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestNew_Defaults(t *testing.T) {
+	r := New(nil, nil, nil, Options{MaxAge: 2 * time.Hour})
+
+	assert.Equal(t, Options{
+		MinAge:          time.Minute,
+		ReverseAfter:    10 * time.Minute,
+		InquiryInterval: 5 * time.Minute,
+		MaxAge:          2 * time.Hour,
+		BatchSize:       100,
+	}, r.opts)
+}
@@ -0,0 +1,145 @@
+package reconciliation
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"payments_gateway/money"
+)
+
+// Format is the format of a settlement file
+type Format int
+
+const (
+	CSV Format = iota
+	JSON
+)
+
+// Line is a payment the acquirer settled according to its settlement file
+type Line struct {
+	RefID  string
+	Amount money.Money
+}
+
+// line is how a line is written in JSON settlement files, the same as the transactions submitted for settlement
+type line struct {
+	RefID    string  `json:"ref_id"`
+	Amount   float64 `json:"amount"`
+	Currency string  `json:"currency"`
+}
+
+// _csvColumns are the columns CSV settlement files must have, in any order
+var _csvColumns = []string{"ref_id", "amount", "currency"}
+
+// FormatFromPath returns the format of a settlement file from its extension
+func FormatFromPath(path string) (Format, error) {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".csv":
+		return CSV, nil
+	case ".json":
+		return JSON, nil
+	default:
+		return 0, fmt.Errorf("unsupported settlement file %q, expected a .csv or .json file", path)
+	}
+}
+
+// Parse reads the lines of a settlement file. Amounts are in the major unit of their currency as
+// in the transactions submitted for settlement, e.g. 20.5 GBP
+func Parse(r io.Reader, format Format) ([]Line, error) {
+	var raw []line
+
+	var err error
+
+	switch format {
+	case CSV:
+		raw, err = readCSV(r)
+	case JSON:
+		err = json.NewDecoder(r).Decode(&raw)
+	default:
+		err = errors.New("unsupported settlement file format")
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("reading settlement file: %w", err)
+	}
+
+	lines := make([]Line, 0, len(raw))
+	seen := make(map[string]bool, len(raw))
+
+	for i, l := range raw {
+		if l.RefID == "" {
+			return nil, fmt.Errorf("line %d: ref_id is missing", i+1)
+		}
+
+		if seen[l.RefID] {
+			return nil, fmt.Errorf("line %d: duplicate ref_id %s", i+1, l.RefID)
+		}
+
+		seen[l.RefID] = true
+
+		amount, err := money.FromMajor(l.Amount, strings.ToUpper(l.Currency))
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", i+1, err)
+		}
+
+		lines = append(lines, Line{RefID: l.RefID, Amount: amount})
+	}
+
+	return lines, nil
+}
+
+// readCSV reads a CSV settlement file with a header row naming its columns
+func readCSV(r io.Reader) ([]line, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+
+	for _, name := range _csvColumns {
+		if _, ok := columns[name]; !ok {
+			return nil, fmt.Errorf("column %s is missing", name)
+		}
+	}
+
+	var lines []line
+
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			return lines, nil
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		amount, err := strconv.ParseFloat(strings.TrimSpace(record[columns["amount"]]), 64)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: invalid amount %q", len(lines)+1, record[columns["amount"]])
+		}
+
+		lines = append(lines, line{
+			RefID:    strings.TrimSpace(record[columns["ref_id"]]),
+			Amount:   amount,
+			Currency: strings.TrimSpace(record[columns["currency"]]),
+		})
+	}
+}
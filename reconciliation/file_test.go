@@ -0,0 +1,92 @@
+package reconciliation
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"payments_gateway/money"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name     string
+		format   Format
+		contents string
+		want     []Line
+		err      string
+	}{
+		{
+			name:     "csv file",
+			format:   CSV,
+			contents: "currency,ref_id,amount\nGBP,ref1,20.5\n jpy, ref2, 1000\n",
+			want: []Line{
+				{RefID: "ref1", Amount: money.New(2050, "GBP")},
+				{RefID: "ref2", Amount: money.New(1000, "JPY")},
+			},
+		},
+		{
+			name:     "json file",
+			format:   JSON,
+			contents: `[{"ref_id": "ref1", "amount": 20.5, "currency": "GBP"}]`,
+			want:     []Line{{RefID: "ref1", Amount: money.New(2050, "GBP")}},
+		},
+		{
+			name:     "empty csv file",
+			format:   CSV,
+			contents: "",
+			want:     []Line{},
+		},
+		{
+			name:     "csv file without an amount column",
+			format:   CSV,
+			contents: "ref_id,currency\nref1,GBP\n",
+			err:      "reading settlement file: column amount is missing",
+		},
+		{
+			name:     "invalid csv amount",
+			format:   CSV,
+			contents: "ref_id,amount,currency\nref1,twenty,GBP\n",
+			err:      `reading settlement file: line 1: invalid amount "twenty"`,
+		},
+		{
+			name:     "amount with fractions of a minor unit",
+			format:   JSON,
+			contents: `[{"ref_id": "ref1", "amount": 20.505, "currency": "GBP"}]`,
+			err:      "line 1: " + money.ErrPrecision.Error(),
+		},
+		{
+			name:     "duplicate ref",
+			format:   CSV,
+			contents: "ref_id,amount,currency\nref1,20.5,GBP\nref1,20.5,GBP\n",
+			err:      "line 2: duplicate ref_id ref1",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Parse(strings.NewReader(tt.contents), tt.format)
+			if tt.err != "" {
+				assert.EqualError(t, err, tt.err)
+
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestFormatFromPath(t *testing.T) {
+	format, err := FormatFromPath("settlement/2022-05-01.CSV")
+	assert.NoError(t, err)
+	assert.Equal(t, CSV, format)
+
+	format, err = FormatFromPath("2022-05-01.json")
+	assert.NoError(t, err)
+	assert.Equal(t, JSON, format)
+
+	_, err = FormatFromPath("2022-05-01.xml")
+	assert.EqualError(t, err, `unsupported settlement file "2022-05-01.xml", expected a .csv or .json file`)
+}
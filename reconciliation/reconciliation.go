@@ -0,0 +1,97 @@
+package reconciliation
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"payments_gateway/model"
+	protos "payments_gateway/protos"
+	"payments_gateway/storage"
+	identifier "payments_gateway/utils"
+)
+
+// Reconciler compares the settlement files of acquirers to the payments the gateway settled with them
+type Reconciler struct {
+	store storage.Client
+	newID func() string
+}
+
+// New creates a reconciler storing its reports in store
+func New(store storage.Client) *Reconciler {
+	return &Reconciler{store: store, newID: identifier.NewUUID}
+}
+
+// Reconcile matches the lines of a settlement file of an acquirer to the stored payments by ref and
+// amount and stores the report. Payments settled with the acquirer that neither this file nor an
+// earlier one had are reported missing at the bank
+func (r *Reconciler) Reconcile(ctx context.Context, acquirer, fileName string, lines []Line) (*model.ReconciliationReport, error) {
+	refIDs := make([]string, 0, len(lines))
+	for _, l := range lines {
+		refIDs = append(refIDs, l.RefID)
+	}
+
+	amounts, err := r.store.GetSettlementAmounts(ctx, acquirer, refIDs)
+	if err != nil {
+		return nil, fmt.Errorf("getting settled payments: %w", err)
+	}
+
+	report := &model.ReconciliationReport{
+		ID:       r.newID(),
+		Acquirer: acquirer,
+		FileName: fileName,
+		Records:  make([]model.ReconciliationRecord, 0, len(lines)),
+	}
+
+	for _, l := range lines {
+		record := model.ReconciliationRecord{RefID: l.RefID, BankAmount: l.Amount}
+
+		gatewayAmount, ok := amounts[l.RefID]
+
+		switch {
+		case !ok:
+			record.Result = protos.ReconciliationResult_MISSING_IN_GATEWAY
+		case gatewayAmount == l.Amount:
+			record.Result = protos.ReconciliationResult_MATCHED
+		default:
+			record.Result = protos.ReconciliationResult_AMOUNT_MISMATCH
+		}
+
+		record.GatewayAmount = gatewayAmount
+
+		delete(amounts, l.RefID)
+
+		report.Records = append(report.Records, record)
+	}
+
+	missing := make([]string, 0, len(amounts))
+	for refID := range amounts {
+		missing = append(missing, refID)
+	}
+
+	sort.Strings(missing)
+
+	for _, refID := range missing {
+		report.Records = append(report.Records, model.ReconciliationRecord{
+			RefID:         refID,
+			Result:        protos.ReconciliationResult_MISSING_AT_BANK,
+			GatewayAmount: amounts[refID],
+		})
+	}
+
+	if err := r.store.AddReconciliationReport(ctx, report); err != nil {
+		return nil, fmt.Errorf("storing reconciliation report: %w", err)
+	}
+
+	return report, nil
+}
+
+// Counts returns the number of records of a report by result
+func Counts(report *model.ReconciliationReport) map[protos.ReconciliationResult]int {
+	counts := map[protos.ReconciliationResult]int{}
+	for _, record := range report.Records {
+		counts[record.Result]++
+	}
+
+	return counts
+}
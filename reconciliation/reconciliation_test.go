@@ -0,0 +1,72 @@
+package reconciliation
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"payments_gateway/model"
+	"payments_gateway/money"
+	protos "payments_gateway/protos"
+	"payments_gateway/storage/mocks"
+)
+
+func TestReconciler_Reconcile(t *testing.T) {
+	mockController := gomock.NewController(t)
+
+	storageMock := mock_storage.NewMockClient(mockController)
+
+	defer mockController.Finish()
+
+	r := New(storageMock)
+	r.newID = func() string { return "report1" }
+
+	lines := []Line{
+		{RefID: "matched", Amount: money.New(2050, "GBP")},
+		{RefID: "mismatched", Amount: money.New(2000, "GBP")},
+		{RefID: "unknown", Amount: money.New(500, "EUR")},
+	}
+
+	want := &model.ReconciliationReport{
+		ID:       "report1",
+		Acquirer: "eu",
+		FileName: "2022-05-01.csv",
+		Records: []model.ReconciliationRecord{
+			{RefID: "matched", Result: protos.ReconciliationResult_MATCHED, GatewayAmount: money.New(2050, "GBP"), BankAmount: money.New(2050, "GBP")},
+			{RefID: "mismatched", Result: protos.ReconciliationResult_AMOUNT_MISMATCH, GatewayAmount: money.New(2050, "GBP"), BankAmount: money.New(2000, "GBP")},
+			{RefID: "unknown", Result: protos.ReconciliationResult_MISSING_IN_GATEWAY, BankAmount: money.New(500, "EUR")},
+			{RefID: "missing1", Result: protos.ReconciliationResult_MISSING_AT_BANK, GatewayAmount: money.New(100, "GBP")},
+			{RefID: "missing2", Result: protos.ReconciliationResult_MISSING_AT_BANK, GatewayAmount: money.New(200, "GBP")},
+		},
+	}
+
+	storageMock.EXPECT().
+		GetSettlementAmounts(gomock.Any(), "eu", []string{"matched", "mismatched", "unknown"}).
+		Return(map[string]money.Money{
+			"matched":    money.New(2050, "GBP"),
+			"mismatched": money.New(2050, "GBP"),
+			"missing2":   money.New(200, "GBP"),
+			"missing1":   money.New(100, "GBP"),
+		}, nil)
+	storageMock.EXPECT().AddReconciliationReport(gomock.Any(), want).Return(nil)
+
+	got, err := r.Reconcile(context.Background(), "eu", "2022-05-01.csv", lines)
+
+	assert.NoError(t, err)
+	assert.Equal(t, want, got)
+	assert.Equal(t, map[protos.ReconciliationResult]int{
+		protos.ReconciliationResult_MATCHED:            1,
+		protos.ReconciliationResult_AMOUNT_MISMATCH:    1,
+		protos.ReconciliationResult_MISSING_IN_GATEWAY: 1,
+		protos.ReconciliationResult_MISSING_AT_BANK:    2,
+	}, Counts(got))
+
+	storageMock.EXPECT().GetSettlementAmounts(gomock.Any(), "eu", []string{}).Return(nil, errors.New("connection refused"))
+
+	_, err = r.Reconcile(context.Background(), "eu", "2022-05-02.csv", nil)
+
+	assert.EqualError(t, err, "getting settled payments: connection refused")
+}
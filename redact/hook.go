@@ -0,0 +1,32 @@
+package redact
+
+import (
+	log "github.com/sirupsen/logrus"
+)
+
+// Hook is a logrus hook that redacts the message and every field of a log entry before it
+// is formatted. Add it to a logger with logger.AddHook
+type Hook struct {
+	Redactor Redactor
+}
+
+// NewHook creates a hook redacting log entries with r
+func NewHook(r Redactor) *Hook {
+	return &Hook{Redactor: r}
+}
+
+// Levels redacts entries of every level
+func (h *Hook) Levels() []log.Level {
+	return log.AllLevels
+}
+
+// Fire redacts an entry, logrus passes every hook a copy of the entry fields
+func (h *Hook) Fire(entry *log.Entry) error {
+	for key, value := range entry.Data {
+		entry.Data[key] = h.Redactor.Value(value)
+	}
+
+	entry.Message = h.Redactor.String(entry.Message)
+
+	return nil
+}
@@ -0,0 +1,227 @@
+package redact
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"regexp"
+	"strings"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	"payments_gateway/card"
+	"payments_gateway/model"
+)
+
+// _panCandidate matches digit runs long enough to be a card number, only runs
+// that pass the Luhn check are masked
+var _panCandidate = regexp.MustCompile(`\d{13,19}`)
+
+// proto fields holding card and contact details, matched by name in any message
+const (
+	_cardNumberField = "card_number"
+	_cvvField        = "cvv"
+	_emailField      = "email"
+	_phoneField      = "phone"
+)
+
+// Redactor removes card details from values before they are logged. Card numbers are
+// masked apart from the first and last four digits and CVVs are dropped
+type Redactor struct {
+	// HashContactDetails replaces email addresses and phone numbers with a hash, so
+	// log lines of the same customer can still be correlated
+	HashContactDetails bool
+	// HashKey keys the contact details hash with HMAC-SHA256, a plain SHA-256 is used when empty
+	HashKey []byte
+}
+
+// Value returns a redacted copy of a logged value. Proto messages, model cards and
+// transactions, strings, errors and slices of them are redacted, any other value is returned as is
+func (r Redactor) Value(v interface{}) interface{} {
+	switch value := v.(type) {
+	case proto.Message:
+		return r.Message(value)
+	case model.Card:
+		return r.Card(value)
+	case *model.Card:
+		if value == nil {
+			return value
+		}
+
+		redacted := r.Card(*value)
+
+		return &redacted
+	case model.Transaction:
+		value.Card = r.Card(value.Card)
+
+		return value
+	case *model.Transaction:
+		if value == nil {
+			return value
+		}
+
+		redacted := *value
+		redacted.Card = r.Card(value.Card)
+
+		return &redacted
+	case []*model.Transaction:
+		out := make([]*model.Transaction, 0, len(value))
+		for _, t := range value {
+			out = append(out, r.Value(t).(*model.Transaction))
+		}
+
+		return out
+	case []interface{}:
+		out := make([]interface{}, 0, len(value))
+		for _, elem := range value {
+			out = append(out, r.Value(elem))
+		}
+
+		return out
+	case string:
+		return r.String(value)
+	case error:
+		if redacted := r.String(value.Error()); redacted != value.Error() {
+			return errors.New(redacted)
+		}
+
+		return value
+	default:
+		return v
+	}
+}
+
+// Card masks the card number and drops the CVV of a card
+func (r Redactor) Card(c model.Card) model.Card {
+	c.CardNum = MaskPAN(c.CardNum)
+	c.Cvv = 0
+
+	return c
+}
+
+// Message returns a redacted copy of a proto message. Fields named card_number are masked,
+// cvv fields are cleared and email and phone fields are hashed when HashContactDetails is set.
+// Card numbers in any other string field are masked
+func (r Redactor) Message(m proto.Message) proto.Message {
+	if m == nil || !m.ProtoReflect().IsValid() {
+		return m
+	}
+
+	out := proto.Clone(m)
+	r.redactMessage(out.ProtoReflect())
+
+	return out
+}
+
+func (r Redactor) redactMessage(m protoreflect.Message) {
+	// fields are collected first as the message must not be changed while ranging over it
+	var fields []protoreflect.FieldDescriptor
+
+	m.Range(func(fd protoreflect.FieldDescriptor, _ protoreflect.Value) bool {
+		fields = append(fields, fd)
+
+		return true
+	})
+
+	for _, fd := range fields {
+		v := m.Get(fd)
+
+		switch {
+		case fd.IsList():
+			list := v.List()
+			for i := 0; i < list.Len(); i++ {
+				if fd.Message() != nil {
+					r.redactMessage(list.Get(i).Message())
+				} else if fd.Kind() == protoreflect.StringKind {
+					list.Set(i, protoreflect.ValueOfString(r.String(list.Get(i).String())))
+				}
+			}
+		case fd.IsMap():
+			r.redactMap(fd, v.Map())
+		case fd.Message() != nil:
+			r.redactMessage(v.Message())
+		default:
+			r.redactField(m, fd, v)
+		}
+	}
+}
+
+func (r Redactor) redactMap(fd protoreflect.FieldDescriptor, m protoreflect.Map) {
+	var keys []protoreflect.MapKey
+
+	m.Range(func(k protoreflect.MapKey, _ protoreflect.Value) bool {
+		keys = append(keys, k)
+
+		return true
+	})
+
+	for _, k := range keys {
+		switch {
+		case fd.MapValue().Message() != nil:
+			r.redactMessage(m.Get(k).Message())
+		case fd.MapValue().Kind() == protoreflect.StringKind:
+			m.Set(k, protoreflect.ValueOfString(r.String(m.Get(k).String())))
+		}
+	}
+}
+
+func (r Redactor) redactField(m protoreflect.Message, fd protoreflect.FieldDescriptor, v protoreflect.Value) {
+	switch fd.Name() {
+	case _cvvField:
+		m.Clear(fd)
+	case _cardNumberField:
+		if fd.Kind() == protoreflect.StringKind {
+			m.Set(fd, protoreflect.ValueOfString(MaskPAN(v.String())))
+		}
+	case _emailField, _phoneField:
+		if fd.Kind() == protoreflect.StringKind && r.HashContactDetails {
+			m.Set(fd, protoreflect.ValueOfString(r.hash(v.String())))
+		}
+	default:
+		if fd.Kind() == protoreflect.StringKind {
+			m.Set(fd, protoreflect.ValueOfString(r.String(v.String())))
+		}
+	}
+}
+
+// String masks every card number found in free text
+func (r Redactor) String(s string) string {
+	return _panCandidate.ReplaceAllStringFunc(s, func(candidate string) string {
+		if !card.Luhn(candidate) {
+			return candidate
+		}
+
+		return MaskPAN(candidate)
+	})
+}
+
+// hash returns a hex encoded hash of a normalised email address or phone number
+func (r Redactor) hash(in string) string {
+	in = strings.ToLower(strings.TrimSpace(in))
+	if in == "" {
+		return ""
+	}
+
+	if len(r.HashKey) == 0 {
+		sum := sha256.Sum256([]byte(in))
+
+		return "sha256:" + hex.EncodeToString(sum[:])
+	}
+
+	mac := hmac.New(sha256.New, r.HashKey)
+	mac.Write([]byte(in))
+
+	return "hmac-sha256:" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// MaskPAN masks all digits of a card number apart from the first and last four.
+// Numbers too short to keep eight digits are masked completely
+func MaskPAN(pan string) string {
+	if len(pan) <= 8 {
+		return strings.Repeat("X", len(pan))
+	}
+
+	return pan[:4] + strings.Repeat("X", len(pan)-8) + pan[len(pan)-4:]
+}
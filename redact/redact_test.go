@@ -0,0 +1,138 @@
+package redact
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+
+	"payments_gateway/model"
+	protos "payments_gateway/protos"
+)
+
+func request() *protos.ProcessPaymentRequest {
+	return &protos.ProcessPaymentRequest{
+		BillingDetails: &protos.BillingDetails{
+			Name:    "Bruce",
+			Surname: "Wayne",
+			Email:   "iam@batman.com",
+			Phone:   "0789825678",
+		},
+		CardNumber: "378282246310005",
+		Expiry:     "04/30",
+		Amount:     20.5,
+		Currency:   "GBP",
+		Cvv:        3421,
+		CardType:   protos.CardType_AMERICAN_EXPRESS,
+	}
+}
+
+func TestRedactor_Message(t *testing.T) {
+	req := request()
+
+	got := Redactor{}.Message(req).(*protos.ProcessPaymentRequest)
+
+	assert.Equal(t, "3782XXXXXXX0005", got.GetCardNumber())
+	assert.Equal(t, int32(0), got.GetCvv())
+	assert.Equal(t, "04/30", got.GetExpiry())
+	assert.Equal(t, "iam@batman.com", got.GetBillingDetails().GetEmail())
+
+	// the logged message is a copy
+	assert.Equal(t, "378282246310005", req.GetCardNumber())
+	assert.Equal(t, int32(3421), req.GetCvv())
+}
+
+func TestRedactor_Message_HashContactDetails(t *testing.T) {
+	got := Redactor{HashContactDetails: true}.Message(request()).(*protos.ProcessPaymentRequest)
+
+	assert.Contains(t, got.GetBillingDetails().GetEmail(), "sha256:")
+	assert.NotContains(t, got.GetBillingDetails().GetEmail(), "batman")
+	assert.NotContains(t, got.GetBillingDetails().GetPhone(), "0789825678")
+
+	// the same customer hashes to the same value
+	again := Redactor{HashContactDetails: true}.Message(request()).(*protos.ProcessPaymentRequest)
+	assert.Equal(t, got.GetBillingDetails().GetEmail(), again.GetBillingDetails().GetEmail())
+
+	keyed := Redactor{HashContactDetails: true, HashKey: []byte("secret")}.Message(request()).(*protos.ProcessPaymentRequest)
+	assert.Contains(t, keyed.GetBillingDetails().GetEmail(), "hmac-sha256:")
+}
+
+func TestRedactor_Value(t *testing.T) {
+	c := model.Card{Name: "Bruce", CardNum: "378282246310005", Cvv: 3421}
+
+	tests := []struct {
+		name string
+		in   interface{}
+		want interface{}
+	}{
+		{
+			name: "card",
+			in:   c,
+			want: model.Card{Name: "Bruce", CardNum: "3782XXXXXXX0005"},
+		},
+		{
+			name: "card pointer",
+			in:   &c,
+			want: &model.Card{Name: "Bruce", CardNum: "3782XXXXXXX0005"},
+		},
+		{
+			name: "transaction",
+			in:   &model.Transaction{RefID: "ref", Card: c},
+			want: &model.Transaction{RefID: "ref", Card: model.Card{Name: "Bruce", CardNum: "3782XXXXXXX0005"}},
+		},
+		{
+			name: "card number in free text",
+			in:   "invalid card 4111111111111111 for ref 1234567890123",
+			want: "invalid card 4111XXXXXXXX1111 for ref 1234567890123",
+		},
+		{
+			name: "error",
+			in:   errors.New("declined 4111111111111111"),
+			want: errors.New("declined 4111XXXXXXXX1111"),
+		},
+		{
+			name: "query arguments",
+			in:   []interface{}{"4111111111111111", 2050},
+			want: []interface{}{"4111XXXXXXXX1111", 2050},
+		},
+		{
+			name: "other values are unchanged",
+			in:   2050,
+			want: 2050,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Redactor{}.Value(tt.in))
+		})
+	}
+
+	// the logged card is a copy
+	assert.Equal(t, "378282246310005", c.CardNum)
+}
+
+func TestMaskPAN(t *testing.T) {
+	assert.Equal(t, "3782XXXXXXX0005", MaskPAN("378282246310005"))
+	assert.Equal(t, "XXXXXX", MaskPAN("378005"))
+	assert.Equal(t, "", MaskPAN(""))
+}
+
+func TestHook(t *testing.T) {
+	var out bytes.Buffer
+
+	logger := log.New()
+	logger.SetOutput(&out)
+	logger.SetFormatter(&log.JSONFormatter{})
+	logger.AddHook(NewHook(Redactor{}))
+
+	logger.WithField("request", request()).
+		WithField("card", model.Card{CardNum: "378282246310005", Cvv: 3421}).
+		WithError(errors.New("card 378282246310005 declined")).
+		Error("processing 378282246310005")
+
+	assert.NotContains(t, out.String(), "378282246310005")
+	assert.NotContains(t, out.String(), "3421")
+	assert.Contains(t, out.String(), "3782XXXXXXX0005")
+}
@@ -0,0 +1,162 @@
+package responsecodes
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+
+	protos "payments_gateway/protos"
+)
+
+// Mapping is what a response code returned by an acquirer means for the payment
+type Mapping struct {
+	Status protos.Status
+	// Reason replaces the reason returned by the acquirer when set
+	Reason    string
+	Decline   protos.DeclineType
+	Retryable bool
+}
+
+// mappingJSON is how a Mapping is written in the config file
+type mappingJSON struct {
+	Status    string `json:"status"`
+	Reason    string `json:"reason"`
+	Decline   string `json:"decline"`
+	Retryable bool   `json:"retryable"`
+}
+
+var _declines = map[string]protos.DeclineType{
+	"":     protos.DeclineType_NOT_DECLINED,
+	"soft": protos.DeclineType_SOFT_DECLINE,
+	"hard": protos.DeclineType_HARD_DECLINE,
+}
+
+// UnmarshalJSON reads a mapping such as {"status": "REJECTED", "decline": "soft", "retryable": true}
+func (m *Mapping) UnmarshalJSON(data []byte) error {
+	var raw mappingJSON
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	status, ok := protos.Status_value[strings.ToUpper(raw.Status)]
+	if !ok || !mappable(protos.Status(status)) {
+		return fmt.Errorf("invalid status %q", raw.Status)
+	}
+
+	decline, ok := _declines[strings.ToLower(raw.Decline)]
+	if !ok {
+		return fmt.Errorf("invalid decline %q, expected soft or hard", raw.Decline)
+	}
+
+	if decline != protos.DeclineType_NOT_DECLINED && protos.Status(status) != protos.Status_REJECTED {
+		return fmt.Errorf("only REJECTED codes can be declines")
+	}
+
+	*m = Mapping{
+		Status:    protos.Status(status),
+		Reason:    raw.Reason,
+		Decline:   decline,
+		Retryable: raw.Retryable,
+	}
+
+	return nil
+}
+
+// mappable reports whether a response code may give a new payment the status
+func mappable(status protos.Status) bool {
+	switch status {
+	case protos.Status_APPROVED, protos.Status_REJECTED, protos.Status_PENDING, protos.Status_COMPLETED:
+		return true
+	default:
+		return false
+	}
+}
+
+// Table maps the response codes of an acquirer
+type Table map[string]Mapping
+
+// DefaultTable maps the response codes of the bank simulator, it is used for every acquirer
+// without a table of its own
+func DefaultTable() Table {
+	return Table{
+		"00": {Status: protos.Status_APPROVED},
+		"06": {Status: protos.Status_REJECTED, Decline: protos.DeclineType_SOFT_DECLINE, Retryable: true},
+		"12": {Status: protos.Status_REJECTED, Decline: protos.DeclineType_HARD_DECLINE},
+		"39": {Status: protos.Status_REJECTED, Decline: protos.DeclineType_HARD_DECLINE},
+		"19": {Status: protos.Status_PENDING},
+		"20": {Status: protos.Status_COMPLETED},
+	}
+}
+
+// LoadTables reads a JSON file of response code tables by acquirer name, such as config/response_codes.json
+func LoadTables(path string) (map[string]Table, error) {
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading response codes: %w", err)
+	}
+
+	var tables map[string]Table
+	if err := json.Unmarshal(contents, &tables); err != nil {
+		return nil, fmt.Errorf("parsing response codes: %w", err)
+	}
+
+	return tables, nil
+}
+
+// Mapper maps the response codes of every acquirer and counts the codes missing from their
+// tables. It is safe for concurrent use
+type Mapper struct {
+	tables   map[string]Table
+	defaults Table
+
+	mu      sync.Mutex
+	unknown map[string]map[string]int64
+}
+
+// NewMapper creates a mapper from tables by acquirer name
+func NewMapper(tables map[string]Table) *Mapper {
+	return &Mapper{
+		tables:   tables,
+		defaults: DefaultTable(),
+		unknown:  map[string]map[string]int64{},
+	}
+}
+
+// Map returns what a response code of an acquirer means. Codes missing from the table of the
+// acquirer map to UNKNOWN_RESPONSE_CODE and are counted
+func (m *Mapper) Map(acquirer, code string) Mapping {
+	table, ok := m.tables[acquirer]
+	if !ok {
+		table = m.defaults
+	}
+
+	if mapping, ok := table[code]; ok {
+		return mapping
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.unknown[acquirer] == nil {
+		m.unknown[acquirer] = map[string]int64{}
+	}
+
+	m.unknown[acquirer][code]++
+
+	return Mapping{Status: protos.Status_UNKNOWN_RESPONSE_CODE}
+}
+
+// UnknownCodes returns how many times each unknown response code was returned by an acquirer
+func (m *Mapper) UnknownCodes(acquirer string) map[string]int64 {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	counts := make(map[string]int64, len(m.unknown[acquirer]))
+	for code, n := range m.unknown[acquirer] {
+		counts[code] = n
+	}
+
+	return counts
+}
@@ -0,0 +1,117 @@
+package responsecodes
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	protos "payments_gateway/protos"
+)
+
+func TestMapper_Map(t *testing.T) {
+	m := NewMapper(map[string]Table{
+		"eu": {
+			"00": {Status: protos.Status_APPROVED},
+			"51": {Status: protos.Status_REJECTED, Reason: "insufficient funds", Decline: protos.DeclineType_SOFT_DECLINE, Retryable: true},
+		},
+	})
+
+	tests := []struct {
+		name     string
+		acquirer string
+		code     string
+		want     Mapping
+	}{
+		{
+			name:     "code of the acquirer table",
+			acquirer: "eu",
+			code:     "51",
+			want:     Mapping{Status: protos.Status_REJECTED, Reason: "insufficient funds", Decline: protos.DeclineType_SOFT_DECLINE, Retryable: true},
+		},
+		{
+			name:     "acquirer without a table uses the default table",
+			acquirer: "default",
+			code:     "12",
+			want:     Mapping{Status: protos.Status_REJECTED, Decline: protos.DeclineType_HARD_DECLINE},
+		},
+		{
+			name:     "code missing from the acquirer table",
+			acquirer: "eu",
+			code:     "12",
+			want:     Mapping{Status: protos.Status_UNKNOWN_RESPONSE_CODE},
+		},
+		{
+			name:     "code missing from the default table",
+			acquirer: "default",
+			code:     "99",
+			want:     Mapping{Status: protos.Status_UNKNOWN_RESPONSE_CODE},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, m.Map(tt.acquirer, tt.code))
+		})
+	}
+
+	m.Map("eu", "12")
+
+	assert.Equal(t, map[string]int64{"12": 2}, m.UnknownCodes("eu"))
+	assert.Equal(t, map[string]int64{"99": 1}, m.UnknownCodes("default"))
+	assert.Equal(t, map[string]int64{}, m.UnknownCodes("other"))
+}
+
+func TestLoadTables(t *testing.T) {
+	tests := []struct {
+		name     string
+		contents string
+		want     map[string]Table
+		err      string
+	}{
+		{
+			name: "valid tables",
+			contents: `{"eu": {
+				"00": {"status": "APPROVED"},
+				"51": {"status": "rejected", "reason": "insufficient funds", "decline": "soft", "retryable": true}
+			}}`,
+			want: map[string]Table{"eu": {
+				"00": {Status: protos.Status_APPROVED},
+				"51": {Status: protos.Status_REJECTED, Reason: "insufficient funds", Decline: protos.DeclineType_SOFT_DECLINE, Retryable: true},
+			}},
+		},
+		{
+			name:     "status a new payment cannot have",
+			contents: `{"eu": {"00": {"status": "REFUNDED"}}}`,
+			err:      `parsing response codes: invalid status "REFUNDED"`,
+		},
+		{
+			name:     "unknown decline",
+			contents: `{"eu": {"05": {"status": "REJECTED", "decline": "maybe"}}}`,
+			err:      `parsing response codes: invalid decline "maybe", expected soft or hard`,
+		},
+		{
+			name:     "decline that is not rejected",
+			contents: `{"eu": {"00": {"status": "APPROVED", "decline": "hard"}}}`,
+			err:      "parsing response codes: only REJECTED codes can be declines",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "response_codes.json")
+			if err := os.WriteFile(path, []byte(tt.contents), 0o600); err != nil {
+				t.Fatal(err)
+			}
+
+			got, err := LoadTables(path)
+			if tt.err != "" {
+				assert.EqualError(t, err, tt.err)
+
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
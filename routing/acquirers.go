@@ -0,0 +1,68 @@
+package routing
+
+import (
+	"fmt"
+	"os"
+
+	bank "payments_gateway/aquiring-bank"
+	"payments_gateway/breaker"
+)
+
+// NewAcquirers creates the clients of the default acquirer and of the acquirers of the routingConfigPath
+// file, each guarded by its own circuit breaker. The default acquirer is configured by the bankConfigPath
+// file, the BANK_* environment variables and override, in increasing order of precedence. Acquirers in the
+// routing file are configured by the file alone and may not be named bank.DefaultAcquirer, either path may be empty
+func NewAcquirers(bankConfigPath, routingConfigPath string, override bank.Config, opts breaker.Options) (*bank.Registry, error) {
+	var config bank.Config
+
+	if bankConfigPath != "" {
+		fileConfig, err := bank.LoadConfig(bankConfigPath)
+		if err != nil {
+			return nil, err
+		}
+
+		config = fileConfig
+	}
+
+	envConfig, err := bank.ConfigFromEnv(os.LookupEnv)
+	if err != nil {
+		return nil, err
+	}
+
+	configs := map[string]bank.Config{bank.DefaultAcquirer: config.Merge(envConfig).Merge(override)}
+
+	if routingConfigPath != "" {
+		routingConfig, err := LoadConfig(routingConfigPath)
+		if err != nil {
+			return nil, err
+		}
+
+		for name, acquirerConfig := range routingConfig.Acquirers {
+			if name == bank.DefaultAcquirer {
+				return nil, fmt.Errorf("routing config may not configure the %s acquirer, it is configured like a single acquirer", bank.DefaultAcquirer)
+			}
+
+			configs[name] = acquirerConfig
+		}
+	}
+
+	acquirers := bank.NewRegistry()
+
+	for name, acquirerConfig := range configs {
+		clientOpts, err := acquirerConfig.Options()
+		if err != nil {
+			return nil, fmt.Errorf("acquirer %s: %w", name, err)
+		}
+
+		client, err := bank.New(clientOpts)
+		if err != nil {
+			return nil, fmt.Errorf("acquirer %s: %w", name, err)
+		}
+
+		if err := acquirers.Register(name, bank.WithBreaker(name, client, opts)); err != nil {
+			return nil, err
+		}
+	}
+
+	return acquirers, nil
+}
@@ -0,0 +1,134 @@
+package routing
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	bank "payments_gateway/aquiring-bank"
+)
+
+// Payment holds the details of a payment that routing rules match on
+type Payment struct {
+	MerchantID string
+	CardType   string
+	Currency   string
+	// Amount in the minor unit of Currency
+	Amount int64
+}
+
+// Rule routes the payments it matches to an acquirer. Empty fields match every payment and
+// amounts are in minor units, a zero amount leaves that end of the band open
+type Rule struct {
+	Acquirer   string   `json:"acquirer"`
+	CardTypes  []string `json:"card_types"`
+	Currencies []string `json:"currencies"`
+	MinAmount  int64    `json:"min_amount_minor_units"`
+	MaxAmount  int64    `json:"max_amount_minor_units"`
+	Merchants  []string `json:"merchants"`
+}
+
+// Matches reports whether a payment satisfies every condition of the rule
+func (r Rule) Matches(p Payment) bool {
+	return matchesAny(r.CardTypes, p.CardType) &&
+		matchesAny(r.Currencies, p.Currency) &&
+		matchesAny(r.Merchants, p.MerchantID) &&
+		(r.MinAmount == 0 || p.Amount >= r.MinAmount) &&
+		(r.MaxAmount == 0 || p.Amount <= r.MaxAmount)
+}
+
+// Config is the routing config file, it names the acquirers payments can be routed to
+// in addition to the default acquirer, the rules choosing between them and the secondary
+// acquirer each acquirer fails over to
+type Config struct {
+	Acquirers map[string]bank.Config `json:"acquirers"`
+	Rules     []Rule                 `json:"rules"`
+	Failover  map[string]string      `json:"failover"`
+}
+
+// LoadConfig reads a JSON routing config file such as config/routing.json
+func LoadConfig(path string) (Config, error) {
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		return Config{}, fmt.Errorf("reading routing config: %w", err)
+	}
+
+	var c Config
+	if err := json.Unmarshal(contents, &c); err != nil {
+		return Config{}, fmt.Errorf("parsing routing config: %w", err)
+	}
+
+	return c, nil
+}
+
+// Router picks the acquirer of a payment, rules are evaluated in order and the first
+// match wins. Payments no rule matches go to bank.DefaultAcquirer
+type Router struct {
+	rules    []Rule
+	failover map[string]string
+}
+
+// NewRouter creates a router, every rule must route to an acquirer in the registry.
+// failover maps an acquirer to the secondary acquirer used while it is unavailable
+func NewRouter(rules []Rule, failover map[string]string, registry *bank.Registry) (*Router, error) {
+	for i, rule := range rules {
+		if _, ok := registry.Get(rule.Acquirer); !ok {
+			return nil, fmt.Errorf("rule %d routes to unknown acquirer %q", i, rule.Acquirer)
+		}
+
+		if rule.MaxAmount != 0 && rule.MaxAmount < rule.MinAmount {
+			return nil, fmt.Errorf("rule %d has an invalid amount band", i)
+		}
+	}
+
+	for primary, secondary := range failover {
+		if _, ok := registry.Get(primary); !ok {
+			return nil, fmt.Errorf("failover configured for unknown acquirer %q", primary)
+		}
+
+		if _, ok := registry.Get(secondary); !ok || secondary == primary {
+			return nil, fmt.Errorf("acquirer %q fails over to invalid acquirer %q", primary, secondary)
+		}
+	}
+
+	return &Router{rules: rules, failover: failover}, nil
+}
+
+// Route returns the name of the acquirer a payment is sent to
+func (r *Router) Route(p Payment) string {
+	if r == nil {
+		return bank.DefaultAcquirer
+	}
+
+	for _, rule := range r.rules {
+		if rule.Matches(p) {
+			return rule.Acquirer
+		}
+	}
+
+	return bank.DefaultAcquirer
+}
+
+// Failover returns the secondary acquirer of an acquirer, or an empty string when it has none
+func (r *Router) Failover(acquirer string) string {
+	if r == nil {
+		return ""
+	}
+
+	return r.failover[acquirer]
+}
+
+func matchesAny(values []string, value string) bool {
+	if len(values) == 0 {
+		return true
+	}
+
+	for _, v := range values {
+		if strings.EqualFold(v, value) {
+			return true
+		}
+	}
+
+	return false
+}
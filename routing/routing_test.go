@@ -0,0 +1,147 @@
+package routing
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	bank "payments_gateway/aquiring-bank"
+	"payments_gateway/aquiring-bank/mocks"
+	"payments_gateway/breaker"
+)
+
+func registry(t *testing.T, names ...string) *bank.Registry {
+	r := bank.NewRegistry()
+
+	for _, name := range names {
+		if err := r.Register(name, &mock_bank.MockClient{}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	return r
+}
+
+func TestRouter_Route(t *testing.T) {
+	router, err := NewRouter([]Rule{
+		{Acquirer: "amex-direct", CardTypes: []string{"AMERICAN_EXPRESS"}},
+		{Acquirer: "eu", Currencies: []string{"EUR"}, MaxAmount: 100000},
+		{Acquirer: "high-value", MinAmount: 100001},
+		{Acquirer: "eu", Merchants: []string{"merchant-eu"}},
+	}, map[string]string{"eu": bank.DefaultAcquirer}, registry(t, bank.DefaultAcquirer, "amex-direct", "eu", "high-value"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		payment Payment
+		want    string
+	}{
+		{
+			name:    "card brand",
+			payment: Payment{CardType: "AMERICAN_EXPRESS", Currency: "EUR", Amount: 2050},
+			want:    "amex-direct",
+		},
+		{
+			name:    "currency and amount band",
+			payment: Payment{CardType: "VISA", Currency: "eur", Amount: 2050},
+			want:    "eu",
+		},
+		{
+			name:    "amount above the band of the currency rule",
+			payment: Payment{CardType: "VISA", Currency: "EUR", Amount: 200000},
+			want:    "high-value",
+		},
+		{
+			name:    "merchant",
+			payment: Payment{MerchantID: "merchant-eu", CardType: "VISA", Currency: "GBP", Amount: 2050},
+			want:    "eu",
+		},
+		{
+			name:    "no rule matches",
+			payment: Payment{CardType: "VISA", Currency: "GBP", Amount: 2050},
+			want:    bank.DefaultAcquirer,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, router.Route(tt.payment))
+		})
+	}
+
+	assert.Equal(t, bank.DefaultAcquirer, router.Failover("eu"))
+	assert.Equal(t, "", router.Failover("amex-direct"))
+
+	var none *Router
+	assert.Equal(t, bank.DefaultAcquirer, none.Route(Payment{}))
+	assert.Equal(t, "", none.Failover(bank.DefaultAcquirer))
+}
+
+func TestNewRouter_Invalid(t *testing.T) {
+	r := registry(t, bank.DefaultAcquirer)
+
+	_, err := NewRouter([]Rule{{Acquirer: "unknown"}}, nil, r)
+	assert.EqualError(t, err, `rule 0 routes to unknown acquirer "unknown"`)
+
+	_, err = NewRouter([]Rule{{Acquirer: bank.DefaultAcquirer, MinAmount: 100, MaxAmount: 10}}, nil, r)
+	assert.EqualError(t, err, "rule 0 has an invalid amount band")
+
+	_, err = NewRouter(nil, map[string]string{"unknown": bank.DefaultAcquirer}, r)
+	assert.EqualError(t, err, `failover configured for unknown acquirer "unknown"`)
+
+	_, err = NewRouter(nil, map[string]string{bank.DefaultAcquirer: bank.DefaultAcquirer}, r)
+	assert.EqualError(t, err, `acquirer "default" fails over to invalid acquirer "default"`)
+}
+
+func TestLoadConfig(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "routing.json")
+	if err := os.WriteFile(path, []byte(`{
+		"acquirers": {"eu": {"base_url": "https://eu.acquirer.example.com/api/v1"}},
+		"rules": [{"acquirer": "eu", "currencies": ["EUR"], "max_amount_minor_units": 100000}],
+		"failover": {"default": "eu"}
+	}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	c, err := LoadConfig(path)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "https://eu.acquirer.example.com/api/v1", c.Acquirers["eu"].BaseURL)
+	assert.Equal(t, []Rule{{Acquirer: "eu", Currencies: []string{"EUR"}, MaxAmount: 100000}}, c.Rules)
+	assert.Equal(t, map[string]string{"default": "eu"}, c.Failover)
+}
+
+func TestNewAcquirers(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "routing.json")
+	if err := os.WriteFile(path, []byte(`{"acquirers": {"eu": {"base_url": "https://eu.acquirer.example.com/api/v1"}}}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	acquirers, err := NewAcquirers("", path, bank.Config{BaseURL: "https://acquirer.example.com/api/v1"}, breaker.DefaultOptions())
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.Equal(t, []string{"default", "eu"}, acquirers.Names())
+
+	for _, name := range acquirers.Names() {
+		client, _ := acquirers.Get(name)
+
+		assert.IsType(t, &bank.BreakerClient{}, client, name)
+	}
+
+	_, err = NewAcquirers("", path, bank.Config{BaseURL: "ftp://acquirer.example.com"}, breaker.DefaultOptions())
+
+	assert.EqualError(t, err, `acquirer default: invalid acquiring bank base url "ftp://acquirer.example.com"`)
+
+	if err := os.WriteFile(path, []byte(`{"acquirers": {"default": {"base_url": "https://eu.acquirer.example.com/api/v1"}}}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = NewAcquirers("", path, bank.Config{BaseURL: "https://acquirer.example.com/api/v1"}, breaker.DefaultOptions())
+
+	assert.EqualError(t, err, "routing config may not configure the default acquirer, it is configured like a single acquirer")
+}
@@ -3,7 +3,16 @@ create type payment_status as enum (
     'PENDING',
     'REJECTED',
     'AUTHORIZED',
-    'CARD_VERIFIED'
+    'CARD_VERIFIED',
+    'COMPLETED',
+    'CAPTURED',
+    'VOIDED',
+    'PARTIALLY_REFUNDED',
+    'REFUNDED',
+    'UNKNOWN_RESPONSE_CODE',
+    'EXPIRED',
+    'AUTHORIZING',
+    'REVERSED'
     );
 
 alter type payment_status owner to user1;
@@ -16,6 +25,31 @@ create type payment_type as enum (
 
 alter type payment_type owner to user1;
 
+-- API keys are only stored hashed, see the auth package. Only operators may call RPCs such as ListAcquirers
+create table merchants
+(
+    merchant_id         varchar                             NOT NULL,
+    name                varchar                             NOT NULL,
+    operator            boolean   default false             not null,
+    insert_timestamp    timestamp default CURRENT_TIMESTAMP not null,
+    PRIMARY KEY (merchant_id)
+);
+
+alter table
+    merchants owner to user1;
+
+create table merchant_api_keys
+(
+    key_hash            varchar                             NOT NULL,
+    merchant_id         varchar                             NOT NULL REFERENCES merchants (merchant_id),
+    revoked_timestamp   timestamp NULL,
+    insert_timestamp    timestamp default CURRENT_TIMESTAMP not null,
+    PRIMARY KEY (key_hash)
+);
+
+alter table
+    merchant_api_keys owner to user1;
+
 create table payment_details
 (
     ref_id              varchar                             NOT NULL,
@@ -28,15 +62,273 @@ create table payment_details
     postcode            varchar,
     card_number         varchar                             NOT NULL,
     currency            varchar                             NOT NULL,
-    amount              REAL NULL,
+    amount_minor_units  bigint NULL,
     payment_type        payment_type NULL,
     status              payment_status NULL,
     status_reason             varchar,
     updated_timestamp timestamp default CURRENT_TIMESTAMP not null,
     insert_timestamp    timestamp default CURRENT_TIMESTAMP not null,
+    captured_amount_minor_units bigint default 0 not null,
+    refunded_amount_minor_units bigint default 0 not null,
+    pending_refund_amount_minor_units bigint NULL,
+    card_token          varchar NULL,
+    acquirer            varchar default 'default'           not null,
+    response_code       varchar NULL,
+    settlement_batch_id varchar NULL,
+    settlement_failure_reason varchar NULL,
+    settlement_amount_minor_units bigint NULL,
+    merchant_id         varchar   default ''                not null,
+    merchant_reference  varchar NULL,
+    metadata            jsonb     default '{}'              not null,
+    inquiry_attempts    integer   default 0                 not null,
+    last_inquiry_timestamp timestamp NULL,
     PRIMARY KEY (ref_id)
 );
 
 
 alter table
     payment_details owner to user1;
+
+-- captured and partially refunded payments are waiting to be settled
+create index payment_details_captured_idx on payment_details (acquirer, updated_timestamp) where status in ('CAPTURED', 'PARTIALLY_REFUNDED');
+
+-- pending payments and payments with an unknown response code are resolved with their acquirer and the
+-- authorizations left unfinished are reversed, oldest first
+create index payment_details_pending_idx on payment_details (updated_timestamp) where status in ('PENDING', 'AUTHORIZING', 'UNKNOWN_RESPONSE_CODE');
+
+create index payment_details_settlement_batch_idx on payment_details (settlement_batch_id);
+
+-- ListPayments pages through the payments of a merchant by (insert_timestamp, ref_id), the other
+-- filters narrow the pages of the first two indexes or are looked up by their own
+create index payment_details_merchant_insert_idx on payment_details (merchant_id, insert_timestamp, ref_id);
+
+create index payment_details_merchant_status_idx on payment_details (merchant_id, status, insert_timestamp, ref_id);
+
+create index payment_details_merchant_email_idx on payment_details (merchant_id, lower(email));
+
+create index payment_details_merchant_card_idx on payment_details (merchant_id, right(card_number, 4));
+
+create index payment_details_merchant_reference_idx on payment_details (merchant_id, merchant_reference, insert_timestamp);
+
+create index payment_details_metadata_idx on payment_details using gin (metadata jsonb_path_ops);
+
+create type settlement_batch_status as enum (
+    'SUBMITTING',
+    'SUBMITTED',
+    'FAILED'
+    );
+
+alter type settlement_batch_status owner to user1;
+
+-- a batch stays SUBMITTING if the settlement job stopped while it was being submitted, the next run fails
+-- it once it is stale so its payments are submitted again
+create table settlement_batches
+(
+    batch_id            varchar                             NOT NULL,
+    acquirer            varchar                             NOT NULL,
+    status              settlement_batch_status             NOT NULL,
+    payment_count       int       default 0                 not null,
+    failure_count       int       default 0                 not null,
+    failure_reason      varchar,
+    insert_timestamp    timestamp default CURRENT_TIMESTAMP not null,
+    updated_timestamp   timestamp default CURRENT_TIMESTAMP not null,
+    PRIMARY KEY (batch_id)
+);
+
+alter table
+    settlement_batches owner to user1;
+
+create type reconciliation_result as enum (
+    'MATCHED',
+    'MISSING_AT_BANK',
+    'MISSING_IN_GATEWAY',
+    'AMOUNT_MISMATCH'
+    );
+
+alter type reconciliation_result owner to user1;
+
+create table reconciliation_reports
+(
+    report_id           varchar                             NOT NULL,
+    acquirer            varchar                             NOT NULL,
+    file_name           varchar,
+    insert_timestamp    timestamp default CURRENT_TIMESTAMP not null,
+    PRIMARY KEY (report_id)
+);
+
+alter table
+    reconciliation_reports owner to user1;
+
+-- ref_id does not reference payment_details as payments missing in the gateway are recorded too
+create table reconciliation_records
+(
+    report_id                  varchar               NOT NULL REFERENCES reconciliation_reports (report_id),
+    ref_id                     varchar               NOT NULL,
+    result                     reconciliation_result NOT NULL,
+    gateway_amount_minor_units bigint NULL,
+    gateway_currency           varchar NULL,
+    bank_amount_minor_units    bigint NULL,
+    bank_currency              varchar NULL,
+    PRIMARY KEY (report_id, ref_id)
+);
+
+create index reconciliation_records_ref_id_idx on reconciliation_records (ref_id);
+
+alter table
+    reconciliation_records owner to user1;
+
+create type status_source as enum (
+    'BANK',
+    'OPERATOR',
+    'GATEWAY'
+    );
+
+alter type status_source owner to user1;
+
+create table payment_status_history
+(
+    id                  bigserial                           NOT NULL,
+    ref_id              varchar                             NOT NULL REFERENCES payment_details (ref_id),
+    old_status          payment_status NULL,
+    new_status          payment_status                      NOT NULL,
+    reason              varchar,
+    source              status_source                       NOT NULL,
+    insert_timestamp    timestamp default CURRENT_TIMESTAMP not null,
+    PRIMARY KEY (id)
+);
+
+create index payment_status_history_ref_id_idx on payment_status_history (ref_id, id);
+
+alter table
+    payment_status_history owner to user1;
+
+-- the status history is an audit trail so rows can only ever be appended
+create function reject_payment_status_history_change() returns trigger as
+$$
+begin
+    raise exception 'payment_status_history is append-only';
+end;
+$$ language plpgsql;
+
+create trigger payment_status_history_append_only
+    before update or delete
+    on payment_status_history
+    for each row
+execute procedure reject_payment_status_history_change();
+
+-- payment status changes are POSTed to the webhook endpoints of the merchant of the payment, an
+-- endpoint without statuses is sent every change. The secret signs the events so it is kept in plain text
+create table webhook_endpoints
+(
+    endpoint_id         varchar                             NOT NULL,
+    merchant_id         varchar                             NOT NULL,
+    url                 varchar                             NOT NULL,
+    secret              varchar                             NOT NULL,
+    statuses            varchar[] default '{}'              not null,
+    deleted_timestamp   timestamp NULL,
+    insert_timestamp    timestamp default CURRENT_TIMESTAMP not null,
+    PRIMARY KEY (endpoint_id)
+);
+
+create index webhook_endpoints_merchant_idx on webhook_endpoints (merchant_id) where deleted_timestamp is null;
+
+alter table
+    webhook_endpoints owner to user1;
+
+create type webhook_delivery_status as enum (
+    'DELIVERY_PENDING',
+    'DELIVERED',
+    'DEAD_LETTER'
+    );
+
+alter type webhook_delivery_status owner to user1;
+
+-- a delivery is added with the status change it sends, in the same transaction. The dispatcher
+-- leases a pending delivery by moving next_attempt_timestamp past the attempt
+create table webhook_deliveries
+(
+    delivery_id            bigserial                           NOT NULL,
+    endpoint_id            varchar                             NOT NULL REFERENCES webhook_endpoints (endpoint_id),
+    status_history_id      bigint                              NOT NULL REFERENCES payment_status_history (id),
+    status                 webhook_delivery_status default 'DELIVERY_PENDING' not null,
+    attempts               int       default 0                 not null,
+    last_response_code     int NULL,
+    last_error             varchar NULL,
+    next_attempt_timestamp timestamp default CURRENT_TIMESTAMP not null,
+    insert_timestamp       timestamp default CURRENT_TIMESTAMP not null,
+    updated_timestamp      timestamp default CURRENT_TIMESTAMP not null,
+    PRIMARY KEY (delivery_id)
+);
+
+create index webhook_deliveries_pending_idx on webhook_deliveries (next_attempt_timestamp) where status = 'DELIVERY_PENDING';
+
+create index webhook_deliveries_endpoint_idx on webhook_deliveries (endpoint_id, delivery_id);
+
+alter table
+    webhook_deliveries owner to user1;
+
+-- payment events written in the transaction that changed the payment, the relay publishes them in id order
+-- and marks them published, so events are published at least once and in order for each payment
+create table outbox
+(
+    id                  bigserial                           NOT NULL,
+    aggregate_id        varchar                             NOT NULL,
+    event_type          varchar                             NOT NULL,
+    payload             jsonb                               NOT NULL,
+    insert_timestamp    timestamp default CURRENT_TIMESTAMP not null,
+    published_timestamp timestamp NULL,
+    PRIMARY KEY (id)
+);
+
+create index outbox_unpublished_idx on outbox (id) where published_timestamp is null;
+
+alter table
+    outbox owner to user1;
+
+-- idempotency keys are scoped to the merchant making the request
+create table idempotency_keys
+(
+    merchant_id         varchar   default ''                NOT NULL,
+    idempotency_key     varchar                             NOT NULL,
+    request_hash        varchar                             NOT NULL,
+    response            bytea NULL,
+    insert_timestamp    timestamp default CURRENT_TIMESTAMP not null,
+    PRIMARY KEY (merchant_id, idempotency_key)
+);
+
+alter table
+    idempotency_keys owner to user1;
+
+-- merchant references reserved by payments when the gateway enforces unique references, payments made
+-- while it did not are not in it
+create table merchant_references
+(
+    merchant_id         varchar                             NOT NULL,
+    merchant_reference  varchar                             NOT NULL,
+    insert_timestamp    timestamp default CURRENT_TIMESTAMP not null,
+    PRIMARY KEY (merchant_id, merchant_reference)
+);
+
+alter table
+    merchant_references owner to user1;
+
+-- card numbers are only stored encrypted, see the vault package. CVVs are never stored
+create table card_vault
+(
+    token                 varchar                             NOT NULL,
+    name                  varchar,
+    surname               varchar,
+    postcode              varchar,
+    card_type             varchar                             NOT NULL,
+    expiry                varchar                             NOT NULL,
+    masked_card_number    varchar                             NOT NULL,
+    encrypted_card_number bytea                               NOT NULL,
+    wrapped_key           bytea                               NOT NULL,
+    key_id                varchar                             NOT NULL,
+    insert_timestamp      timestamp default CURRENT_TIMESTAMP not null,
+    merchant_id           varchar   default ''                not null,
+    PRIMARY KEY (token)
+);
+
+alter table
+    card_vault owner to user1;
@@ -0,0 +1,17 @@
+-- Adds the statuses of captured, voided, refunded and completed payments and the amounts captured and refunded.
+-- init.sql already creates them, this only needs running against databases created before.
+-- Postgres before 12 cannot add an enum value inside a transaction, so they are added first.
+
+alter type payment_status add value if not exists 'COMPLETED';
+alter type payment_status add value if not exists 'CAPTURED';
+alter type payment_status add value if not exists 'VOIDED';
+alter type payment_status add value if not exists 'PARTIALLY_REFUNDED';
+alter type payment_status add value if not exists 'REFUNDED';
+
+begin;
+
+alter table payment_details
+    add column captured_amount REAL default 0 not null,
+    add column refunded_amount REAL default 0 not null;
+
+commit;
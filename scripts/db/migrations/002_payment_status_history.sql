@@ -0,0 +1,45 @@
+-- Adds the append-only history of payment status changes, existing payments have no history.
+-- init.sql already creates it, this only needs running against databases created before.
+
+begin;
+
+create type status_source as enum (
+    'BANK',
+    'OPERATOR',
+    'GATEWAY'
+    );
+
+alter type status_source owner to user1;
+
+create table payment_status_history
+(
+    id                  bigserial                           NOT NULL,
+    ref_id              varchar                             NOT NULL REFERENCES payment_details (ref_id),
+    old_status          payment_status NULL,
+    new_status          payment_status                      NOT NULL,
+    reason              varchar,
+    source              status_source                       NOT NULL,
+    insert_timestamp    timestamp default CURRENT_TIMESTAMP not null,
+    PRIMARY KEY (id)
+);
+
+create index payment_status_history_ref_id_idx on payment_status_history (ref_id, id);
+
+alter table
+    payment_status_history owner to user1;
+
+-- the status history is an audit trail so rows can only ever be appended
+create function reject_payment_status_history_change() returns trigger as
+$$
+begin
+    raise exception 'payment_status_history is append-only';
+end;
+$$ language plpgsql;
+
+create trigger payment_status_history_append_only
+    before update or delete
+    on payment_status_history
+    for each row
+execute procedure reject_payment_status_history_change();
+
+commit;
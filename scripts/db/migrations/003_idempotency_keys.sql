@@ -0,0 +1,19 @@
+-- Adds the idempotency keys of ProcessPayment, merchant_id is empty until payments are scoped to merchants.
+-- init.sql already creates it, this only needs running against databases created before.
+
+begin;
+
+create table idempotency_keys
+(
+    merchant_id         varchar   default ''                NOT NULL,
+    idempotency_key     varchar                             NOT NULL,
+    request_hash        varchar                             NOT NULL,
+    response            bytea NULL,
+    insert_timestamp    timestamp default CURRENT_TIMESTAMP not null,
+    PRIMARY KEY (merchant_id, idempotency_key)
+);
+
+alter table
+    idempotency_keys owner to user1;
+
+commit;
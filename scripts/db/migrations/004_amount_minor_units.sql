@@ -0,0 +1,36 @@
+-- Moves payment amounts from REAL major units to bigint minor units, e.g. 20.5 GBP is stored as 2050.
+-- init.sql already creates the new columns, this only needs running against databases created before.
+
+begin;
+
+alter table payment_details
+    add column amount_minor_units          bigint NULL,
+    add column captured_amount_minor_units bigint default 0 not null,
+    add column refunded_amount_minor_units bigint default 0 not null;
+
+-- REAL only keeps ~7 significant digits so the converted amounts are rounded to the nearest minor unit
+update payment_details
+set amount_minor_units          = round(amount::numeric * power(10, exponent)),
+    captured_amount_minor_units = round(captured_amount::numeric * power(10, exponent)),
+    refunded_amount_minor_units = round(refunded_amount::numeric * power(10, exponent))
+from (select ref_id as id,
+             case upper(currency)
+                 when 'BIF' then 0 when 'CLP' then 0 when 'DJF' then 0 when 'GNF' then 0
+                 when 'ISK' then 0 when 'JPY' then 0 when 'KMF' then 0 when 'KRW' then 0
+                 when 'PYG' then 0 when 'RWF' then 0 when 'UGX' then 0 when 'UYI' then 0
+                 when 'VND' then 0 when 'VUV' then 0 when 'XAF' then 0 when 'XOF' then 0
+                 when 'XPF' then 0
+                 when 'BHD' then 3 when 'IQD' then 3 when 'JOD' then 3 when 'KWD' then 3
+                 when 'LYD' then 3 when 'OMR' then 3 when 'TND' then 3
+                 when 'CLF' then 4 when 'UYW' then 4
+                 else 2
+                 end as exponent
+      from payment_details) exponents
+where ref_id = exponents.id;
+
+alter table payment_details
+    drop column amount,
+    drop column captured_amount,
+    drop column refunded_amount;
+
+commit;
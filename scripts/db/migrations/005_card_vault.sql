@@ -0,0 +1,28 @@
+-- Adds the card vault and the token a payment was made with.
+-- init.sql already creates both, this only needs running against databases created before.
+
+begin;
+
+alter table payment_details
+    add column card_token varchar NULL;
+
+create table card_vault
+(
+    token                 varchar                             NOT NULL,
+    name                  varchar,
+    surname               varchar,
+    postcode              varchar,
+    card_type             varchar                             NOT NULL,
+    expiry                varchar                             NOT NULL,
+    masked_card_number    varchar                             NOT NULL,
+    encrypted_card_number bytea                               NOT NULL,
+    wrapped_key           bytea                               NOT NULL,
+    key_id                varchar                             NOT NULL,
+    insert_timestamp      timestamp default CURRENT_TIMESTAMP not null,
+    PRIMARY KEY (token)
+);
+
+alter table
+    card_vault owner to user1;
+
+commit;
@@ -0,0 +1,9 @@
+-- Adds the acquirer a payment was routed to, payments made before routing went to the default acquirer.
+-- init.sql already creates it, this only needs running against databases created before.
+
+begin;
+
+alter table payment_details
+    add column acquirer varchar default 'default' not null;
+
+commit;
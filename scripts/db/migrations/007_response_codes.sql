@@ -0,0 +1,12 @@
+-- Adds the status of payments authorized with an unknown response code and the raw response code of the acquirer.
+-- init.sql already creates both, this only needs running against databases created before.
+-- Postgres before 12 cannot add an enum value inside a transaction, so it is added first.
+
+alter type payment_status add value if not exists 'UNKNOWN_RESPONSE_CODE';
+
+begin;
+
+alter table payment_details
+    add column response_code varchar NULL;
+
+commit;
@@ -0,0 +1,38 @@
+-- Adds settlement batches and the batch each captured payment was settled in.
+-- init.sql already creates them, this only needs running against databases created before.
+
+begin;
+
+alter table payment_details
+    add column settlement_batch_id varchar NULL,
+    add column settlement_failure_reason varchar NULL;
+
+create index payment_details_captured_idx on payment_details (acquirer, updated_timestamp) where status = 'CAPTURED';
+
+create index payment_details_settlement_batch_idx on payment_details (settlement_batch_id);
+
+create type settlement_batch_status as enum (
+    'SUBMITTING',
+    'SUBMITTED',
+    'FAILED'
+    );
+
+alter type settlement_batch_status owner to user1;
+
+create table settlement_batches
+(
+    batch_id            varchar                             NOT NULL,
+    acquirer            varchar                             NOT NULL,
+    status              settlement_batch_status             NOT NULL,
+    payment_count       int       default 0                 not null,
+    failure_count       int       default 0                 not null,
+    failure_reason      varchar,
+    insert_timestamp    timestamp default CURRENT_TIMESTAMP not null,
+    updated_timestamp   timestamp default CURRENT_TIMESTAMP not null,
+    PRIMARY KEY (batch_id)
+);
+
+alter table
+    settlement_batches owner to user1;
+
+commit;
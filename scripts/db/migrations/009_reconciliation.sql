@@ -0,0 +1,45 @@
+-- Adds settlement file reconciliation reports.
+-- init.sql already creates them, this only needs running against databases created before.
+
+begin;
+
+create type reconciliation_result as enum (
+    'MATCHED',
+    'MISSING_AT_BANK',
+    'MISSING_IN_GATEWAY',
+    'AMOUNT_MISMATCH'
+    );
+
+alter type reconciliation_result owner to user1;
+
+create table reconciliation_reports
+(
+    report_id           varchar                             NOT NULL,
+    acquirer            varchar                             NOT NULL,
+    file_name           varchar,
+    insert_timestamp    timestamp default CURRENT_TIMESTAMP not null,
+    PRIMARY KEY (report_id)
+);
+
+alter table
+    reconciliation_reports owner to user1;
+
+-- ref_id does not reference payment_details as payments missing in the gateway are recorded too
+create table reconciliation_records
+(
+    report_id                  varchar               NOT NULL REFERENCES reconciliation_reports (report_id),
+    ref_id                     varchar               NOT NULL,
+    result                     reconciliation_result NOT NULL,
+    gateway_amount_minor_units bigint NULL,
+    gateway_currency           varchar NULL,
+    bank_amount_minor_units    bigint NULL,
+    bank_currency              varchar NULL,
+    PRIMARY KEY (report_id, ref_id)
+);
+
+create index reconciliation_records_ref_id_idx on reconciliation_records (ref_id);
+
+alter table
+    reconciliation_records owner to user1;
+
+commit;
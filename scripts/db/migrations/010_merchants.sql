@@ -0,0 +1,38 @@
+-- Adds merchants, their API keys and the merchant every payment and vaulted card belongs to. Payments and cards
+-- stored before belong to no merchant ('') and can no longer be retrieved by API callers.
+-- init.sql already creates them, this only needs running against databases created before.
+
+begin;
+
+-- API keys are only stored hashed, see the auth package. Only operators may call RPCs such as ListAcquirers
+create table merchants
+(
+    merchant_id         varchar                             NOT NULL,
+    name                varchar                             NOT NULL,
+    operator            boolean   default false             not null,
+    insert_timestamp    timestamp default CURRENT_TIMESTAMP not null,
+    PRIMARY KEY (merchant_id)
+);
+
+alter table
+    merchants owner to user1;
+
+create table merchant_api_keys
+(
+    key_hash            varchar                             NOT NULL,
+    merchant_id         varchar                             NOT NULL REFERENCES merchants (merchant_id),
+    revoked_timestamp   timestamp NULL,
+    insert_timestamp    timestamp default CURRENT_TIMESTAMP not null,
+    PRIMARY KEY (key_hash)
+);
+
+alter table
+    merchant_api_keys owner to user1;
+
+alter table payment_details
+    add column merchant_id varchar default '' not null;
+
+alter table card_vault
+    add column merchant_id varchar default '' not null;
+
+commit;
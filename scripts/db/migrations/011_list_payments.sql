@@ -0,0 +1,16 @@
+-- Adds the indexes ListPayments filters payments with.
+-- init.sql already creates them, this only needs running against databases created before.
+
+begin;
+
+-- ListPayments pages through the payments of a merchant by (insert_timestamp, ref_id), the other
+-- filters narrow the pages of the first two indexes or are looked up by their own
+create index payment_details_merchant_insert_idx on payment_details (merchant_id, insert_timestamp, ref_id);
+
+create index payment_details_merchant_status_idx on payment_details (merchant_id, status, insert_timestamp, ref_id);
+
+create index payment_details_merchant_email_idx on payment_details (merchant_id, lower(email));
+
+create index payment_details_merchant_card_idx on payment_details (merchant_id, right(card_number, 4));
+
+commit;
@@ -0,0 +1,28 @@
+-- Adds the merchant reference and metadata of payments and the references reserved while unique
+-- references are enforced.
+-- init.sql already creates them, this only needs running against databases created before.
+
+begin;
+
+alter table payment_details
+    add column merchant_reference varchar NULL,
+    add column metadata jsonb default '{}' not null;
+
+create index payment_details_merchant_reference_idx on payment_details (merchant_id, merchant_reference, insert_timestamp);
+
+create index payment_details_metadata_idx on payment_details using gin (metadata jsonb_path_ops);
+
+-- merchant references reserved by payments when the gateway enforces unique references, payments made
+-- while it did not are not in it
+create table merchant_references
+(
+    merchant_id         varchar                             NOT NULL,
+    merchant_reference  varchar                             NOT NULL,
+    insert_timestamp    timestamp default CURRENT_TIMESTAMP not null,
+    PRIMARY KEY (merchant_id, merchant_reference)
+);
+
+alter table
+    merchant_references owner to user1;
+
+commit;
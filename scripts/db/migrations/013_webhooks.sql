@@ -0,0 +1,57 @@
+-- Adds the webhook endpoints of merchants and the deliveries of payment status changes to them.
+-- init.sql already creates them, this only needs running against databases created before.
+
+begin;
+
+-- payment status changes are POSTed to the webhook endpoints of the merchant of the payment, an
+-- endpoint without statuses is sent every change. The secret signs the events so it is kept in plain text
+create table webhook_endpoints
+(
+    endpoint_id         varchar                             NOT NULL,
+    merchant_id         varchar                             NOT NULL,
+    url                 varchar                             NOT NULL,
+    secret              varchar                             NOT NULL,
+    statuses            varchar[] default '{}'              not null,
+    deleted_timestamp   timestamp NULL,
+    insert_timestamp    timestamp default CURRENT_TIMESTAMP not null,
+    PRIMARY KEY (endpoint_id)
+);
+
+create index webhook_endpoints_merchant_idx on webhook_endpoints (merchant_id) where deleted_timestamp is null;
+
+alter table
+    webhook_endpoints owner to user1;
+
+create type webhook_delivery_status as enum (
+    'DELIVERY_PENDING',
+    'DELIVERED',
+    'DEAD_LETTER'
+    );
+
+alter type webhook_delivery_status owner to user1;
+
+-- a delivery is added with the status change it sends, in the same transaction. The dispatcher
+-- leases a pending delivery by moving next_attempt_timestamp past the attempt
+create table webhook_deliveries
+(
+    delivery_id            bigserial                           NOT NULL,
+    endpoint_id            varchar                             NOT NULL REFERENCES webhook_endpoints (endpoint_id),
+    status_history_id      bigint                              NOT NULL REFERENCES payment_status_history (id),
+    status                 webhook_delivery_status default 'DELIVERY_PENDING' not null,
+    attempts               int       default 0                 not null,
+    last_response_code     int NULL,
+    last_error             varchar NULL,
+    next_attempt_timestamp timestamp default CURRENT_TIMESTAMP not null,
+    insert_timestamp       timestamp default CURRENT_TIMESTAMP not null,
+    updated_timestamp      timestamp default CURRENT_TIMESTAMP not null,
+    PRIMARY KEY (delivery_id)
+);
+
+create index webhook_deliveries_pending_idx on webhook_deliveries (next_attempt_timestamp) where status = 'DELIVERY_PENDING';
+
+create index webhook_deliveries_endpoint_idx on webhook_deliveries (endpoint_id, delivery_id);
+
+alter table
+    webhook_deliveries owner to user1;
+
+commit;
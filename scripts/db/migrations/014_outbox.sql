@@ -0,0 +1,24 @@
+-- Adds the outbox of payment events published by the outbox relay.
+-- init.sql already creates it, this only needs running against databases created before.
+
+begin;
+
+-- payment events written in the transaction that changed the payment, the relay publishes them in id order
+-- and marks them published, so events are published at least once and in order for each payment
+create table outbox
+(
+    id                  bigserial                           NOT NULL,
+    aggregate_id        varchar                             NOT NULL,
+    event_type          varchar                             NOT NULL,
+    payload             jsonb                               NOT NULL,
+    insert_timestamp    timestamp default CURRENT_TIMESTAMP not null,
+    published_timestamp timestamp NULL,
+    PRIMARY KEY (id)
+);
+
+create index outbox_unpublished_idx on outbox (id) where published_timestamp is null;
+
+alter table
+    outbox owner to user1;
+
+commit;
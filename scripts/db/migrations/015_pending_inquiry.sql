@@ -0,0 +1,16 @@
+-- Adds the status of pending payments the acquirer gave no final outcome for in time, and the
+-- bookkeeping of the status inquiries made for pending payments.
+-- init.sql already creates them, this only needs running against databases created before.
+-- Postgres before 12 cannot add an enum value inside a transaction, so it is added first.
+
+alter type payment_status add value if not exists 'EXPIRED';
+
+begin;
+
+alter table payment_details
+    add column inquiry_attempts integer default 0 not null,
+    add column last_inquiry_timestamp timestamp NULL;
+
+create index payment_details_pending_idx on payment_details (updated_timestamp) where status = 'PENDING';
+
+commit;
@@ -0,0 +1,15 @@
+-- Adds the statuses of payments stored before they are authorized and of authorizations reversed
+-- after their outcome was not received, and claims both pending and unfinished authorizations.
+-- init.sql already creates them, this only needs running against databases created before.
+-- Postgres before 12 cannot add an enum value inside a transaction, so they are added first.
+
+alter type payment_status add value if not exists 'AUTHORIZING';
+alter type payment_status add value if not exists 'REVERSED';
+
+begin;
+
+drop index if exists payment_details_pending_idx;
+
+create index payment_details_pending_idx on payment_details (updated_timestamp) where status in ('PENDING', 'AUTHORIZING');
+
+commit;
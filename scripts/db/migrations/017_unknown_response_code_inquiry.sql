@@ -0,0 +1,11 @@
+-- Claims the payments with an unknown response code along with the pending payments, so they are
+-- resolved with their acquirer or reversed.
+-- init.sql already creates the index, this only needs running against databases created before.
+
+begin;
+
+drop index if exists payment_details_pending_idx;
+
+create index payment_details_pending_idx on payment_details (updated_timestamp) where status in ('PENDING', 'AUTHORIZING', 'UNKNOWN_RESPONSE_CODE');
+
+commit;
@@ -0,0 +1,19 @@
+-- Settles partially refunded payments for their captured amount less their refunds, and stores the amount each
+-- payment was submitted for so settlement files are reconciled against it.
+-- init.sql already creates them, this only needs running against databases created before.
+
+begin;
+
+alter table payment_details
+    add column settlement_amount_minor_units bigint NULL;
+
+-- payments were submitted for their captured amount before
+update payment_details
+set settlement_amount_minor_units = captured_amount_minor_units
+where settlement_batch_id is not null;
+
+drop index if exists payment_details_captured_idx;
+
+create index payment_details_captured_idx on payment_details (acquirer, updated_timestamp) where status in ('CAPTURED', 'PARTIALLY_REFUNDED');
+
+commit;
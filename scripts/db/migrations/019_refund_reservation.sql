@@ -0,0 +1,10 @@
+-- Reserves the amount of a refund before it is sent to the acquirer, so concurrent refunds of a payment cannot
+-- together exceed its captured amount.
+-- init.sql already creates the column, this only needs running against databases created before.
+
+begin;
+
+alter table payment_details
+    add column pending_refund_amount_minor_units bigint NULL;
+
+commit;
@@ -0,0 +1,110 @@
+package server
+
+import (
+	"context"
+	"errors"
+
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	bank "payments_gateway/aquiring-bank"
+	"payments_gateway/breaker"
+	"payments_gateway/errcodes"
+	protos "payments_gateway/protos"
+)
+
+var (
+	_errUnknownAcquirer = errcodes.Error(codes.Internal, errcodes.Internal, "acquirer is not registered")
+	_errBankUnavailable = errcodes.Error(codes.Unavailable, errcodes.BankUnavailable, "acquiring bank is unavailable")
+	_circuitStates      = map[breaker.State]protos.CircuitState{
+		breaker.Closed:   protos.CircuitState_CLOSED,
+		breaker.Open:     protos.CircuitState_OPEN,
+		breaker.HalfOpen: protos.CircuitState_HALF_OPEN,
+	}
+)
+
+// breakerStats is implemented by acquirer clients guarded by a circuit breaker
+type breakerStats interface {
+	Stats() breaker.Stats
+}
+
+// ListAcquirers returns every registered acquirer with the state of its circuit breaker and the
+// unknown response codes it returned
+func (s *server) ListAcquirers(_ context.Context, _ *protos.ListAcquirersRequest) (*protos.ListAcquirersResponse, error) {
+	resp := &protos.ListAcquirersResponse{}
+
+	for _, name := range s.acquirers.Names() {
+		client, _ := s.acquirers.Get(name)
+
+		status := &protos.AcquirerStatus{
+			Name:                 name,
+			Failover:             s.router.Failover(name),
+			UnknownResponseCodes: s.responseCodes.UnknownCodes(name),
+		}
+
+		if guarded, ok := client.(breakerStats); ok {
+			stats := guarded.Stats()
+
+			status.CircuitState = _circuitStates[stats.State]
+			status.Calls = int32(stats.Calls)
+			status.Failures = int32(stats.Failures)
+			status.SlowCalls = int32(stats.SlowCalls)
+
+			if !stats.OpenedAt.IsZero() {
+				status.OpenedTimestamp = timestamppb.New(stats.OpenedAt)
+			}
+		}
+
+		resp.Acquirers = append(resp.Acquirers, status)
+	}
+
+	return resp, nil
+}
+
+// acquirer returns the client of a registered acquirer. Payments stored before routing was
+// added have no acquirer and were sent to the default acquirer
+func (s *server) acquirer(name string) (bank.Client, error) {
+	if name == "" {
+		name = bank.DefaultAcquirer
+	}
+
+	client, ok := s.acquirers.Get(name)
+	if !ok {
+		log.WithField("acquirer", name).Error("acquirer is not registered")
+
+		return nil, _errUnknownAcquirer
+	}
+
+	return client, nil
+}
+
+// withFailover makes a call to an acquirer and makes it again with its secondary acquirer when
+// the circuit breaker of the first one is open. The call is given the name of the acquirer it is
+// made with, withFailover returns the acquirer that made the call
+func (s *server) withFailover(acquirer string, client bank.Client, call func(string, bank.Client) error) (string, bank.Client, error) {
+	err := call(acquirer, client)
+	if !errors.Is(err, breaker.ErrOpen) {
+		return acquirer, client, err
+	}
+
+	secondary := s.router.Failover(acquirer)
+
+	secondaryClient, ok := s.acquirers.Get(secondary)
+	if !ok {
+		return acquirer, client, err
+	}
+
+	log.WithField("acquirer", acquirer).WithField("failover", secondary).Warn("circuit breaker of acquirer is open, failing over")
+
+	return secondary, secondaryClient, call(secondary, secondaryClient)
+}
+
+// bankError converts the error of a call to an acquirer into the error returned to the caller
+func bankError(err error, operation string) error {
+	if errors.Is(err, breaker.ErrOpen) {
+		return _errBankUnavailable
+	}
+
+	return errcodes.Error(codes.Internal, errcodes.BankUnavailable, operation)
+}
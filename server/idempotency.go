@@ -0,0 +1,142 @@
+package server
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/proto"
+
+	"payments_gateway/errcodes"
+	protos "payments_gateway/protos"
+)
+
+var (
+	_errIdempotencyKeyReused     = errcodes.Error(codes.FailedPrecondition, errcodes.IdempotencyKeyReused, "idempotency key was used with a different request")
+	_errIdempotencyKeyInProgress = errcodes.Error(codes.AlreadyExists, errcodes.IdempotencyKeyInUse, "request with idempotency key is still being processed")
+	_errIdempotencyKey           = errcodes.Error(codes.Internal, errcodes.Internal, "error checking idempotency key")
+)
+
+// _requestHashPrefix marks the request hashes keyed with the idempotency hash key, keys created before
+// store the plain SHA-256 of the request
+const _requestHashPrefix = "hmac-sha256:"
+
+// WithIdempotencyHashKey keys the hashes of the requests stored with idempotency keys, so the card
+// details of a request cannot be recovered from its hash without the key
+func WithIdempotencyHashKey(key []byte) Option {
+	return func(s *server) {
+		s.idempotencyHashKey = key
+	}
+}
+
+// processIdempotentPayment processes a payment once per merchant and idempotency key,
+// a retry of the same request returns the original response
+func (s *server) processIdempotentPayment(ctx context.Context, merchantID string, request *protos.ProcessPaymentRequest) (*protos.ProcessPaymentResponse, error) {
+	key := request.GetIdempotencyKey()
+
+	hash, err := s.requestHash(request)
+	if err != nil {
+		log.WithField("idempotency_key", key).WithError(err).Error("hashing request")
+
+		return nil, _errIdempotencyKey
+	}
+
+	created, err := s.dbClient.CreateIdempotencyKey(ctx, merchantID, key, hash)
+	if err != nil {
+		log.WithField("idempotency_key", key).WithError(err).Error("creating idempotency key")
+
+		return nil, _errIdempotencyKey
+	}
+
+	if !created {
+		return s.replayPayment(ctx, merchantID, key, request, hash)
+	}
+
+	resp, err := s.processPayment(ctx, request)
+	if err != nil {
+		// the payment was not stored or not authorized, its authorization is reversed when it may
+		// have been approved, so the key is released to allow the merchant to retry
+		if err := s.dbClient.DeleteIdempotencyKey(ctx, merchantID, key); err != nil {
+			log.WithField("idempotency_key", key).WithError(err).Error("releasing idempotency key")
+		}
+
+		return resp, err
+	}
+
+	if err := s.dbClient.SaveIdempotentResponse(ctx, merchantID, key, resp); err != nil {
+		// the payment has been processed, so it is still returned to the merchant
+		log.WithField("idempotency_key", key).WithError(err).Error("saving idempotent response")
+	}
+
+	return resp, nil
+}
+
+// replayPayment returns the response of a request that was already made with an idempotency key
+func (s *server) replayPayment(ctx context.Context, merchantID, key string, request *protos.ProcessPaymentRequest, hash string) (*protos.ProcessPaymentResponse, error) {
+	record, err := s.dbClient.GetIdempotencyKey(ctx, merchantID, key)
+	if err != nil {
+		log.WithField("idempotency_key", key).WithError(err).Error("getting idempotency key")
+
+		return nil, _errIdempotencyKey
+	}
+
+	// the original request failed and released the key after we tried to reserve it
+	if record == nil {
+		return nil, errcodes.Error(codes.Aborted, errcodes.IdempotencyKeyInUse, "idempotency key was released, retry the request")
+	}
+
+	if !strings.HasPrefix(record.RequestHash, _requestHashPrefix) {
+		legacy, err := legacyRequestHash(request)
+		if err != nil {
+			log.WithField("idempotency_key", key).WithError(err).Error("hashing request")
+
+			return nil, _errIdempotencyKey
+		}
+
+		hash = legacy
+	}
+
+	if !hmac.Equal([]byte(record.RequestHash), []byte(hash)) {
+		return nil, _errIdempotencyKeyReused
+	}
+
+	if record.Response == nil {
+		return nil, _errIdempotencyKeyInProgress
+	}
+
+	log.WithField("idempotency_key", key).Info("replaying payment response")
+
+	return record.Response, nil
+}
+
+// requestHash fingerprints a request so a replay with a different body can be detected. The whole
+// request is hashed with HMAC-SHA256 keyed with the idempotency hash key, so a replay with another card is
+// detected while the stored hash reveals nothing about the card without the key
+func (s *server) requestHash(request *protos.ProcessPaymentRequest) (string, error) {
+	body, err := proto.MarshalOptions{Deterministic: true}.Marshal(request)
+	if err != nil {
+		return "", err
+	}
+
+	mac := hmac.New(sha256.New, s.idempotencyHashKey)
+	mac.Write(body)
+
+	return _requestHashPrefix + hex.EncodeToString(mac.Sum(nil)), nil
+}
+
+// legacyRequestHash is the plain SHA-256 hash of a request stored with the keys created before request
+// hashes were keyed
+func legacyRequestHash(request *protos.ProcessPaymentRequest) (string, error) {
+	body, err := proto.MarshalOptions{Deterministic: true}.Marshal(request)
+	if err != nil {
+		return "", err
+	}
+
+	sum := sha256.Sum256(body)
+
+	return hex.EncodeToString(sum[:]), nil
+}
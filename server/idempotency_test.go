@@ -0,0 +1,256 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	bank "payments_gateway/aquiring-bank"
+	"payments_gateway/aquiring-bank/mocks"
+	"payments_gateway/auth"
+	"payments_gateway/model"
+	"payments_gateway/money"
+	protos "payments_gateway/protos"
+	"payments_gateway/storage/mocks"
+)
+
+func Test_server_ProcessPayment_Idempotency(t *testing.T) {
+	mockController := gomock.NewController(t)
+
+	storageMock := mock_storage.NewMockClient(mockController)
+
+	bankMock := mock_bank.NewMockClient(mockController)
+
+	defer mockController.Finish()
+
+	key := "order-1234"
+
+	// idempotency keys are scoped to the authenticated merchant
+	merchant := &model.Merchant{ID: "merchant-1"}
+
+	req := &protos.ProcessPaymentRequest{
+		BillingDetails: &protos.BillingDetails{
+			Name:    "Bruce",
+			Surname: "Wayne",
+		},
+		CardNumber:     "378282246310005",
+		Expiry:         "04/30",
+		Amount:         20.5,
+		Currency:       "GBP",
+		Cvv:            3421,
+		PaymentType:    protos.PaymentType_CARD,
+		CardType:       protos.CardType_AMERICAN_EXPRESS,
+		IdempotencyKey: key,
+	}
+
+	hashKey := []byte("idempotency hash key")
+
+	hash, err := New(storageMock, bankMock, WithIdempotencyHashKey(hashKey)).requestHash(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	legacyHash, err := legacyRequestHash(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	original := &protos.ProcessPaymentResponse{
+		Reference:    "825ca1787c9d4672991848a5bfbc1057",
+		Status:       protos.Status_APPROVED,
+		StatusReason: "approved and completed successfully",
+	}
+
+	tests := []struct {
+		name                string
+		storageMockOutcomes func(storageMock *mock_storage.MockClient)
+		BankMockOutcomes    func(bankMock *mock_bank.MockClient)
+		want                *protos.ProcessPaymentResponse
+		err                 error
+	}{
+		{
+			name: "first request is processed and its response saved",
+			storageMockOutcomes: func(storageMock *mock_storage.MockClient) {
+				storageMock.EXPECT().
+					CreateIdempotencyKey(gomock.Any(), merchant.ID, key, hash).
+					Times(1).
+					Return(true, nil)
+				expectStored(storageMock, merchant.ID, req, money.New(2050, "GBP"), model.Authorization{Acquirer: bank.DefaultAcquirer, Status: protos.Status_APPROVED, Reason: "approved and completed successfully", ResponseCode: "00"})
+				storageMock.EXPECT().
+					SaveIdempotentResponse(gomock.Any(), merchant.ID, key, gomock.Any()).
+					Times(1).
+					Return(nil)
+			},
+			BankMockOutcomes: func(bankMock *mock_bank.MockClient) {
+				bankMock.EXPECT().
+					Validate(gomock.Any(), model.ConvertToCardDetails(req)).
+					Times(1).
+					Return(true, nil)
+				bankMock.EXPECT().
+					Authorize(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return("00", "approved and completed successfully", nil)
+			},
+			want: original,
+		},
+		{
+			name: "retry returns the original response",
+			storageMockOutcomes: func(storageMock *mock_storage.MockClient) {
+				storageMock.EXPECT().
+					CreateIdempotencyKey(gomock.Any(), merchant.ID, key, hash).
+					Times(1).
+					Return(false, nil)
+				storageMock.EXPECT().
+					GetIdempotencyKey(gomock.Any(), merchant.ID, key).
+					Times(1).
+					Return(&model.IdempotencyRecord{Key: key, RequestHash: hash, Response: original}, nil)
+			},
+			BankMockOutcomes: func(bankMock *mock_bank.MockClient) {},
+			want:             original,
+		},
+		{
+			name: "retry of a key created before hashes were keyed returns the original response",
+			storageMockOutcomes: func(storageMock *mock_storage.MockClient) {
+				storageMock.EXPECT().
+					CreateIdempotencyKey(gomock.Any(), merchant.ID, key, hash).
+					Times(1).
+					Return(false, nil)
+				storageMock.EXPECT().
+					GetIdempotencyKey(gomock.Any(), merchant.ID, key).
+					Times(1).
+					Return(&model.IdempotencyRecord{Key: key, RequestHash: legacyHash, Response: original}, nil)
+			},
+			BankMockOutcomes: func(bankMock *mock_bank.MockClient) {},
+			want:             original,
+		},
+		{
+			name: "retry with a different request is rejected",
+			storageMockOutcomes: func(storageMock *mock_storage.MockClient) {
+				storageMock.EXPECT().
+					CreateIdempotencyKey(gomock.Any(), merchant.ID, key, hash).
+					Times(1).
+					Return(false, nil)
+				storageMock.EXPECT().
+					GetIdempotencyKey(gomock.Any(), merchant.ID, key).
+					Times(1).
+					Return(&model.IdempotencyRecord{Key: key, RequestHash: "another request", Response: original}, nil)
+			},
+			BankMockOutcomes: func(bankMock *mock_bank.MockClient) {},
+			err:              fmt.Errorf("rpc error: code = FailedPrecondition desc = idempotency key was used with a different request"),
+		},
+		{
+			name: "retry while the original request is being processed",
+			storageMockOutcomes: func(storageMock *mock_storage.MockClient) {
+				storageMock.EXPECT().
+					CreateIdempotencyKey(gomock.Any(), merchant.ID, key, hash).
+					Times(1).
+					Return(false, nil)
+				storageMock.EXPECT().
+					GetIdempotencyKey(gomock.Any(), merchant.ID, key).
+					Times(1).
+					Return(&model.IdempotencyRecord{Key: key, RequestHash: hash}, nil)
+			},
+			BankMockOutcomes: func(bankMock *mock_bank.MockClient) {},
+			err:              fmt.Errorf("rpc error: code = AlreadyExists desc = request with idempotency key is still being processed"),
+		},
+		{
+			name: "failed request releases the key",
+			storageMockOutcomes: func(storageMock *mock_storage.MockClient) {
+				storageMock.EXPECT().
+					CreateIdempotencyKey(gomock.Any(), merchant.ID, key, hash).
+					Times(1).
+					Return(true, nil)
+				expectReversed(storageMock, merchant.ID, req)
+				storageMock.EXPECT().
+					DeleteIdempotencyKey(gomock.Any(), merchant.ID, key).
+					Times(1).
+					Return(nil)
+			},
+			BankMockOutcomes: func(bankMock *mock_bank.MockClient) {
+				bankMock.EXPECT().
+					Validate(gomock.Any(), model.ConvertToCardDetails(req)).
+					Times(1).
+					Return(true, nil)
+				bankMock.EXPECT().
+					Authorize(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return("", "", fmt.Errorf("connection refused"))
+				bankMock.EXPECT().
+					Reverse(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return("00", "authorization reversed", nil)
+			},
+			err: fmt.Errorf("rpc error: code = Internal desc = authorize transaction"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.storageMockOutcomes(storageMock)
+			tt.BankMockOutcomes(bankMock)
+
+			s := New(storageMock, bankMock, WithIdempotencyHashKey(hashKey))
+
+			got, err := s.ProcessPayment(auth.NewContext(context.Background(), merchant), req)
+			if err != nil {
+				assert.Equal(t, tt.err.Error(), err.Error())
+
+				return
+			}
+
+			assert.Equal(t, tt.want.Status, got.Status)
+			assert.Equal(t, tt.want.StatusReason, got.StatusReason)
+		})
+	}
+}
+
+func Test_server_requestHash(t *testing.T) {
+	s := New(nil, nil, WithIdempotencyHashKey([]byte("idempotency hash key")))
+
+	req := &protos.ProcessPaymentRequest{
+		CardNumber: "378282246310005",
+		Expiry:     "04/30",
+		Amount:     20.5,
+		Currency:   "GBP",
+		Cvv:        3421,
+	}
+
+	hash, err := s.requestHash(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.True(t, strings.HasPrefix(hash, "hmac-sha256:"))
+
+	legacy, err := legacyRequestHash(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.NotEqual(t, "hmac-sha256:"+legacy, hash, "the hash is keyed")
+
+	// another card with the same last four digits is a different request
+	otherCard, err := s.requestHash(&protos.ProcessPaymentRequest{CardNumber: "371449635390005", Expiry: "04/30", Amount: 20.5, Currency: "GBP", Cvv: 3421})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.NotEqual(t, hash, otherCard)
+
+	otherCvv, err := s.requestHash(&protos.ProcessPaymentRequest{CardNumber: "378282246310005", Expiry: "04/30", Amount: 20.5, Currency: "GBP", Cvv: 1234})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.NotEqual(t, hash, otherCvv)
+
+	otherKey, err := New(nil, nil, WithIdempotencyHashKey([]byte("another key"))).requestHash(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.NotEqual(t, hash, otherKey)
+}
@@ -0,0 +1,234 @@
+package server
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	"payments_gateway/auth"
+	"payments_gateway/errcodes"
+	"payments_gateway/model"
+	protos "payments_gateway/protos"
+)
+
+const (
+	_defaultPageSize = 50
+	_maxPageSize     = 500
+)
+
+var (
+	_errListingPayments = errcodes.Error(codes.Internal, errcodes.Internal, "error listing payments")
+	_errInvalidToken    = errcodes.Error(codes.InvalidArgument, errcodes.InvalidPageToken, "invalid page token")
+	_errTokenFilters    = errcodes.Error(codes.InvalidArgument, errcodes.InvalidPageToken, "page token was issued for different filters")
+)
+
+// pageToken is the position of the last item of a page, the ref of a payment or the ID of a webhook
+// delivery, with a hash of the filters and order of the request so a token cannot be used to page
+// through different results
+type pageToken struct {
+	InsertTime time.Time `json:"t"`
+	ID         string    `json:"r"`
+	Filters    string    `json:"f"`
+}
+
+// ListPayments returns the payments of the merchant matching the filters of the request a page at
+// a time, pages are found by keyset so payments added while paging do not shift later pages
+func (s *server) ListPayments(ctx context.Context, request *protos.ListPaymentsRequest) (*protos.ListPaymentsResponse, error) {
+	filter, err := paymentFilter(request)
+	if err != nil {
+		log.WithField("request", request).Warn("request contains invalid filters")
+
+		return nil, err
+	}
+
+	filters, err := filtersHash(request)
+	if err != nil {
+		log.WithError(err).Error("hashing list filters")
+
+		return nil, _errListingPayments
+	}
+
+	if request.GetPageToken() != "" {
+		token, err := decodePageToken(request.GetPageToken(), filters)
+		if err != nil {
+			return nil, err
+		}
+
+		filter.After = &model.PaymentCursor{InsertTime: token.InsertTime, RefID: token.ID}
+	}
+
+	pageSize := int(request.GetPageSize())
+	if pageSize == 0 {
+		pageSize = _defaultPageSize
+	} else if pageSize > _maxPageSize {
+		pageSize = _maxPageSize
+	}
+
+	// one more payment than the page holds tells whether there is a next page
+	filter.Limit = pageSize + 1
+
+	payments, err := s.dbClient.ListPayments(ctx, auth.MerchantID(ctx), filter)
+	if err != nil {
+		log.WithError(err).Error("listing payments")
+
+		return nil, _errListingPayments
+	}
+
+	resp := &protos.ListPaymentsResponse{Payments: payments}
+
+	if len(payments) > pageSize {
+		resp.Payments = payments[:pageSize]
+
+		last := resp.Payments[pageSize-1]
+
+		resp.NextPageToken, err = encodePageToken(pageToken{
+			InsertTime: last.GetInsertTimestamp().AsTime(),
+			ID:         last.GetRef(),
+			Filters:    filters,
+		})
+		if err != nil {
+			log.WithError(err).Error("encoding page token")
+
+			return nil, _errListingPayments
+		}
+	}
+
+	return resp, nil
+}
+
+// paymentFilter converts the filters of a request, returning an InvalidArgument error naming every
+// invalid filter
+func paymentFilter(request *protos.ListPaymentsRequest) (model.PaymentFilter, error) {
+	var violations []*errdetails.BadRequest_FieldViolation
+
+	for _, status := range request.GetStatuses() {
+		if _, ok := protos.Status_name[int32(status)]; !ok || status == protos.Status_UNKNOWN {
+			violations = append(violations, errcodes.FieldViolation("statuses", "is not a payment status"))
+
+			break
+		}
+	}
+
+	if request.GetFromTimestamp() != nil && request.GetToTimestamp() != nil &&
+		!request.GetFromTimestamp().AsTime().Before(request.GetToTimestamp().AsTime()) {
+		violations = append(violations, errcodes.FieldViolation("to_timestamp", "must be after from_timestamp"))
+	}
+
+	if request.GetMinAmountMinorUnits() < 0 {
+		violations = append(violations, errcodes.FieldViolation("min_amount_minor_units", "must not be negative"))
+	}
+
+	if request.GetMaxAmountMinorUnits() < 0 {
+		violations = append(violations, errcodes.FieldViolation("max_amount_minor_units", "must not be negative"))
+	}
+
+	if request.GetMaxAmountMinorUnits() > 0 && request.GetMinAmountMinorUnits() > request.GetMaxAmountMinorUnits() {
+		violations = append(violations, errcodes.FieldViolation("max_amount_minor_units", "must not be below min_amount_minor_units"))
+	}
+
+	if lastFour := request.GetCardLastFour(); lastFour != "" && !isDigits(lastFour, 4) {
+		violations = append(violations, errcodes.FieldViolation("card_last_four", "must be four digits"))
+	}
+
+	if request.GetPageSize() < 0 {
+		violations = append(violations, errcodes.FieldViolation("page_size", "must not be negative"))
+	}
+
+	if len(violations) > 0 {
+		return model.PaymentFilter{}, errcodes.Error(codes.InvalidArgument, errcodes.InvalidFilter, "invalid filter", violations...)
+	}
+
+	filter := model.PaymentFilter{
+		Statuses:     request.GetStatuses(),
+		Currency:     request.GetCurrency(),
+		MinAmount:    request.GetMinAmountMinorUnits(),
+		MaxAmount:    request.GetMaxAmountMinorUnits(),
+		CardLastFour: request.GetCardLastFour(),
+		Email:        request.GetEmail(),
+		Reference:    request.GetMerchantReference(),
+		Metadata:     request.GetMetadata(),
+		OldestFirst:  request.GetSortOrder() == protos.SortOrder_OLDEST_FIRST,
+	}
+
+	if request.GetFromTimestamp() != nil {
+		filter.From = request.GetFromTimestamp().AsTime()
+	}
+
+	if request.GetToTimestamp() != nil {
+		filter.To = request.GetToTimestamp().AsTime()
+	}
+
+	return filter, nil
+}
+
+// filtersHash fingerprints the filters and order of a list request, leaving out its page_size and page_token
+func filtersHash(request proto.Message) (string, error) {
+	filters := proto.Clone(request).ProtoReflect()
+
+	for _, name := range []protoreflect.Name{"page_size", "page_token"} {
+		if field := filters.Descriptor().Fields().ByName(name); field != nil {
+			filters.Clear(field)
+		}
+	}
+
+	body, err := proto.MarshalOptions{Deterministic: true}.Marshal(filters.Interface())
+	if err != nil {
+		return "", err
+	}
+
+	sum := sha256.Sum256(body)
+
+	return hex.EncodeToString(sum[:8]), nil
+}
+
+func encodePageToken(token pageToken) (string, error) {
+	body, err := json.Marshal(token)
+	if err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(body), nil
+}
+
+// decodePageToken returns a page token issued for the filters
+func decodePageToken(value, filters string) (pageToken, error) {
+	body, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return pageToken{}, _errInvalidToken
+	}
+
+	var token pageToken
+	if err := json.Unmarshal(body, &token); err != nil || token.ID == "" {
+		return pageToken{}, _errInvalidToken
+	}
+
+	if token.Filters != filters {
+		return pageToken{}, _errTokenFilters
+	}
+
+	return token, nil
+}
+
+// isDigits reports whether value is n ASCII digits
+func isDigits(value string, n int) bool {
+	if len(value) != n {
+		return false
+	}
+
+	for _, r := range value {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,175 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"payments_gateway/aquiring-bank/mocks"
+	"payments_gateway/auth"
+	"payments_gateway/errcodes"
+	"payments_gateway/model"
+	protos "payments_gateway/protos"
+	"payments_gateway/storage/mocks"
+)
+
+func Test_server_ListPayments(t *testing.T) {
+	mockController := gomock.NewController(t)
+
+	storageMock := mock_storage.NewMockClient(mockController)
+
+	bankMock := mock_bank.NewMockClient(mockController)
+
+	defer mockController.Finish()
+
+	merchant := &model.Merchant{ID: "merchant-1"}
+
+	insertTime := time.Date(2022, 5, 1, 6, 0, 0, 0, time.UTC)
+
+	payments := []*protos.GetPaymentResponse{
+		{Ref: "ref3", Status: protos.Status_CAPTURED, InsertTimestamp: timestamppb.New(insertTime.Add(2 * time.Minute))},
+		{Ref: "ref2", Status: protos.Status_CAPTURED, InsertTimestamp: timestamppb.New(insertTime.Add(time.Minute))},
+		{Ref: "ref1", Status: protos.Status_CAPTURED, InsertTimestamp: timestamppb.New(insertTime)},
+	}
+
+	request := &protos.ListPaymentsRequest{
+		Statuses: []protos.Status{protos.Status_CAPTURED},
+		Currency: "GBP",
+		PageSize: 2,
+	}
+
+	filter := model.PaymentFilter{Statuses: []protos.Status{protos.Status_CAPTURED}, Currency: "GBP", Limit: 3}
+
+	filters, err := filtersHash(request)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	token, err := encodePageToken(pageToken{InsertTime: insertTime.Add(time.Minute), ID: "ref2", Filters: filters})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	secondPage := &protos.ListPaymentsRequest{Statuses: []protos.Status{protos.Status_CAPTURED}, Currency: "GBP", PageSize: 2, PageToken: token}
+
+	otherFilters := &protos.ListPaymentsRequest{Statuses: []protos.Status{protos.Status_APPROVED}, Currency: "GBP", PageSize: 2, PageToken: token}
+
+	tests := []struct {
+		name                string
+		request             *protos.ListPaymentsRequest
+		storageMockOutcomes func(storageMock *mock_storage.MockClient)
+		want                *protos.ListPaymentsResponse
+		err                 error
+	}{
+		{
+			name:    "first page has a next page token",
+			request: request,
+			storageMockOutcomes: func(storageMock *mock_storage.MockClient) {
+				storageMock.EXPECT().ListPayments(gomock.Any(), merchant.ID, filter).Times(1).Return(payments, nil)
+			},
+			want: &protos.ListPaymentsResponse{Payments: payments[:2], NextPageToken: token},
+		},
+		{
+			name:    "last page starts after the token",
+			request: secondPage,
+			storageMockOutcomes: func(storageMock *mock_storage.MockClient) {
+				next := filter
+				next.After = &model.PaymentCursor{InsertTime: insertTime.Add(time.Minute), RefID: "ref2"}
+
+				storageMock.EXPECT().ListPayments(gomock.Any(), merchant.ID, next).Times(1).Return(payments[2:], nil)
+			},
+			want: &protos.ListPaymentsResponse{Payments: payments[2:]},
+		},
+		{
+			name:    "oldest first with the default page size",
+			request: &protos.ListPaymentsRequest{SortOrder: protos.SortOrder_OLDEST_FIRST, FromTimestamp: timestamppb.New(insertTime)},
+			storageMockOutcomes: func(storageMock *mock_storage.MockClient) {
+				storageMock.EXPECT().
+					ListPayments(gomock.Any(), merchant.ID, model.PaymentFilter{From: insertTime, OldestFirst: true, Limit: 51}).
+					Times(1).
+					Return(nil, nil)
+			},
+			want: &protos.ListPaymentsResponse{},
+		},
+		{
+			name:    "page size is capped",
+			request: &protos.ListPaymentsRequest{PageSize: 10000},
+			storageMockOutcomes: func(storageMock *mock_storage.MockClient) {
+				storageMock.EXPECT().ListPayments(gomock.Any(), merchant.ID, model.PaymentFilter{Limit: 501}).Times(1).Return(nil, nil)
+			},
+			want: &protos.ListPaymentsResponse{},
+		},
+		{
+			name: "invalid filters",
+			request: &protos.ListPaymentsRequest{
+				Statuses:            []protos.Status{protos.Status_UNKNOWN},
+				FromTimestamp:       timestamppb.New(insertTime),
+				ToTimestamp:         timestamppb.New(insertTime),
+				MinAmountMinorUnits: 500,
+				MaxAmountMinorUnits: 100,
+				CardLastFour:        "00X5",
+			},
+			storageMockOutcomes: func(storageMock *mock_storage.MockClient) {},
+			err:                 fmt.Errorf("rpc error: code = InvalidArgument desc = invalid filter"),
+		},
+		{
+			name:                "token of different filters",
+			request:             otherFilters,
+			storageMockOutcomes: func(storageMock *mock_storage.MockClient) {},
+			err:                 fmt.Errorf("rpc error: code = InvalidArgument desc = page token was issued for different filters"),
+		},
+		{
+			name:                "malformed token",
+			request:             &protos.ListPaymentsRequest{PageToken: "not a token"},
+			storageMockOutcomes: func(storageMock *mock_storage.MockClient) {},
+			err:                 fmt.Errorf("rpc error: code = InvalidArgument desc = invalid page token"),
+		},
+		{
+			name:    "storage error",
+			request: request,
+			storageMockOutcomes: func(storageMock *mock_storage.MockClient) {
+				storageMock.EXPECT().ListPayments(gomock.Any(), merchant.ID, filter).Times(1).Return(nil, errors.New("connection refused"))
+			},
+			err: fmt.Errorf("rpc error: code = Internal desc = error listing payments"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.storageMockOutcomes(storageMock)
+
+			s := New(storageMock, bankMock)
+
+			got, err := s.ListPayments(auth.NewContext(context.Background(), merchant), tt.request)
+			if tt.err != nil {
+				assert.Equal(t, tt.err.Error(), err.Error())
+
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want.String(), got.String())
+		})
+	}
+}
+
+func Test_paymentFilter_Violations(t *testing.T) {
+	_, err := paymentFilter(&protos.ListPaymentsRequest{
+		Statuses:            []protos.Status{protos.Status_APPROVED, protos.Status(42)},
+		MinAmountMinorUnits: -1,
+		CardLastFour:        "12345",
+		PageSize:            -1,
+	})
+
+	var fields []string
+	for _, violation := range errcodes.FieldViolations(err) {
+		fields = append(fields, violation.GetField())
+	}
+
+	assert.Equal(t, []string{"statuses", "min_amount_minor_units", "card_last_four", "page_size"}, fields)
+}
@@ -0,0 +1,67 @@
+package server
+
+import (
+	"context"
+
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"payments_gateway/errcodes"
+	protos "payments_gateway/protos"
+	"payments_gateway/reconciliation"
+)
+
+var (
+	_errReportNotFound              = errcodes.Error(codes.NotFound, errcodes.ReportNotFound, "reconciliation report not found")
+	_errGettingReconciliationReport = errcodes.Error(codes.Internal, errcodes.Internal, "error getting reconciliation report")
+)
+
+// GetReconciliationReport returns the report of a reconciled settlement file, optionally only the
+// records with one result
+func (s *server) GetReconciliationReport(ctx context.Context, request *protos.GetReconciliationReportRequest) (*protos.GetReconciliationReportResponse, error) {
+	if err := missingParameters(field{name: "report_id", set: request.GetReportId() != ""}); err != nil {
+		return nil, err
+	}
+
+	report, err := s.dbClient.GetReconciliationReport(ctx, request.GetReportId())
+	if err != nil {
+		log.WithField("report_id", request.GetReportId()).WithError(err).Error("getting reconciliation report")
+
+		return nil, _errGettingReconciliationReport
+	}
+
+	if report == nil {
+		return nil, _errReportNotFound
+	}
+
+	counts := reconciliation.Counts(report)
+
+	resp := &protos.GetReconciliationReportResponse{
+		ReportId:         report.ID,
+		Acquirer:         report.Acquirer,
+		FileName:         report.FileName,
+		CreatedTimestamp: timestamppb.New(report.CreatedAt),
+		Matched:          int32(counts[protos.ReconciliationResult_MATCHED]),
+		MissingAtBank:    int32(counts[protos.ReconciliationResult_MISSING_AT_BANK]),
+		MissingInGateway: int32(counts[protos.ReconciliationResult_MISSING_IN_GATEWAY]),
+		AmountMismatch:   int32(counts[protos.ReconciliationResult_AMOUNT_MISMATCH]),
+	}
+
+	for _, record := range report.Records {
+		if request.GetResult() != protos.ReconciliationResult_UNSPECIFIED_RESULT && record.Result != request.GetResult() {
+			continue
+		}
+
+		resp.Records = append(resp.Records, &protos.ReconciliationRecord{
+			Ref:                     record.RefID,
+			Result:                  record.Result,
+			GatewayAmountMinorUnits: record.GatewayAmount.Amount,
+			GatewayCurrency:         record.GatewayAmount.Currency,
+			BankAmountMinorUnits:    record.BankAmount.Amount,
+			BankCurrency:            record.BankAmount.Currency,
+		})
+	}
+
+	return resp, nil
+}
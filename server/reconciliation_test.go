@@ -0,0 +1,115 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"payments_gateway/aquiring-bank/mocks"
+	"payments_gateway/model"
+	"payments_gateway/money"
+	protos "payments_gateway/protos"
+	"payments_gateway/storage/mocks"
+)
+
+func Test_server_GetReconciliationReport(t *testing.T) {
+	mockController := gomock.NewController(t)
+
+	storageMock := mock_storage.NewMockClient(mockController)
+
+	bankMock := mock_bank.NewMockClient(mockController)
+
+	defer mockController.Finish()
+
+	createdAt := time.Date(2022, 5, 1, 6, 0, 0, 0, time.UTC)
+
+	report := &model.ReconciliationReport{
+		ID:        "report1",
+		Acquirer:  "eu",
+		FileName:  "2022-05-01.csv",
+		CreatedAt: createdAt,
+		Records: []model.ReconciliationRecord{
+			{RefID: "ref1", Result: protos.ReconciliationResult_MATCHED, GatewayAmount: money.New(2050, "GBP"), BankAmount: money.New(2050, "GBP")},
+			{RefID: "ref2", Result: protos.ReconciliationResult_MISSING_AT_BANK, GatewayAmount: money.New(1000, "GBP")},
+			{RefID: "ref3", Result: protos.ReconciliationResult_AMOUNT_MISMATCH, GatewayAmount: money.New(2050, "GBP"), BankAmount: money.New(2000, "GBP")},
+		},
+	}
+
+	tests := []struct {
+		name                string
+		request             *protos.GetReconciliationReportRequest
+		storageMockOutcomes func(storageMock *mock_storage.MockClient)
+		want                *protos.GetReconciliationReportResponse
+		err                 error
+	}{
+		{
+			name:    "records with one result",
+			request: &protos.GetReconciliationReportRequest{ReportId: "report1", Result: protos.ReconciliationResult_AMOUNT_MISMATCH},
+			storageMockOutcomes: func(storageMock *mock_storage.MockClient) {
+				storageMock.EXPECT().GetReconciliationReport(gomock.Any(), "report1").Times(1).Return(report, nil)
+			},
+			want: &protos.GetReconciliationReportResponse{
+				ReportId:         "report1",
+				Acquirer:         "eu",
+				FileName:         "2022-05-01.csv",
+				CreatedTimestamp: timestamppb.New(createdAt),
+				Matched:          1,
+				MissingAtBank:    1,
+				AmountMismatch:   1,
+				Records: []*protos.ReconciliationRecord{{
+					Ref:                     "ref3",
+					Result:                  protos.ReconciliationResult_AMOUNT_MISMATCH,
+					GatewayAmountMinorUnits: 2050,
+					GatewayCurrency:         "GBP",
+					BankAmountMinorUnits:    2000,
+					BankCurrency:            "GBP",
+				}},
+			},
+		},
+		{
+			name:    "report not found",
+			request: &protos.GetReconciliationReportRequest{ReportId: "report2"},
+			storageMockOutcomes: func(storageMock *mock_storage.MockClient) {
+				storageMock.EXPECT().GetReconciliationReport(gomock.Any(), "report2").Times(1).Return(nil, nil)
+			},
+			err: fmt.Errorf("rpc error: code = NotFound desc = reconciliation report not found"),
+		},
+		{
+			name:    "storage error",
+			request: &protos.GetReconciliationReportRequest{ReportId: "report3"},
+			storageMockOutcomes: func(storageMock *mock_storage.MockClient) {
+				storageMock.EXPECT().GetReconciliationReport(gomock.Any(), "report3").Times(1).Return(nil, errors.New("connection refused"))
+			},
+			err: fmt.Errorf("rpc error: code = Internal desc = error getting reconciliation report"),
+		},
+		{
+			name:                "missing report id",
+			request:             &protos.GetReconciliationReportRequest{},
+			storageMockOutcomes: func(storageMock *mock_storage.MockClient) {},
+			err:                 fmt.Errorf("rpc error: code = InvalidArgument desc = missing parameter"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.storageMockOutcomes(storageMock)
+
+			s := New(storageMock, bankMock)
+
+			got, err := s.GetReconciliationReport(context.Background(), tt.request)
+			if tt.err != nil {
+				assert.Equal(t, tt.err.Error(), err.Error())
+
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want.String(), got.String())
+		})
+	}
+}
@@ -0,0 +1,113 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"unicode/utf8"
+
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+
+	"payments_gateway/auth"
+	"payments_gateway/errcodes"
+	protos "payments_gateway/protos"
+)
+
+const (
+	_maxReferenceLength     = 128
+	_maxMetadataKeys        = 20
+	_maxMetadataKeyLength   = 40
+	_maxMetadataValueLength = 500
+)
+
+var (
+	_errMerchantReferenceInUse = errcodes.Error(codes.AlreadyExists, errcodes.MerchantReferenceInUse, "merchant reference was already used")
+	_errReservingReference     = errcodes.Error(codes.Internal, errcodes.Internal, "error reserving merchant reference")
+)
+
+// WithUniqueMerchantReferences rejects payments made with a merchant reference the merchant already
+// used, by default references may be reused
+func WithUniqueMerchantReferences() Option {
+	return func(s *server) {
+		s.uniqueReferences = true
+	}
+}
+
+// processPayment processes a payment, reserving its merchant reference first when references must
+// be unique. The reference is released again when the payment is not stored or not authorized
+func (s *server) processPayment(ctx context.Context, request *protos.ProcessPaymentRequest) (*protos.ProcessPaymentResponse, error) {
+	if err := validateMerchantData(request.GetMerchantReference(), request.GetMetadata()); err != nil {
+		log.WithField("request", request).Warn("request contains invalid merchant data")
+
+		return nil, err
+	}
+
+	reference := request.GetMerchantReference()
+	if !s.uniqueReferences || reference == "" {
+		return s.authorizePayment(ctx, request)
+	}
+
+	merchantID := auth.MerchantID(ctx)
+
+	reserved, err := s.dbClient.ReserveMerchantReference(ctx, merchantID, reference)
+	if err != nil {
+		log.WithField("merchant_reference", reference).WithError(err).Error("reserving merchant reference")
+
+		return nil, _errReservingReference
+	}
+
+	if !reserved {
+		return nil, _errMerchantReferenceInUse
+	}
+
+	resp, err := s.authorizePayment(ctx, request)
+	if err != nil {
+		if err := s.dbClient.ReleaseMerchantReference(ctx, merchantID, reference); err != nil {
+			log.WithField("merchant_reference", reference).WithError(err).Error("releasing merchant reference")
+		}
+
+		return resp, err
+	}
+
+	return resp, nil
+}
+
+// validateMerchantData checks the merchant reference and metadata of a payment are within their limits
+func validateMerchantData(reference string, metadata map[string]string) error {
+	var violations []*errdetails.BadRequest_FieldViolation
+
+	if utf8.RuneCountInString(reference) > _maxReferenceLength {
+		violations = append(violations, errcodes.FieldViolation("merchant_reference", fmt.Sprintf("must be at most %d characters", _maxReferenceLength)))
+	}
+
+	if len(metadata) > _maxMetadataKeys {
+		violations = append(violations, errcodes.FieldViolation("metadata", fmt.Sprintf("must have at most %d keys", _maxMetadataKeys)))
+	}
+
+	keys := make([]string, 0, len(metadata))
+	for key := range metadata {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		if key == "" || utf8.RuneCountInString(key) > _maxMetadataKeyLength {
+			violations = append(violations, errcodes.FieldViolation("metadata", fmt.Sprintf("keys must be 1 to %d characters", _maxMetadataKeyLength)))
+
+			break
+		}
+
+		if utf8.RuneCountInString(metadata[key]) > _maxMetadataValueLength {
+			violations = append(violations, errcodes.FieldViolation("metadata["+key+"]", fmt.Sprintf("must be at most %d characters", _maxMetadataValueLength)))
+		}
+	}
+
+	if len(violations) == 0 {
+		return nil
+	}
+
+	return errcodes.Error(codes.InvalidArgument, errcodes.InvalidMetadata, "invalid merchant reference or metadata", violations...)
+}
@@ -0,0 +1,242 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+
+	bank "payments_gateway/aquiring-bank"
+	"payments_gateway/aquiring-bank/mocks"
+	"payments_gateway/errcodes"
+	"payments_gateway/model"
+	"payments_gateway/money"
+	protos "payments_gateway/protos"
+	"payments_gateway/storage/mocks"
+)
+
+func Test_server_ProcessPayment_MerchantReference(t *testing.T) {
+	mockController := gomock.NewController(t)
+
+	storageMock := mock_storage.NewMockClient(mockController)
+
+	bankMock := mock_bank.NewMockClient(mockController)
+
+	defer mockController.Finish()
+
+	reference := "order-1234"
+
+	req := &protos.ProcessPaymentRequest{
+		BillingDetails: &protos.BillingDetails{
+			Name:    "Bruce",
+			Surname: "Wayne",
+		},
+		CardNumber:        "378282246310005",
+		Expiry:            "04/30",
+		Amount:            20.5,
+		Currency:          "GBP",
+		Cvv:               3421,
+		PaymentType:       protos.PaymentType_CARD,
+		CardType:          protos.CardType_AMERICAN_EXPRESS,
+		MerchantReference: reference,
+		Metadata:          map[string]string{"channel": "web"},
+	}
+
+	approved := func(bankMock *mock_bank.MockClient) {
+		bankMock.EXPECT().
+			Validate(gomock.Any(), model.ConvertToCardDetails(req)).
+			Times(1).
+			Return(true, nil)
+		bankMock.EXPECT().
+			Authorize(gomock.Any(), gomock.Any()).
+			Times(1).
+			Return("00", "approved and completed successfully", nil)
+	}
+
+	stored := func(storageMock *mock_storage.MockClient) {
+		expectStored(storageMock, "", req, money.New(2050, "GBP"), model.Authorization{Acquirer: bank.DefaultAcquirer, Status: protos.Status_APPROVED, Reason: "approved and completed successfully", ResponseCode: "00"})
+	}
+
+	tests := []struct {
+		name                string
+		opts                []Option
+		storageMockOutcomes func(storageMock *mock_storage.MockClient)
+		BankMockOutcomes    func(bankMock *mock_bank.MockClient)
+		want                protos.Status
+		err                 error
+	}{
+		{
+			name:                "references may be reused by default",
+			storageMockOutcomes: stored,
+			BankMockOutcomes:    approved,
+			want:                protos.Status_APPROVED,
+		},
+		{
+			name: "unique reference is reserved",
+			opts: []Option{WithUniqueMerchantReferences()},
+			storageMockOutcomes: func(storageMock *mock_storage.MockClient) {
+				storageMock.EXPECT().
+					ReserveMerchantReference(gomock.Any(), "", reference).
+					Times(1).
+					Return(true, nil)
+				stored(storageMock)
+			},
+			BankMockOutcomes: approved,
+			want:             protos.Status_APPROVED,
+		},
+		{
+			name: "reference already used",
+			opts: []Option{WithUniqueMerchantReferences()},
+			storageMockOutcomes: func(storageMock *mock_storage.MockClient) {
+				storageMock.EXPECT().
+					ReserveMerchantReference(gomock.Any(), "", reference).
+					Times(1).
+					Return(false, nil)
+			},
+			BankMockOutcomes: func(bankMock *mock_bank.MockClient) {},
+			err:              fmt.Errorf("rpc error: code = AlreadyExists desc = merchant reference was already used"),
+		},
+		{
+			name: "error reserving reference",
+			opts: []Option{WithUniqueMerchantReferences()},
+			storageMockOutcomes: func(storageMock *mock_storage.MockClient) {
+				storageMock.EXPECT().
+					ReserveMerchantReference(gomock.Any(), "", reference).
+					Times(1).
+					Return(false, fmt.Errorf("connection refused"))
+			},
+			BankMockOutcomes: func(bankMock *mock_bank.MockClient) {},
+			err:              fmt.Errorf("rpc error: code = Internal desc = error reserving merchant reference"),
+		},
+		{
+			name: "failed payment releases the reference",
+			opts: []Option{WithUniqueMerchantReferences()},
+			storageMockOutcomes: func(storageMock *mock_storage.MockClient) {
+				storageMock.EXPECT().
+					ReserveMerchantReference(gomock.Any(), "", reference).
+					Times(1).
+					Return(true, nil)
+				expectReversed(storageMock, "", req)
+				storageMock.EXPECT().
+					ReleaseMerchantReference(gomock.Any(), "", reference).
+					Times(1).
+					Return(nil)
+			},
+			BankMockOutcomes: func(bankMock *mock_bank.MockClient) {
+				bankMock.EXPECT().
+					Validate(gomock.Any(), model.ConvertToCardDetails(req)).
+					Times(1).
+					Return(true, nil)
+				bankMock.EXPECT().
+					Authorize(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return("", "", fmt.Errorf("connection refused"))
+				bankMock.EXPECT().
+					Reverse(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return("00", "authorization reversed", nil)
+			},
+			err: fmt.Errorf("rpc error: code = Internal desc = authorize transaction"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.storageMockOutcomes(storageMock)
+			tt.BankMockOutcomes(bankMock)
+
+			s := New(storageMock, bankMock, tt.opts...)
+
+			got, err := s.ProcessPayment(context.Background(), req)
+			if tt.err != nil {
+				assert.Equal(t, tt.err.Error(), err.Error())
+
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got.Status)
+		})
+	}
+}
+
+func Test_validateMerchantData(t *testing.T) {
+	tooMany := make(map[string]string, 21)
+	for i := 0; i < 21; i++ {
+		tooMany[fmt.Sprintf("key%d", i)] = "value"
+	}
+
+	tests := []struct {
+		name      string
+		reference string
+		metadata  map[string]string
+		fields    []string
+	}{
+		{
+			name:      "within limits",
+			reference: strings.Repeat("r", 128),
+			metadata:  map[string]string{strings.Repeat("k", 40): strings.Repeat("v", 500)},
+		},
+		{
+			name:      "reference too long",
+			reference: strings.Repeat("r", 129),
+			fields:    []string{"merchant_reference"},
+		},
+		{
+			name:     "too many keys",
+			metadata: tooMany,
+			fields:   []string{"metadata"},
+		},
+		{
+			name:     "empty key",
+			metadata: map[string]string{"": "value"},
+			fields:   []string{"metadata"},
+		},
+		{
+			name:     "key and values too long",
+			metadata: map[string]string{"a": strings.Repeat("v", 501), "b": strings.Repeat("v", 501), strings.Repeat("k", 41): "value"},
+			fields:   []string{"metadata[a]", "metadata[b]", "metadata"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateMerchantData(tt.reference, tt.metadata)
+			if tt.fields == nil {
+				assert.NoError(t, err)
+
+				return
+			}
+
+			var fields []string
+			for _, violation := range errcodes.FieldViolations(err) {
+				fields = append(fields, violation.GetField())
+			}
+
+			assert.Equal(t, tt.fields, fields)
+			assert.Equal(t, errcodes.InvalidMetadata, errcodes.FromError(err))
+		})
+	}
+}
+
+func Test_server_ProcessPayment_InvalidMetadata(t *testing.T) {
+	mockController := gomock.NewController(t)
+
+	defer mockController.Finish()
+
+	req := &protos.ProcessPaymentRequest{
+		CardNumber:        "378282246310005",
+		Expiry:            "04/30",
+		Amount:            20.5,
+		Currency:          "GBP",
+		Cvv:               3421,
+		MerchantReference: strings.Repeat("r", 129),
+	}
+
+	s := New(mock_storage.NewMockClient(mockController), mock_bank.NewMockClient(mockController), WithUniqueMerchantReferences())
+
+	_, err := s.ProcessPayment(context.Background(), proto.Clone(req).(*protos.ProcessPaymentRequest))
+	assert.Equal(t, "rpc error: code = InvalidArgument desc = invalid merchant reference or metadata", err.Error())
+}
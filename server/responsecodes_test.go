@@ -0,0 +1,151 @@
+package server
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	bank "payments_gateway/aquiring-bank"
+	"payments_gateway/aquiring-bank/mocks"
+	"payments_gateway/model"
+	protos "payments_gateway/protos"
+	"payments_gateway/responsecodes"
+	"payments_gateway/storage/mocks"
+)
+
+func Test_server_ResponseCodes(t *testing.T) {
+	mockController := gomock.NewController(t)
+
+	storageMock := mock_storage.NewMockClient(mockController)
+
+	bankMock := mock_bank.NewMockClient(mockController)
+
+	defer mockController.Finish()
+
+	s := New(storageMock, bankMock, WithResponseCodes(responsecodes.NewMapper(map[string]responsecodes.Table{
+		bank.DefaultAcquirer: {
+			"00": {Status: protos.Status_APPROVED},
+			"51": {
+				Status:    protos.Status_REJECTED,
+				Reason:    "insufficient funds",
+				Decline:   protos.DeclineType_SOFT_DECLINE,
+				Retryable: true,
+			},
+		},
+	})))
+
+	req := &protos.ProcessPaymentRequest{
+		BillingDetails: &protos.BillingDetails{Name: "Bruce", Surname: "Wayne"},
+		CardNumber:     "378282246310005",
+		Expiry:         "04/30",
+		Amount:         20.5,
+		Currency:       "GBP",
+		Cvv:            3421,
+		PaymentType:    protos.PaymentType_CARD,
+		CardType:       protos.CardType_AMERICAN_EXPRESS,
+	}
+
+	tests := []struct {
+		name   string
+		code   string
+		reason string
+		status protos.Status
+		want   *protos.Error
+	}{
+		{
+			name:   "mapped reason replaces the reason of the acquirer",
+			code:   "51",
+			reason: "51",
+			status: protos.Status_REJECTED,
+			want: &protos.Error{
+				Code:        "AUTHORIZATION_DECLINED",
+				Reason:      "insufficient funds",
+				BankCode:    "51",
+				DeclineType: protos.DeclineType_SOFT_DECLINE,
+				Retryable:   true,
+			},
+		},
+		{
+			name:   "code of the default table missing from the acquirer table",
+			code:   "12",
+			reason: "invalid transaction",
+			status: protos.Status_UNKNOWN_RESPONSE_CODE,
+			want:   &protos.Error{Code: "UNKNOWN_RESPONSE_CODE", Reason: "invalid transaction", BankCode: "12"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bankMock.EXPECT().Validate(gomock.Any(), gomock.Any()).Times(1).Return(true, nil)
+			bankMock.EXPECT().Authorize(gomock.Any(), gomock.Any()).Times(1).Return(tt.code, tt.reason, nil)
+			expectStored(storageMock, "", req, gomock.Any(), model.Authorization{
+				Acquirer:     bank.DefaultAcquirer,
+				Status:       tt.status,
+				Reason:       tt.want.GetReason(),
+				ResponseCode: tt.code,
+			})
+
+			got, err := s.ProcessPayment(context.Background(), req)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.status, got.GetStatus())
+			assert.Equal(t, tt.want.String(), got.GetError().String())
+		})
+	}
+
+	acquirers, err := s.ListAcquirers(context.Background(), &protos.ListAcquirersRequest{})
+
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]int64{"12": 1}, acquirers.GetAcquirers()[0].GetUnknownResponseCodes())
+}
+
+func Test_server_ResponseCodes_Modifications(t *testing.T) {
+	mockController := gomock.NewController(t)
+
+	storageMock := mock_storage.NewMockClient(mockController)
+
+	bankMock := mock_bank.NewMockClient(mockController)
+
+	defer mockController.Finish()
+
+	s := New(storageMock, bankMock, WithResponseCodes(responsecodes.NewMapper(map[string]responsecodes.Table{
+		bank.DefaultAcquirer: {
+			"A1": {Status: protos.Status_APPROVED},
+		},
+	})))
+
+	refID := "825ca1787c9d4672991848a5bfbc1057"
+
+	approved := &protos.GetPaymentResponse{
+		Ref:              refID,
+		Amount:           20.5,
+		AmountMinorUnits: 2050,
+		Currency:         "GBP",
+		Status:           protos.Status_APPROVED,
+	}
+
+	// the approval code of the acquirer table captures the payment
+	storageMock.EXPECT().GetPaymentInfo(gomock.Any(), "", refID).Times(1).Return(approved, nil)
+	bankMock.EXPECT().Capture(gomock.Any(), gomock.Any()).Times(1).Return("A1", "captured", nil)
+	storageMock.EXPECT().
+		UpdatePaymentStatus(gomock.Any(), model.StatusUpdate{RefID: refID, Status: protos.Status_CAPTURED, Reason: "captured", Source: protos.StatusSource_OPERATOR, CapturedAmount: 2050}).
+		Times(1).
+		Return(nil)
+
+	captured, err := s.CapturePayment(context.Background(), &protos.CapturePaymentRequest{Ref: refID})
+
+	assert.NoError(t, err)
+	assert.Equal(t, protos.Status_CAPTURED, captured.GetStatus())
+	assert.Nil(t, captured.GetError())
+
+	// 00 is missing from the acquirer table so the void is declined
+	storageMock.EXPECT().GetPaymentInfo(gomock.Any(), "", refID).Times(1).Return(approved, nil)
+	bankMock.EXPECT().Void(gomock.Any(), gomock.Any()).Times(1).Return("00", "voided", nil)
+
+	voided, err := s.VoidPayment(context.Background(), &protos.VoidPaymentRequest{Ref: refID})
+
+	assert.NoError(t, err)
+	assert.Equal(t, protos.Status_APPROVED, voided.GetStatus())
+	assert.Equal(t, "MODIFICATION_DECLINED", voided.GetError().GetCode())
+}
@@ -0,0 +1,69 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	bank "payments_gateway/aquiring-bank"
+	"payments_gateway/breaker"
+	"payments_gateway/model"
+	"payments_gateway/money"
+	protos "payments_gateway/protos"
+)
+
+// _reversalTimeout bounds reversing a failed authorization and storing its outcome, including the
+// retries of the acquirer client
+const _reversalTimeout = 30 * time.Second
+
+// failAuthorization stores the outcome of an authorization that returned an error and returns the status
+// the payment is left with. An authorization no acquirer was sent because their breakers are open is
+// rejected. Any other may have been approved by the acquirer, e.g. when the response timed out, so it
+// is reversed to release the funds it holds. A payment stays AUTHORIZING until the acquirer approves its
+// reversal, the pending job reverses it again otherwise
+func (s *server) failAuthorization(refID, acquirer string, client bank.Client, amount money.Money, authErr error) protos.Status {
+	// the authorization may have failed because the caller gave up, the payment is resolved regardless
+	ctx, cancel := context.WithTimeout(context.Background(), _reversalTimeout)
+	defer cancel()
+
+	logger := log.WithField("ref", refID).WithField("acquirer", acquirer)
+
+	authorization := model.Authorization{
+		Acquirer: acquirer,
+		Status:   protos.Status_REJECTED,
+		Reason:   "acquiring bank is unavailable",
+		Source:   protos.StatusSource_GATEWAY,
+	}
+
+	if !errors.Is(authErr, breaker.ErrOpen) {
+		code, reason, err := client.Reverse(ctx, model.Modification{RefID: refID, Amount: amount})
+		if err != nil {
+			logger.WithError(err).Error("reversing authorization, it is reversed again by the pending job")
+
+			return protos.Status_AUTHORIZING
+		}
+
+		logger = logger.WithField("code", code).WithField("reason", reason)
+
+		if s.responseCodes.Map(acquirer, code).Status != protos.Status_APPROVED {
+			logger.Error("acquirer did not approve the reversal, it is reversed again by the pending job")
+
+			return protos.Status_AUTHORIZING
+		}
+
+		logger.Info("reversed authorization")
+
+		authorization.Status = protos.Status_REVERSED
+		authorization.Reason = "authorization outcome was not received"
+	}
+
+	if err := s.dbClient.UpdateAuthorization(ctx, refID, authorization); err != nil {
+		logger.WithField("status", authorization.Status).WithError(err).Error("storing failed authorization")
+
+		return protos.Status_AUTHORIZING
+	}
+
+	return authorization.Status
+}
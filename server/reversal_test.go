@@ -0,0 +1,263 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	bank "payments_gateway/aquiring-bank"
+	"payments_gateway/aquiring-bank/mocks"
+	"payments_gateway/breaker"
+	"payments_gateway/errcodes"
+	"payments_gateway/model"
+	"payments_gateway/money"
+	protos "payments_gateway/protos"
+	"payments_gateway/routing"
+	"payments_gateway/storage/mocks"
+)
+
+func Test_server_ProcessPayment_Reversal(t *testing.T) {
+	req := &protos.ProcessPaymentRequest{
+		BillingDetails: &protos.BillingDetails{Name: "Bruce", Surname: "Wayne"},
+		CardNumber:     "378282246310005",
+		Expiry:         "04/30",
+		Amount:         20.5,
+		Currency:       "GBP",
+		Cvv:            3421,
+		PaymentType:    protos.PaymentType_CARD,
+		CardType:       protos.CardType_AMERICAN_EXPRESS,
+	}
+
+	authorizing := model.Authorization{Acquirer: bank.DefaultAcquirer, Status: protos.Status_AUTHORIZING, Source: protos.StatusSource_GATEWAY}
+
+	timeout := errors.New("error performing authorization request: context deadline exceeded")
+
+	tests := []struct {
+		name     string
+		outcomes func(storageMock *mock_storage.MockClient, bankMock *mock_bank.MockClient, cancel context.CancelFunc)
+		// status of the stored payment returned with the error, UNKNOWN when it is not stored
+		status protos.Status
+		err    error
+	}{
+		{
+			name: "authorization that timed out is reversed",
+			outcomes: func(storageMock *mock_storage.MockClient, bankMock *mock_bank.MockClient, cancel context.CancelFunc) {
+				var refID string
+
+				gomock.InOrder(
+					storageMock.EXPECT().AddPaymentInfo(gomock.Any(), "", gomock.Any(), req, money.New(2050, "GBP"), authorizing).
+						DoAndReturn(func(_ context.Context, _, ref string, _ *protos.ProcessPaymentRequest, _ money.Money, _ model.Authorization) error {
+							refID = ref
+
+							return nil
+						}),
+					bankMock.EXPECT().Authorize(gomock.Any(), gomock.Any()).
+						DoAndReturn(func(context.Context, model.Transaction) (string, string, error) {
+							cancel()
+
+							return "", "", timeout
+						}),
+					bankMock.EXPECT().Reverse(gomock.Any(), gomock.Any()).
+						DoAndReturn(func(ctx context.Context, m model.Modification) (string, string, error) {
+							// the request was cancelled, the reversal is not
+							assert.NoError(t, ctx.Err())
+							assert.Equal(t, model.Modification{RefID: refID, Amount: money.New(2050, "GBP")}, m)
+
+							return "00", "authorization reversed", nil
+						}),
+					storageMock.EXPECT().UpdateAuthorization(gomock.Any(), gomock.Any(), model.Authorization{
+						Acquirer: bank.DefaultAcquirer,
+						Status:   protos.Status_REVERSED,
+						Reason:   "authorization outcome was not received",
+						Source:   protos.StatusSource_GATEWAY,
+					}).Return(nil),
+				)
+			},
+			status: protos.Status_REVERSED,
+			err:    fmt.Errorf("rpc error: code = Internal desc = authorize transaction"),
+		},
+		{
+			name: "failed reversal leaves the payment authorizing",
+			outcomes: func(storageMock *mock_storage.MockClient, bankMock *mock_bank.MockClient, cancel context.CancelFunc) {
+				gomock.InOrder(
+					storageMock.EXPECT().AddPaymentInfo(gomock.Any(), "", gomock.Any(), req, money.New(2050, "GBP"), authorizing).Return(nil),
+					bankMock.EXPECT().Authorize(gomock.Any(), gomock.Any()).Return("", "", timeout),
+					bankMock.EXPECT().Reverse(gomock.Any(), gomock.Any()).Return("", "", errors.New("unexpected response status code: 503")),
+				)
+
+				storageMock.EXPECT().UpdateAuthorization(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+			status: protos.Status_AUTHORIZING,
+			err:    fmt.Errorf("rpc error: code = Internal desc = authorize transaction"),
+		},
+		{
+			name: "declined reversal leaves the payment authorizing",
+			outcomes: func(storageMock *mock_storage.MockClient, bankMock *mock_bank.MockClient, cancel context.CancelFunc) {
+				gomock.InOrder(
+					storageMock.EXPECT().AddPaymentInfo(gomock.Any(), "", gomock.Any(), req, money.New(2050, "GBP"), authorizing).Return(nil),
+					bankMock.EXPECT().Authorize(gomock.Any(), gomock.Any()).Return("", "", timeout),
+					bankMock.EXPECT().Reverse(gomock.Any(), gomock.Any()).Return("12", "invalid transaction", nil),
+				)
+
+				storageMock.EXPECT().UpdateAuthorization(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+			status: protos.Status_AUTHORIZING,
+			err:    fmt.Errorf("rpc error: code = Internal desc = authorize transaction"),
+		},
+		{
+			name: "reversal with an unknown response code leaves the payment authorizing",
+			outcomes: func(storageMock *mock_storage.MockClient, bankMock *mock_bank.MockClient, cancel context.CancelFunc) {
+				gomock.InOrder(
+					storageMock.EXPECT().AddPaymentInfo(gomock.Any(), "", gomock.Any(), req, money.New(2050, "GBP"), authorizing).Return(nil),
+					bankMock.EXPECT().Authorize(gomock.Any(), gomock.Any()).Return("", "", timeout),
+					bankMock.EXPECT().Reverse(gomock.Any(), gomock.Any()).Return("XX", "", nil),
+				)
+
+				storageMock.EXPECT().UpdateAuthorization(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+			status: protos.Status_AUTHORIZING,
+			err:    fmt.Errorf("rpc error: code = Internal desc = authorize transaction"),
+		},
+		{
+			name: "authorization that was not sent is rejected",
+			outcomes: func(storageMock *mock_storage.MockClient, bankMock *mock_bank.MockClient, cancel context.CancelFunc) {
+				gomock.InOrder(
+					storageMock.EXPECT().AddPaymentInfo(gomock.Any(), "", gomock.Any(), req, money.New(2050, "GBP"), authorizing).Return(nil),
+					bankMock.EXPECT().Authorize(gomock.Any(), gomock.Any()).Return("", "", breaker.ErrOpen),
+					storageMock.EXPECT().UpdateAuthorization(gomock.Any(), gomock.Any(), model.Authorization{
+						Acquirer: bank.DefaultAcquirer,
+						Status:   protos.Status_REJECTED,
+						Reason:   "acquiring bank is unavailable",
+						Source:   protos.StatusSource_GATEWAY,
+					}).Return(nil),
+				)
+
+				bankMock.EXPECT().Reverse(gomock.Any(), gomock.Any()).Times(0)
+			},
+			status: protos.Status_REJECTED,
+			err:    fmt.Errorf("rpc error: code = Unavailable desc = acquiring bank is unavailable"),
+		},
+		{
+			name: "payment that cannot be stored is not sent",
+			outcomes: func(storageMock *mock_storage.MockClient, bankMock *mock_bank.MockClient, cancel context.CancelFunc) {
+				storageMock.EXPECT().AddPaymentInfo(gomock.Any(), "", gomock.Any(), req, money.New(2050, "GBP"), authorizing).
+					Return(errors.New("connection refused"))
+
+				bankMock.EXPECT().Authorize(gomock.Any(), gomock.Any()).Times(0)
+			},
+			err: fmt.Errorf("rpc error: code = Internal desc = error adding payment info"),
+		},
+		{
+			name: "authorization that cannot be stored is left to be reversed",
+			outcomes: func(storageMock *mock_storage.MockClient, bankMock *mock_bank.MockClient, cancel context.CancelFunc) {
+				gomock.InOrder(
+					storageMock.EXPECT().AddPaymentInfo(gomock.Any(), "", gomock.Any(), req, money.New(2050, "GBP"), authorizing).Return(nil),
+					bankMock.EXPECT().Authorize(gomock.Any(), gomock.Any()).Return("00", "approved and completed successfully", nil),
+					storageMock.EXPECT().UpdateAuthorization(gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.New("connection refused")),
+				)
+
+				bankMock.EXPECT().Reverse(gomock.Any(), gomock.Any()).Times(0)
+			},
+			err: fmt.Errorf("rpc error: code = Internal desc = error adding payment info"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockController := gomock.NewController(t)
+
+			defer mockController.Finish()
+
+			storageMock := mock_storage.NewMockClient(mockController)
+
+			bankMock := mock_bank.NewMockClient(mockController)
+
+			bankMock.EXPECT().Validate(gomock.Any(), gomock.Any()).Times(1).Return(true, nil)
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			tt.outcomes(storageMock, bankMock, cancel)
+
+			got, err := New(storageMock, bankMock).ProcessPayment(ctx, req)
+
+			assert.EqualError(t, err, tt.err.Error())
+			assert.Equal(t, tt.status, got.GetStatus())
+
+			// the ref of a stored payment is returned with the error
+			if tt.status != protos.Status_UNKNOWN {
+				assert.NotEmpty(t, got.GetReference())
+				assert.Equal(t, got.GetReference(), errcodes.PaymentRef(err))
+			}
+		})
+	}
+}
+
+func Test_server_ProcessPayment_AuthorizeFailover(t *testing.T) {
+	mockController := gomock.NewController(t)
+
+	storageMock := mock_storage.NewMockClient(mockController)
+
+	primaryBank := mock_bank.NewMockClient(mockController)
+
+	secondaryBank := mock_bank.NewMockClient(mockController)
+
+	defer mockController.Finish()
+
+	acquirers := bank.NewRegistry()
+	if err := acquirers.Register(bank.DefaultAcquirer, primaryBank); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := acquirers.Register("secondary", secondaryBank); err != nil {
+		t.Fatal(err)
+	}
+
+	router, err := routing.NewRouter(nil, map[string]string{bank.DefaultAcquirer: "secondary"}, acquirers)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	req := &protos.ProcessPaymentRequest{
+		BillingDetails: &protos.BillingDetails{Name: "Bruce", Surname: "Wayne"},
+		CardNumber:     "378282246310005",
+		Expiry:         "04/30",
+		Amount:         20.5,
+		Currency:       "GBP",
+		Cvv:            3421,
+		PaymentType:    protos.PaymentType_CARD,
+		CardType:       protos.CardType_AMERICAN_EXPRESS,
+	}
+
+	// the breaker of the primary acquirer opens between validating and authorizing the payment, the
+	// acquirer of the payment is updated before it is sent to the secondary one
+	gomock.InOrder(
+		primaryBank.EXPECT().Validate(gomock.Any(), gomock.Any()).Return(true, nil),
+		storageMock.EXPECT().AddPaymentInfo(gomock.Any(), "", gomock.Any(), req, money.New(2050, "GBP"), model.Authorization{
+			Acquirer: bank.DefaultAcquirer,
+			Status:   protos.Status_AUTHORIZING,
+			Source:   protos.StatusSource_GATEWAY,
+		}).Return(nil),
+		primaryBank.EXPECT().Authorize(gomock.Any(), gomock.Any()).Return("", "", breaker.ErrOpen),
+		storageMock.EXPECT().UpdateAuthorization(gomock.Any(), gomock.Any(), model.Authorization{
+			Acquirer: "secondary",
+			Status:   protos.Status_AUTHORIZING,
+			Source:   protos.StatusSource_GATEWAY,
+		}).Return(nil),
+		secondaryBank.EXPECT().Authorize(gomock.Any(), gomock.Any()).Return("00", "approved and completed successfully", nil),
+		storageMock.EXPECT().UpdateAuthorization(gomock.Any(), gomock.Any(), model.Authorization{
+			Acquirer:     "secondary",
+			Status:       protos.Status_APPROVED,
+			Reason:       "approved and completed successfully",
+			ResponseCode: "00",
+		}).Return(nil),
+	)
+
+	got, err := New(storageMock, primaryBank, WithRouting(acquirers, router)).ProcessPayment(context.Background(), req)
+
+	assert.NoError(t, err)
+	assert.Equal(t, protos.Status_APPROVED, got.GetStatus())
+}
@@ -0,0 +1,235 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+
+	bank "payments_gateway/aquiring-bank"
+	"payments_gateway/aquiring-bank/mocks"
+	"payments_gateway/breaker"
+	"payments_gateway/model"
+	"payments_gateway/money"
+	protos "payments_gateway/protos"
+	"payments_gateway/routing"
+	"payments_gateway/storage/mocks"
+)
+
+func Test_server_Routing(t *testing.T) {
+	mockController := gomock.NewController(t)
+
+	storageMock := mock_storage.NewMockClient(mockController)
+
+	defaultBank := mock_bank.NewMockClient(mockController)
+
+	amexBank := mock_bank.NewMockClient(mockController)
+
+	defer mockController.Finish()
+
+	acquirers := bank.NewRegistry()
+	if err := acquirers.Register(bank.DefaultAcquirer, defaultBank); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := acquirers.Register("amex-direct", amexBank); err != nil {
+		t.Fatal(err)
+	}
+
+	router, err := routing.NewRouter([]routing.Rule{
+		{Acquirer: "amex-direct", CardTypes: []string{"AMERICAN_EXPRESS"}, MaxAmount: 100000},
+	}, nil, acquirers)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s := New(storageMock, defaultBank, WithRouting(acquirers, router))
+
+	req := &protos.ProcessPaymentRequest{
+		BillingDetails: &protos.BillingDetails{Name: "Bruce", Surname: "Wayne"},
+		CardNumber:     "378282246310005",
+		Expiry:         "04/30",
+		Amount:         20.5,
+		Currency:       "GBP",
+		Cvv:            3421,
+		PaymentType:    protos.PaymentType_CARD,
+		CardType:       protos.CardType_AMERICAN_EXPRESS,
+	}
+
+	tests := []struct {
+		name     string
+		amount   float64
+		acquirer string
+		bankMock *mock_bank.MockClient
+	}{
+		{
+			name:     "payment matching a rule",
+			amount:   20.5,
+			acquirer: "amex-direct",
+			bankMock: amexBank,
+		},
+		{
+			name:     "payment matching no rule",
+			amount:   2000.5,
+			acquirer: bank.DefaultAcquirer,
+			bankMock: defaultBank,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			request := proto.Clone(req).(*protos.ProcessPaymentRequest)
+			request.Amount = tt.amount
+
+			amount := money.New(int64(tt.amount*100), "GBP")
+
+			tt.bankMock.EXPECT().Validate(gomock.Any(), gomock.Any()).Times(1).Return(true, nil)
+			tt.bankMock.EXPECT().
+				Authorize(gomock.Any(), gomock.Any()).
+				Times(1).
+				Return("00", "approved and completed successfully", nil)
+			expectStored(storageMock, "", gomock.Any(), amount, model.Authorization{
+				Acquirer:     tt.acquirer,
+				Status:       protos.Status_APPROVED,
+				Reason:       "approved and completed successfully",
+				ResponseCode: "00",
+			})
+
+			got, err := s.ProcessPayment(context.Background(), request)
+
+			assert.NoError(t, err)
+			assert.Equal(t, protos.Status_APPROVED, got.GetStatus())
+		})
+	}
+
+	// modifications go to the acquirer stored on the payment, whatever the rules now say
+	payment := &protos.GetPaymentResponse{
+		Ref:              "825ca1787c9d4672991848a5bfbc1057",
+		Currency:         "GBP",
+		AmountMinorUnits: 200050,
+		Status:           protos.Status_APPROVED,
+		Acquirer:         "amex-direct",
+	}
+
+	storageMock.EXPECT().GetPaymentInfo(gomock.Any(), "", payment.Ref).Times(1).Return(payment, nil)
+	amexBank.EXPECT().
+		Capture(gomock.Any(), model.Modification{RefID: payment.Ref, Amount: money.New(200050, "GBP")}).
+		Times(1).
+		Return("00", "captured", nil)
+	storageMock.EXPECT().UpdatePaymentStatus(gomock.Any(), gomock.Any()).Times(1).Return(nil)
+
+	_, err = s.CapturePayment(context.Background(), &protos.CapturePaymentRequest{Ref: payment.Ref})
+	assert.NoError(t, err)
+
+	unknown := &protos.GetPaymentResponse{
+		Ref:              "825ca1787c9d4672991848a5bfbc1058",
+		Currency:         "GBP",
+		AmountMinorUnits: 2050,
+		Status:           protos.Status_APPROVED,
+		Acquirer:         "removed",
+	}
+
+	storageMock.EXPECT().GetPaymentInfo(gomock.Any(), "", unknown.Ref).Times(1).Return(unknown, nil)
+
+	_, err = s.VoidPayment(context.Background(), &protos.VoidPaymentRequest{Ref: unknown.Ref})
+	assert.Equal(t, "rpc error: code = Internal desc = acquirer is not registered", err.Error())
+}
+
+func Test_server_Failover(t *testing.T) {
+	mockController := gomock.NewController(t)
+
+	storageMock := mock_storage.NewMockClient(mockController)
+
+	primaryBank := mock_bank.NewMockClient(mockController)
+
+	secondaryBank := mock_bank.NewMockClient(mockController)
+
+	defer mockController.Finish()
+
+	// the breaker of the primary acquirer opens after the first failed call
+	primary := bank.WithBreaker(bank.DefaultAcquirer, primaryBank, breaker.Options{
+		Window:        time.Minute,
+		MinCalls:      1,
+		ErrorRate:     0.5,
+		OpenTimeout:   time.Minute,
+		HalfOpenCalls: 1,
+	})
+
+	acquirers := bank.NewRegistry()
+	if err := acquirers.Register(bank.DefaultAcquirer, primary); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := acquirers.Register("secondary", secondaryBank); err != nil {
+		t.Fatal(err)
+	}
+
+	router, err := routing.NewRouter(nil, map[string]string{bank.DefaultAcquirer: "secondary"}, acquirers)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s := New(storageMock, primaryBank, WithRouting(acquirers, router))
+
+	req := &protos.ProcessPaymentRequest{
+		BillingDetails: &protos.BillingDetails{Name: "Bruce", Surname: "Wayne"},
+		CardNumber:     "378282246310005",
+		Expiry:         "04/30",
+		Amount:         20.5,
+		Currency:       "GBP",
+		Cvv:            3421,
+		PaymentType:    protos.PaymentType_CARD,
+		CardType:       protos.CardType_AMERICAN_EXPRESS,
+	}
+
+	primaryBank.EXPECT().
+		Validate(gomock.Any(), gomock.Any()).
+		Times(1).
+		Return(false, fmt.Errorf("error performing validation request: giving up after 4 attempt(s)"))
+
+	_, err = s.ProcessPayment(context.Background(), req)
+	assert.Equal(t, "rpc error: code = Internal desc = validate card details", err.Error())
+
+	// the primary acquirer is no longer called while its breaker is open
+	secondaryBank.EXPECT().Validate(gomock.Any(), gomock.Any()).Times(1).Return(true, nil)
+	secondaryBank.EXPECT().
+		Authorize(gomock.Any(), gomock.Any()).
+		Times(1).
+		Return("00", "approved and completed successfully", nil)
+	expectStored(storageMock, "", req, money.New(2050, "GBP"), model.Authorization{
+		Acquirer:     "secondary",
+		Status:       protos.Status_APPROVED,
+		Reason:       "approved and completed successfully",
+		ResponseCode: "00",
+	})
+
+	got, err := s.ProcessPayment(context.Background(), req)
+	assert.NoError(t, err)
+	assert.Equal(t, protos.Status_APPROVED, got.GetStatus())
+
+	status, err := s.ListAcquirers(context.Background(), &protos.ListAcquirersRequest{})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{bank.DefaultAcquirer, "secondary"}, []string{status.GetAcquirers()[0].GetName(), status.GetAcquirers()[1].GetName()})
+	assert.Equal(t, protos.CircuitState_OPEN, status.GetAcquirers()[0].GetCircuitState())
+	assert.Equal(t, int32(1), status.GetAcquirers()[0].GetFailures())
+	assert.Equal(t, "secondary", status.GetAcquirers()[0].GetFailover())
+	assert.NotNil(t, status.GetAcquirers()[0].GetOpenedTimestamp())
+	assert.Equal(t, protos.CircuitState_NO_CIRCUIT_BREAKER, status.GetAcquirers()[1].GetCircuitState())
+
+	// captures are never failed over, they must reach the acquirer that authorized the payment
+	payment := &protos.GetPaymentResponse{
+		Ref:              "825ca1787c9d4672991848a5bfbc1057",
+		Currency:         "GBP",
+		AmountMinorUnits: 2050,
+		Status:           protos.Status_APPROVED,
+		Acquirer:         bank.DefaultAcquirer,
+	}
+
+	storageMock.EXPECT().GetPaymentInfo(gomock.Any(), "", payment.Ref).Times(1).Return(payment, nil)
+
+	_, err = s.CapturePayment(context.Background(), &protos.CapturePaymentRequest{Ref: payment.Ref})
+	assert.Equal(t, "rpc error: code = Unavailable desc = acquiring bank is unavailable", err.Error())
+}
@@ -2,97 +2,344 @@ package server
 
 import (
 	"context"
+	"errors"
 	log "github.com/sirupsen/logrus"
+	"net"
+	"payments_gateway/auth"
+	"payments_gateway/breaker"
+	"payments_gateway/card"
+	"payments_gateway/currency"
+	"payments_gateway/errcodes"
 	"payments_gateway/model"
+	"payments_gateway/money"
 	identifier "payments_gateway/utils"
-	"reflect"
+	"time"
 
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/status"
 
 	bank "payments_gateway/aquiring-bank"
 	protos "payments_gateway/protos"
+	"payments_gateway/responsecodes"
+	"payments_gateway/routing"
+	"payments_gateway/statemachine"
 	"payments_gateway/storage"
+	"payments_gateway/vault"
+	"payments_gateway/watch"
+	"payments_gateway/webhook"
 )
 
 type server struct {
 	protos.UnimplementedPaymentsServer // server implementations must now be forward compatible
 	dbClient                           storage.Client
-	aqBank                             bank.Client
+	acquirers                          *bank.Registry
+	router                             *routing.Router
+	responseCodes                      *responsecodes.Mapper
+	currencies                         *currency.Policy
+	vault                              *vault.Vault
+	uniqueReferences                   bool
+	watchHub                           *watch.Hub
+	webhookResolver                    webhook.Resolver
+	idempotencyHashKey                 []byte
+}
+
+// Option configures optional behaviour of the server
+type Option func(*server)
+
+// WithCurrencyPolicy restricts the currencies accepted by ProcessPayment and their amounts,
+// by default every payable ISO 4217 currency is accepted
+func WithCurrencyPolicy(policy *currency.Policy) Option {
+	return func(s *server) {
+		s.currencies = policy
+	}
+}
+
+// WithResponseCodes maps the response codes of each acquirer, by default every acquirer uses
+// responsecodes.DefaultTable
+func WithResponseCodes(mapper *responsecodes.Mapper) Option {
+	return func(s *server) {
+		s.responseCodes = mapper
+	}
+}
+
+// WithRouting registers the acquirers payments can be routed to and the router choosing between
+// them. The registry must contain bank.DefaultAcquirer, which payments no rule matches are sent to
+func WithRouting(acquirers *bank.Registry, router *routing.Router) Option {
+	return func(s *server) {
+		s.acquirers = acquirers
+		s.router = router
+	}
 }
 
 var _ protos.PaymentsServer = (*server)(nil)
 
+// OperatorMethods are the full names of the RPCs only operators may call, they are not scoped to a merchant
+var OperatorMethods = []string{
+	"/" + protos.Payments_ServiceDesc.ServiceName + "/ListAcquirers",
+	"/" + protos.Payments_ServiceDesc.ServiceName + "/GetReconciliationReport",
+}
+
 var (
-	_errInvalidParam        = status.Error(codes.InvalidArgument, "missing parameter")
-	_errAddingPayment       = status.Error(codes.Internal, "error adding payment info")
-	_errAGettingPaymentInfo = status.Error(codes.Internal, "error getting payment info")
+	_errAddingPayment         = errcodes.Error(codes.Internal, errcodes.Internal, "error adding payment info")
+	_errAGettingPaymentInfo   = errcodes.Error(codes.Internal, errcodes.Internal, "error getting payment info")
+	_errUpdatingPayment       = errcodes.Error(codes.Internal, errcodes.Internal, "error updating payment info")
+	_errGettingPaymentHistory = errcodes.Error(codes.Internal, errcodes.Internal, "error getting payment history")
+	_errPaymentNotFound       = errcodes.Error(codes.NotFound, errcodes.PaymentNotFound, "payment not found")
+	_errRefundInProgress      = errcodes.Error(codes.Aborted, errcodes.RefundInProgress, "another refund of the payment is in progress")
+	_errValidatingPayment     = errcodes.Error(codes.InvalidArgument, errcodes.CardVerificationFailed, "validating payment")
 )
 
 // New - grpc server constructor
-func New(dbClient storage.Client, aqBankClient bank.Client) *server {
-	return &server{
-		dbClient: dbClient,
-		aqBank:   aqBankClient,
+func New(dbClient storage.Client, aqBankClient bank.Client, opts ...Option) *server {
+	s := &server{
+		dbClient:        dbClient,
+		acquirers:       bank.NewRegistry(),
+		responseCodes:   responsecodes.NewMapper(nil),
+		webhookResolver: net.DefaultResolver,
 	}
+
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	// aqBankClient is the default acquirer unless the routing registry already has one
+	if _, ok := s.acquirers.Get(bank.DefaultAcquirer); !ok {
+		_ = s.acquirers.Register(bank.DefaultAcquirer, aqBankClient)
+	}
+
+	return s
 }
 
-// ProcessPayment processes payments made to the payments gateway
+// ProcessPayment processes payments made to the payments gateway. Requests made with an
+// idempotency key are only processed once
 func (s *server) ProcessPayment(ctx context.Context, request *protos.ProcessPaymentRequest) (*protos.ProcessPaymentResponse, error) {
+	if request.GetIdempotencyKey() == "" {
+		return s.processPayment(ctx, request)
+	}
+
+	return s.processIdempotentPayment(ctx, auth.MerchantID(ctx), request)
+}
+
+// authorizePayment validates a payment, authorizes it with its acquirer and stores it
+func (s *server) authorizePayment(ctx context.Context, request *protos.ProcessPaymentRequest) (*protos.ProcessPaymentResponse, error) {
 	// validation of input parameters
-	if !validParams(request.GetAmount(), request.GetCurrency(), request.GetCardNumber(), request.GetCvv(), request.GetExpiry()) {
+	if err := validatePaymentRequest(request); err != nil {
 		log.WithField("request", request).Warn("request contains invalid parameters")
 
-		return nil, _errInvalidParam
+		return nil, err
+	}
+
+	amount, err := s.paymentAmount(request)
+	if err != nil {
+		log.WithField("request", request).WithError(err).Warn("request contains an invalid amount")
+
+		return nil, err
+	}
+
+	// cards stored in the vault are only decrypted here, right before they are sent to the bank
+	cardDetails, err := s.cardDetails(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	// reject card details the acquiring bank would decline anyway
+	if err := card.Validate(cardDetails, time.Now()); err != nil {
+		log.WithField("card_type", cardDetails.CardType).WithError(err).Warn("request contains invalid card details")
+
+		return nil, invalidCard(err)
+	}
+
+	acquirer := s.router.Route(routing.Payment{
+		MerchantID: auth.MerchantID(ctx),
+		CardType:   cardDetails.CardType,
+		Currency:   amount.Currency,
+		Amount:     amount.Amount,
+	})
+
+	aqBank, err := s.acquirer(acquirer)
+	if err != nil {
+		return nil, err
 	}
 
 	refID := identifier.NewUUID()
 
-	// validate the card info
-	if ok, err := s.aqBank.Validate(ctx, model.ConvertToCardDetails(request)); !ok {
+	// validate the card info, the secondary acquirer is used while the chosen one is unavailable
+	var valid bool
+
+	acquirer, aqBank, err = s.withFailover(acquirer, aqBank, func(_ string, aqBank bank.Client) (err error) {
+		valid, err = aqBank.Validate(ctx, cardDetails)
+
+		return err
+	})
+	if errors.Is(err, breaker.ErrOpen) {
+		log.WithField("acquirer", acquirer).Error("validating card details, acquirer is unavailable")
+
+		return nil, _errBankUnavailable
+	}
+
+	// only a decline means the card is invalid, any other error means the acquirer could not be asked
+	var declined *bank.DeclinedError
+	if err != nil && !errors.As(err, &declined) {
+		log.WithField("acquirer", acquirer).WithError(err).Error("validating card details")
+
+		return nil, bankError(err, "validate card details")
+	}
+
+	if !valid {
 		log.WithField("request", request).WithError(err).Error("invalid card details")
 
+		reason := "card details could not be verified"
+		if declined != nil {
+			reason = declined.Reason
+		}
+
 		return &protos.ProcessPaymentResponse{
 			Error: &protos.Error{
-				Reason: err.Error(),
+				Code:   string(errcodes.CardVerificationFailed),
+				Reason: reason,
 			},
-		}, status.Error(codes.InvalidArgument, "validating payment")
+		}, _errValidatingPayment
+	}
+
+	// the payment is stored before it is sent to the acquirer, so an authorization the outcome of which
+	// is not received is never lost and can be reversed
+	// TODO refactor and use model types instead of request
+	authorization := model.Authorization{Acquirer: acquirer, Status: protos.Status_AUTHORIZING, Source: protos.StatusSource_GATEWAY}
+	if err := s.dbClient.AddPaymentInfo(ctx, auth.MerchantID(ctx), refID, paymentRecord(request, cardDetails), amount, authorization); err != nil {
+		log.WithField("ref", refID).WithError(err).Error("adding payment info")
+
+		return nil, _errAddingPayment
 	}
 
 	// Authorise the users card details and funds for the purchase
-	code, reason, err := s.aqBank.Authorize(ctx, model.ConvertToTransaction(refID, request))
+	var code, reason string
+
+	acquirer, aqBank, err = s.withFailover(acquirer, aqBank, func(name string, aqBank bank.Client) (err error) {
+		// the payment failed over before it was sent, it is reversed with the acquirer it is sent to
+		if name != authorization.Acquirer {
+			authorization.Acquirer = name
+
+			if err := s.dbClient.UpdateAuthorization(ctx, refID, authorization); err != nil {
+				return err
+			}
+		}
+
+		code, reason, err = aqBank.Authorize(ctx, model.ConvertToTransaction(refID, cardDetails, amount))
+
+		return err
+	})
 	if err != nil {
-		log.WithField("request", request).WithError(err).Error("authorize transaction")
+		log.WithField("request", request).WithField("acquirer", acquirer).WithError(err).Error("authorize transaction")
+
+		status := s.failAuthorization(refID, acquirer, aqBank, amount, err)
 
-		return nil, status.Error(codes.Internal, "authorize transaction")
+		// the payment is stored, its ref is returned so the merchant can look it up
+		return &protos.ProcessPaymentResponse{
+			Reference: refID,
+			Status:    status,
+			Error:     &protos.Error{Code: string(errcodes.BankUnavailable), Reason: "authorize transaction"},
+		}, errcodes.WithPayment(bankError(err, "authorize transaction"), refID)
 	}
 
-	status := determineStatus(code)
+	mapping := s.responseCodes.Map(acquirer, code)
+	status := mapping.Status
+
+	if mapping.Reason != "" {
+		reason = mapping.Reason
+	}
+
+	if status == protos.Status_UNKNOWN_RESPONSE_CODE {
+		log.WithField("acquirer", acquirer).WithField("code", code).Warn("acquirer returned an unknown response code")
+	}
+
+	if err := statemachine.Transition(protos.Status_AUTHORIZING, status); err != nil {
+		log.WithField("code", code).WithError(err).Error("unexpected status for new payment")
+
+		return nil, err
+	}
+
+	// with the acquirer later modifications of the payment are sent to. A payment the authorization of
+	// which is not stored stays AUTHORIZING and is reversed by the pending job
+	authorization = model.Authorization{Acquirer: acquirer, Status: status, Reason: reason, ResponseCode: code}
+	if err := s.dbClient.UpdateAuthorization(ctx, refID, authorization); err != nil {
+		log.WithField("ref", refID).WithError(err).Error("storing authorization")
 
-	// add the payment info to DB
-	// TODO refactor and use model types instead of request
-	if err := s.dbClient.AddPaymentInfo(ctx, refID, request, status, reason); err != nil {
 		return nil, _errAddingPayment
 	}
 
-	return &protos.ProcessPaymentResponse{
+	resp := &protos.ProcessPaymentResponse{
 		Reference:    refID,
 		Status:       status,
 		StatusReason: reason,
-	}, nil
+	}
+
+	switch status {
+	case protos.Status_REJECTED:
+		resp.Error = &protos.Error{
+			Code:        string(errcodes.AuthorizationDeclined),
+			Reason:      reason,
+			BankCode:    code,
+			DeclineType: mapping.Decline,
+			Retryable:   mapping.Retryable,
+		}
+	case protos.Status_UNKNOWN_RESPONSE_CODE:
+		resp.Error = &protos.Error{Code: string(errcodes.UnknownResponseCode), Reason: reason, BankCode: code}
+	}
+
+	return resp, nil
+}
+
+// paymentAmount validates the amount and currency of a payment against the currency policy
+func (s *server) paymentAmount(request *protos.ProcessPaymentRequest) (money.Money, error) {
+	code, err := currency.Normalize(request.GetCurrency())
+	if err != nil {
+		return money.Money{}, errcodes.Error(codes.InvalidArgument, errcodes.UnsupportedCurrency, err.Error(),
+			errcodes.FieldViolation("currency", err.Error()))
+	}
+
+	amount, err := money.FromMinorOrMajor(request.GetAmountMinorUnits(), request.GetAmount(), code)
+	if err != nil {
+		return money.Money{}, invalidAmount(amountField(request.GetAmountMinorUnits()), err.Error())
+	}
+
+	if amount.Amount == 0 {
+		return money.Money{}, missingParameters(field{name: "amount"})
+	}
+
+	if err := s.currencies.Check(amount.Currency, amount.Amount); err != nil {
+		if errors.Is(err, currency.ErrNotAccepted) {
+			return money.Money{}, errcodes.Error(codes.InvalidArgument, errcodes.CurrencyNotAccepted, err.Error(),
+				errcodes.FieldViolation("currency", err.Error()))
+		}
+
+		return money.Money{}, errcodes.Error(codes.InvalidArgument, errcodes.AmountOutOfRange, err.Error(),
+			errcodes.FieldViolation(amountField(request.GetAmountMinorUnits()), err.Error()))
+	}
+
+	return amount, nil
 }
 
-// GetPayment retrieves payments previously made to the payments gateway
+// GetPayment retrieves payments previously made to the payments gateway by their ref or merchant reference
 func (s *server) GetPayment(ctx context.Context, request *protos.GetPaymentRequest) (*protos.GetPaymentResponse, error) {
+	if request.GetMerchantReference() != "" && request.GetRef() == "" {
+		resp, err := s.dbClient.GetPaymentByReference(ctx, auth.MerchantID(ctx), request.GetMerchantReference())
+		if err != nil {
+			log.WithField("merchant_reference", request.GetMerchantReference()).WithError(err).Error("getting payment by merchant reference")
+
+			return nil, _errAGettingPaymentInfo
+		}
+
+		return resp, nil
+	}
+
 	// validation of input parameters
-	if !validParams(request.GetRef()) {
+	if err := validateRef(request.GetRef()); err != nil {
 		log.WithField("request", request).Warn("request contains invalid parameters")
 
-		return nil, _errInvalidParam
+		return nil, err
 	}
 
-	resp, err := s.dbClient.GetPaymentInfo(ctx, request.GetRef())
+	resp, err := s.dbClient.GetPaymentInfo(ctx, auth.MerchantID(ctx), request.GetRef())
 	if err != nil {
 		return nil, _errAGettingPaymentInfo
 	}
@@ -100,33 +347,345 @@ func (s *server) GetPayment(ctx context.Context, request *protos.GetPaymentReque
 	return resp, err
 }
 
-func (s *server) Register(grpcService *grpc.Server) {
-	protos.RegisterPaymentsServer(grpcService, s)
+// GetPaymentHistory retrieves every status change of a payment previously made to the payments gateway
+func (s *server) GetPaymentHistory(ctx context.Context, request *protos.GetPaymentHistoryRequest) (*protos.GetPaymentHistoryResponse, error) {
+	if err := validateRef(request.GetRef()); err != nil {
+		log.WithField("request", request).Warn("request contains invalid parameters")
+
+		return nil, err
+	}
+
+	history, err := s.dbClient.GetPaymentHistory(ctx, auth.MerchantID(ctx), request.GetRef())
+	if err != nil {
+		log.WithField("ref", request.GetRef()).WithError(err).Error("getting payment history")
+
+		return nil, _errGettingPaymentHistory
+	}
+
+	// every stored payment has at least the status it was created with
+	if len(history) == 0 {
+		return nil, _errPaymentNotFound
+	}
+
+	return &protos.GetPaymentHistoryResponse{
+		Ref:     request.GetRef(),
+		History: history,
+	}, nil
+}
+
+// CapturePayment captures all or part of the amount of an approved payment
+func (s *server) CapturePayment(ctx context.Context, request *protos.CapturePaymentRequest) (*protos.CapturePaymentResponse, error) {
+	if err := validateRef(request.GetRef()); err != nil {
+		log.WithField("request", request).Warn("request contains invalid parameters")
+
+		return nil, err
+	}
+
+	payment, err := s.lookupPayment(ctx, request.GetRef())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := statemachine.Transition(payment.GetStatus(), protos.Status_CAPTURED); err != nil {
+		return nil, err
+	}
+
+	amount, err := money.FromMinorOrMajor(request.GetAmountMinorUnits(), request.GetAmount(), payment.GetCurrency())
+	if err != nil {
+		return nil, invalidAmount(amountField(request.GetAmountMinorUnits()), err.Error())
+	}
+
+	if amount.Amount == 0 {
+		amount.Amount = payment.GetAmountMinorUnits()
+	}
+
+	if amount.Amount > payment.GetAmountMinorUnits() {
+		return nil, invalidAmount(amountField(request.GetAmountMinorUnits()), "exceeds the amount of the payment")
+	}
+
+	aqBank, err := s.acquirer(payment.GetAcquirer())
+	if err != nil {
+		return nil, err
+	}
+
+	code, reason, err := aqBank.Capture(ctx, model.Modification{RefID: payment.GetRef(), Amount: amount})
+	if err != nil {
+		log.WithField("ref", payment.GetRef()).WithError(err).Error("capture payment")
+
+		return nil, bankError(err, "capture payment")
+	}
+
+	if !s.modificationApproved(payment.GetAcquirer(), code) {
+		return &protos.CapturePaymentResponse{
+			Reference:    payment.GetRef(),
+			Status:       payment.GetStatus(),
+			StatusReason: payment.GetStatusReason(),
+			Error:        modificationDeclined(code, reason),
+		}, nil
+	}
+
+	if err := s.updatePaymentStatus(ctx, model.StatusUpdate{
+		RefID:          payment.GetRef(),
+		Status:         protos.Status_CAPTURED,
+		Reason:         reason,
+		Source:         protos.StatusSource_OPERATOR,
+		CapturedAmount: amount.Amount,
+	}); err != nil {
+		return nil, err
+	}
+
+	return &protos.CapturePaymentResponse{
+		Reference:                payment.GetRef(),
+		Status:                   protos.Status_CAPTURED,
+		StatusReason:             reason,
+		CapturedAmount:           amount.Major(),
+		CapturedAmountMinorUnits: amount.Amount,
+	}, nil
 }
 
-// validParams reports whether v is the zero value for its type.
-func validParams(params ...interface{}) bool {
-	for _, p := range params {
-		if reflect.ValueOf(p).IsZero() {
-			return false
+// VoidPayment cancels the authorization of an approved payment that has not been captured
+func (s *server) VoidPayment(ctx context.Context, request *protos.VoidPaymentRequest) (*protos.VoidPaymentResponse, error) {
+	if err := validateRef(request.GetRef()); err != nil {
+		log.WithField("request", request).Warn("request contains invalid parameters")
+
+		return nil, err
+	}
+
+	payment, err := s.lookupPayment(ctx, request.GetRef())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := statemachine.Transition(payment.GetStatus(), protos.Status_VOIDED); err != nil {
+		return nil, err
+	}
+
+	aqBank, err := s.acquirer(payment.GetAcquirer())
+	if err != nil {
+		return nil, err
+	}
+
+	code, reason, err := aqBank.Void(ctx, model.Modification{RefID: payment.GetRef(), Amount: money.New(payment.GetAmountMinorUnits(), payment.GetCurrency())})
+	if err != nil {
+		log.WithField("ref", payment.GetRef()).WithError(err).Error("void payment")
+
+		return nil, bankError(err, "void payment")
+	}
+
+	if !s.modificationApproved(payment.GetAcquirer(), code) {
+		return &protos.VoidPaymentResponse{
+			Reference:    payment.GetRef(),
+			Status:       payment.GetStatus(),
+			StatusReason: payment.GetStatusReason(),
+			Error:        modificationDeclined(code, reason),
+		}, nil
+	}
+
+	if err := s.updatePaymentStatus(ctx, model.StatusUpdate{
+		RefID:  payment.GetRef(),
+		Status: protos.Status_VOIDED,
+		Reason: reason,
+		Source: protos.StatusSource_OPERATOR,
+	}); err != nil {
+		return nil, err
+	}
+
+	return &protos.VoidPaymentResponse{
+		Reference:    payment.GetRef(),
+		Status:       protos.Status_VOIDED,
+		StatusReason: reason,
+	}, nil
+}
+
+// RefundPayment refunds all or part of the captured amount of a payment
+func (s *server) RefundPayment(ctx context.Context, request *protos.RefundPaymentRequest) (*protos.RefundPaymentResponse, error) {
+	if err := validateRef(request.GetRef()); err != nil {
+		log.WithField("request", request).Warn("request contains invalid parameters")
+
+		return nil, err
+	}
+
+	payment, err := s.lookupPayment(ctx, request.GetRef())
+	if err != nil {
+		return nil, err
+	}
+
+	// partial and full refunds are allowed from the same statuses
+	if err := statemachine.Transition(payment.GetStatus(), protos.Status_REFUNDED); err != nil {
+		return nil, err
+	}
+
+	refundable := payment.GetCapturedAmountMinorUnits() - payment.GetRefundedAmountMinorUnits()
+
+	amount, err := money.FromMinorOrMajor(request.GetAmountMinorUnits(), request.GetAmount(), payment.GetCurrency())
+	if err != nil {
+		return nil, invalidAmount(amountField(request.GetAmountMinorUnits()), err.Error())
+	}
+
+	if amount.Amount == 0 {
+		amount.Amount = refundable
+	}
+
+	if amount.Amount <= 0 || amount.Amount > refundable {
+		return nil, invalidAmount(amountField(request.GetAmountMinorUnits()), "exceeds the refundable amount of the payment")
+	}
+
+	aqBank, err := s.acquirer(payment.GetAcquirer())
+	if err != nil {
+		return nil, err
+	}
+
+	// the amount refunded so far is read again while the refund is reserved, a concurrent refund may have been stored
+	refundedBefore, err := s.reserveRefund(ctx, payment.GetRef(), amount.Amount, amountField(request.GetAmountMinorUnits()))
+	if err != nil {
+		return nil, err
+	}
+
+	code, reason, err := aqBank.Refund(ctx, model.Modification{RefID: payment.GetRef(), Amount: amount})
+	if err != nil {
+		log.WithField("ref", payment.GetRef()).WithError(err).Error("refund payment")
+
+		// the acquirer may have refunded a call that failed after it was sent, its reservation is kept until
+		// the refund is reconciled
+		if errors.Is(err, breaker.ErrOpen) {
+			s.releaseRefund(ctx, payment.GetRef())
 		}
+
+		return nil, bankError(err, "refund payment")
 	}
 
-	return true
+	if !s.modificationApproved(payment.GetAcquirer(), code) {
+		s.releaseRefund(ctx, payment.GetRef())
+
+		return &protos.RefundPaymentResponse{
+			Reference:                payment.GetRef(),
+			Status:                   payment.GetStatus(),
+			StatusReason:             payment.GetStatusReason(),
+			RefundedAmount:           payment.GetRefundedAmount(),
+			RefundedAmountMinorUnits: payment.GetRefundedAmountMinorUnits(),
+			Error:                    modificationDeclined(code, reason),
+		}, nil
+	}
+
+	newStatus := protos.Status_PARTIALLY_REFUNDED
+	if refundedBefore+amount.Amount == payment.GetCapturedAmountMinorUnits() {
+		newStatus = protos.Status_REFUNDED
+	}
+
+	if err := s.updatePaymentStatus(ctx, model.StatusUpdate{
+		RefID:          payment.GetRef(),
+		Status:         newStatus,
+		Reason:         reason,
+		Source:         protos.StatusSource_OPERATOR,
+		RefundedAmount: amount.Amount,
+	}); err != nil {
+		return nil, err
+	}
+
+	refunded := money.New(refundedBefore+amount.Amount, payment.GetCurrency())
+
+	return &protos.RefundPaymentResponse{
+		Reference:                payment.GetRef(),
+		Status:                   newStatus,
+		StatusReason:             reason,
+		RefundedAmount:           refunded.Major(),
+		RefundedAmountMinorUnits: refunded.Amount,
+	}, nil
+}
+
+// updatePaymentStatus stores a status update, illegal transitions caused by a concurrent
+// update of the same payment are returned as is
+func (s *server) updatePaymentStatus(ctx context.Context, update model.StatusUpdate) error {
+	err := s.dbClient.UpdatePaymentStatus(ctx, update)
+
+	var transitionErr *statemachine.TransitionError
+
+	switch {
+	case err == nil:
+		return nil
+	case errors.As(err, &transitionErr):
+		return transitionErr
+	case errors.Is(err, storage.ErrPaymentNotFound):
+		return _errPaymentNotFound
+	case errors.Is(err, storage.ErrRefundExceedsCaptured):
+		// the acquirer approved the refund, it has to be reconciled with the payment
+		log.WithField("ref", update.RefID).WithField("refunded_amount", update.RefundedAmount).WithError(err).
+			Error("refund approved by the acquirer exceeds the refundable amount of the payment")
+
+		return invalidAmount("amount", "exceeds the refundable amount of the payment")
+	default:
+		log.WithField("ref", update.RefID).WithError(err).Error("updating payment status")
+
+		return _errUpdatingPayment
+	}
 }
 
-//determineStatus Interpret codes returned from the payment gateway
-func determineStatus(code string) protos.Status {
-	switch code {
-	case "00":
-		return protos.Status_APPROVED
-	case "06", "39", "12":
-		return protos.Status_REJECTED
-	case "19":
-		return protos.Status_PENDING
-	case "20":
-		return protos.Status_COMPLETED
+// reserveRefund reserves the amount of a refund before it is sent to the acquirer and returns the amount
+// refunded so far, field names the amount field of the request
+func (s *server) reserveRefund(ctx context.Context, ref string, amount int64, field string) (int64, error) {
+	refunded, err := s.dbClient.ReserveRefund(ctx, ref, amount)
+
+	var transitionErr *statemachine.TransitionError
+
+	switch {
+	case err == nil:
+		return refunded, nil
+	case errors.As(err, &transitionErr):
+		return 0, transitionErr
+	case errors.Is(err, storage.ErrPaymentNotFound):
+		return 0, _errPaymentNotFound
+	case errors.Is(err, storage.ErrRefundInProgress):
+		return 0, _errRefundInProgress
+	case errors.Is(err, storage.ErrRefundExceedsCaptured):
+		return 0, invalidAmount(field, "exceeds the refundable amount of the payment")
 	default:
-		return protos.Status_PENDING
+		log.WithField("ref", ref).WithError(err).Error("reserving refund")
+
+		return 0, _errUpdatingPayment
+	}
+}
+
+// releaseRefund releases the reservation of a refund the acquirer did not make, a reservation that
+// cannot be released only blocks further refunds of the payment
+func (s *server) releaseRefund(ctx context.Context, ref string) {
+	if err := s.dbClient.ReleaseRefund(ctx, ref); err != nil {
+		log.WithField("ref", ref).WithError(err).Error("releasing refund")
+	}
+}
+
+// lookupPayment retrieves a stored payment of the merchant and converts a missing payment into a
+// NotFound error. Payments are only modified after being looked up so merchants cannot modify the
+// payments of others
+func (s *server) lookupPayment(ctx context.Context, ref string) (*protos.GetPaymentResponse, error) {
+	payment, err := s.dbClient.GetPaymentInfo(ctx, auth.MerchantID(ctx), ref)
+	if err != nil {
+		log.WithField("ref", ref).WithError(err).Error("getting payment info")
+
+		return nil, _errAGettingPaymentInfo
+	}
+
+	if payment.GetStatus() == protos.Status_UNKNOWN {
+		return nil, _errPaymentNotFound
+	}
+
+	return payment, nil
+}
+
+func (s *server) Register(grpcService *grpc.Server) {
+	protos.RegisterPaymentsServer(grpcService, s)
+}
+
+// modificationDeclined describes a capture, void or refund the acquiring bank declined
+func modificationDeclined(code, reason string) *protos.Error {
+	return &protos.Error{Code: string(errcodes.ModificationDeclined), Reason: reason, BankCode: code}
+}
+
+// modificationApproved reports whether the acquiring bank accepted a capture, void or refund, the
+// code is mapped with the response code table of the acquirer of the payment
+func (s *server) modificationApproved(acquirer, code string) bool {
+	if acquirer == "" {
+		acquirer = bank.DefaultAcquirer
 	}
+
+	return s.responseCodes.Map(acquirer, code).Status == protos.Status_APPROVED
 }
@@ -10,12 +10,57 @@ import (
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
 
+	bank "payments_gateway/aquiring-bank"
 	"payments_gateway/aquiring-bank/mocks"
+	"payments_gateway/breaker"
+	"payments_gateway/currency"
 	"payments_gateway/model"
+	"payments_gateway/money"
 	protos "payments_gateway/protos"
+	"payments_gateway/statemachine"
+	"payments_gateway/storage"
 	"payments_gateway/storage/mocks"
 )
 
+// expectStored expects a payment to be stored as AUTHORIZING with the acquirer it is routed to before
+// it is authorized, then its authorization to be stored
+func expectStored(storageMock *mock_storage.MockClient, merchantID string, record, amount interface{}, authorization model.Authorization) {
+	storageMock.EXPECT().
+		AddPaymentInfo(gomock.Any(), merchantID, gomock.Any(), record, amount, model.Authorization{
+			Acquirer: authorization.Acquirer,
+			Status:   protos.Status_AUTHORIZING,
+			Source:   protos.StatusSource_GATEWAY,
+		}).
+		Times(1).
+		Return(nil)
+	storageMock.EXPECT().
+		UpdateAuthorization(gomock.Any(), gomock.Any(), authorization).
+		Times(1).
+		Return(nil)
+}
+
+// expectReversed expects a payment of 20.50 GBP to be stored as AUTHORIZING with the default acquirer
+// before it is authorized, then to be stored as REVERSED after its authorization failed
+func expectReversed(storageMock *mock_storage.MockClient, merchantID string, record interface{}) {
+	storageMock.EXPECT().
+		AddPaymentInfo(gomock.Any(), merchantID, gomock.Any(), record, money.New(2050, "GBP"), model.Authorization{
+			Acquirer: bank.DefaultAcquirer,
+			Status:   protos.Status_AUTHORIZING,
+			Source:   protos.StatusSource_GATEWAY,
+		}).
+		Times(1).
+		Return(nil)
+	storageMock.EXPECT().
+		UpdateAuthorization(gomock.Any(), gomock.Any(), model.Authorization{
+			Acquirer: bank.DefaultAcquirer,
+			Status:   protos.Status_REVERSED,
+			Reason:   "authorization outcome was not received",
+			Source:   protos.StatusSource_GATEWAY,
+		}).
+		Times(1).
+		Return(nil)
+}
+
 func Test_server_ProcessPayment(t *testing.T) {
 	mockController := gomock.NewController(t)
 
@@ -25,7 +70,7 @@ func Test_server_ProcessPayment(t *testing.T) {
 
 	defer mockController.Finish()
 
-	validationErr := fmt.Errorf("failed validation: invalid card number")
+	validationErr := &bank.DeclinedError{Reason: "failed validation: invalid card number"}
 
 	req := &protos.ProcessPaymentRequest{
 		BillingDetails: &protos.BillingDetails{
@@ -38,12 +83,12 @@ func Test_server_ProcessPayment(t *testing.T) {
 			Postcode:      "G15 2DN",
 		},
 		CardNumber:  "378282246310005",
-		Expiry:      "23/4",
+		Expiry:      "04/30",
 		Amount:      20.5,
 		Currency:    "GBP",
-		Cvv:         342,
+		Cvv:         3421,
 		PaymentType: protos.PaymentType_CARD,
-		CardType:    protos.CardType_VISA,
+		CardType:    protos.CardType_AMERICAN_EXPRESS,
 	}
 
 	type args struct {
@@ -63,10 +108,7 @@ func Test_server_ProcessPayment(t *testing.T) {
 			args: args{
 				request: req,
 				storageMockOutcomes: func(storageMock *mock_storage.MockClient) {
-					storageMock.EXPECT().
-						AddPaymentInfo(gomock.Any(), gomock.Any(), req, protos.Status_APPROVED, "approved and completed successfully").
-						Times(1).
-						Return(nil)
+					expectStored(storageMock, "", req, money.New(2050, "GBP"), model.Authorization{Acquirer: bank.DefaultAcquirer, Status: protos.Status_APPROVED, Reason: "approved and completed successfully", ResponseCode: "00"})
 				},
 				BankMockOutcomes: func(bankMock *mock_bank.MockClient) {
 					bankMock.EXPECT().
@@ -102,14 +144,101 @@ func Test_server_ProcessPayment(t *testing.T) {
 			err: fmt.Errorf("rpc error: code = InvalidArgument desc = validating payment"),
 		},
 		{
-			name: "Rejected auth",
+			name: "fails validation without a reason",
 			args: args{
 				request: req,
 				storageMockOutcomes: func(storageMock *mock_storage.MockClient) {
-					storageMock.EXPECT().
-						AddPaymentInfo(gomock.Any(), gomock.Any(), req, protos.Status_REJECTED, "transaction error").
+				},
+				BankMockOutcomes: func(bankMock *mock_bank.MockClient) {
+					bankMock.EXPECT().
+						Validate(gomock.Any(), model.ConvertToCardDetails(req)).
 						Times(1).
-						Return(nil)
+						Return(false, nil)
+				},
+			},
+			err: fmt.Errorf("rpc error: code = InvalidArgument desc = validating payment"),
+		},
+		{
+			name: "validation request fails",
+			args: args{
+				request: req,
+				storageMockOutcomes: func(storageMock *mock_storage.MockClient) {
+				},
+				BankMockOutcomes: func(bankMock *mock_bank.MockClient) {
+					bankMock.EXPECT().
+						Validate(gomock.Any(), model.ConvertToCardDetails(req)).
+						Times(1).
+						Return(false, fmt.Errorf("error performing validation request: giving up after 4 attempt(s)"))
+				},
+			},
+			err: fmt.Errorf("rpc error: code = Internal desc = validate card details"),
+		},
+		{
+			name: "amount with fractions of a penny",
+			args: args{
+				request: &protos.ProcessPaymentRequest{
+					BillingDetails: req.BillingDetails,
+					CardNumber:     req.CardNumber,
+					Expiry:         req.Expiry,
+					Amount:         20.505,
+					Currency:       "GBP",
+					Cvv:            req.Cvv,
+					PaymentType:    protos.PaymentType_CARD,
+					CardType:       protos.CardType_AMERICAN_EXPRESS,
+				},
+				storageMockOutcomes: func(storageMock *mock_storage.MockClient) {
+				},
+				BankMockOutcomes: func(bankMock *mock_bank.MockClient) {
+				},
+			},
+			err: fmt.Errorf("rpc error: code = InvalidArgument desc = invalid amount"),
+		},
+		{
+			name: "card fails validation before reaching the bank",
+			args: args{
+				request: &protos.ProcessPaymentRequest{
+					BillingDetails: req.BillingDetails,
+					CardNumber:     "378282246310006",
+					Expiry:         "01/20",
+					Amount:         20.5,
+					Currency:       "GBP",
+					Cvv:            req.Cvv,
+					PaymentType:    protos.PaymentType_CARD,
+					CardType:       protos.CardType_AMERICAN_EXPRESS,
+				},
+				storageMockOutcomes: func(storageMock *mock_storage.MockClient) {
+				},
+				BankMockOutcomes: func(bankMock *mock_bank.MockClient) {
+				},
+			},
+			err: fmt.Errorf("rpc error: code = InvalidArgument desc = card_number: failed the Luhn check; expiry: card has expired"),
+		},
+		{
+			name: "card type does not match the card number",
+			args: args{
+				request: &protos.ProcessPaymentRequest{
+					BillingDetails: req.BillingDetails,
+					CardNumber:     req.CardNumber,
+					Expiry:         req.Expiry,
+					Amount:         20.5,
+					Currency:       "GBP",
+					Cvv:            req.Cvv,
+					PaymentType:    protos.PaymentType_CARD,
+					CardType:       protos.CardType_VISA,
+				},
+				storageMockOutcomes: func(storageMock *mock_storage.MockClient) {
+				},
+				BankMockOutcomes: func(bankMock *mock_bank.MockClient) {
+				},
+			},
+			err: fmt.Errorf("rpc error: code = InvalidArgument desc = card_type: card number belongs to AMERICAN_EXPRESS"),
+		},
+		{
+			name: "Rejected auth",
+			args: args{
+				request: req,
+				storageMockOutcomes: func(storageMock *mock_storage.MockClient) {
+					expectStored(storageMock, "", req, money.New(2050, "GBP"), model.Authorization{Acquirer: bank.DefaultAcquirer, Status: protos.Status_REJECTED, Reason: "transaction error", ResponseCode: "06"})
 				},
 				BankMockOutcomes: func(bankMock *mock_bank.MockClient) {
 					bankMock.EXPECT().
@@ -126,6 +255,39 @@ func Test_server_ProcessPayment(t *testing.T) {
 				Reference:    "825ca1787c9d4672991848a5bfbc1057",
 				Status:       protos.Status_REJECTED,
 				StatusReason: "transaction error",
+				Error: &protos.Error{
+					Code:        "AUTHORIZATION_DECLINED",
+					Reason:      "transaction error",
+					BankCode:    "06",
+					DeclineType: protos.DeclineType_SOFT_DECLINE,
+					Retryable:   true,
+				},
+			},
+			err: nil,
+		},
+		{
+			name: "unknown response code",
+			args: args{
+				request: req,
+				storageMockOutcomes: func(storageMock *mock_storage.MockClient) {
+					expectStored(storageMock, "", req, money.New(2050, "GBP"), model.Authorization{Acquirer: bank.DefaultAcquirer, Status: protos.Status_UNKNOWN_RESPONSE_CODE, Reason: "do not honour", ResponseCode: "05"})
+				},
+				BankMockOutcomes: func(bankMock *mock_bank.MockClient) {
+					bankMock.EXPECT().
+						Validate(gomock.Any(), model.ConvertToCardDetails(req)).
+						Times(1).
+						Return(true, nil)
+					bankMock.EXPECT().
+						Authorize(gomock.Any(), gomock.Any()).
+						Times(1).
+						Return("05", "do not honour", nil)
+				},
+			},
+			want: &protos.ProcessPaymentResponse{
+				Reference:    "825ca1787c9d4672991848a5bfbc1057",
+				Status:       protos.Status_UNKNOWN_RESPONSE_CODE,
+				StatusReason: "do not honour",
+				Error:        &protos.Error{Code: "UNKNOWN_RESPONSE_CODE", Reason: "do not honour", BankCode: "05"},
 			},
 			err: nil,
 		},
@@ -149,6 +311,14 @@ func Test_server_ProcessPayment(t *testing.T) {
 			assert.Equal(t, got.StatusReason, tt.want.StatusReason)
 
 			assert.Equal(t, got.Status, tt.want.Status)
+
+			assert.Equal(t, tt.want.GetError().GetCode(), got.GetError().GetCode())
+
+			assert.Equal(t, tt.want.GetError().GetBankCode(), got.GetError().GetBankCode())
+
+			assert.Equal(t, tt.want.GetError().GetDeclineType(), got.GetError().GetDeclineType())
+
+			assert.Equal(t, tt.want.GetError().GetRetryable(), got.GetError().GetRetryable())
 		})
 	}
 }
@@ -212,7 +382,7 @@ func Test_server_GetPayment(t *testing.T) {
 				},
 				storageMockOutcomes: func(storageMock *mock_storage.MockClient) {
 					storageMock.EXPECT().
-						GetPaymentInfo(gomock.Any(), refID).
+						GetPaymentInfo(gomock.Any(), "", refID).
 						Times(1).
 						Return(resp, nil)
 				},
@@ -230,7 +400,7 @@ func Test_server_GetPayment(t *testing.T) {
 				},
 				storageMockOutcomes: func(storageMock *mock_storage.MockClient) {
 					storageMock.EXPECT().
-						GetPaymentInfo(gomock.Any(), refID).
+						GetPaymentInfo(gomock.Any(), "", refID).
 						Times(1).
 						Return(&protos.GetPaymentResponse{Ref: refID, Status: protos.Status_UNKNOWN, PaymentType: protos.PaymentType_UNDEFINED, StatusReason: "transaction does not exist"}, nil)
 				},
@@ -245,6 +415,60 @@ func Test_server_GetPayment(t *testing.T) {
 			},
 			err: nil,
 		},
+		{
+			name: "get payment by merchant reference",
+			args: args{
+				request: &protos.GetPaymentRequest{
+					MerchantReference: "order-1234",
+				},
+				storageMockOutcomes: func(storageMock *mock_storage.MockClient) {
+					storageMock.EXPECT().
+						GetPaymentByReference(gomock.Any(), "", "order-1234").
+						Times(1).
+						Return(resp, nil)
+				},
+				BankMockOutcomes: func(bankMock *mock_bank.MockClient) {
+				},
+			},
+			want: resp,
+			err:  nil,
+		},
+		{
+			name: "ref takes precedence over merchant reference",
+			args: args{
+				request: &protos.GetPaymentRequest{
+					Ref:               refID,
+					MerchantReference: "order-1234",
+				},
+				storageMockOutcomes: func(storageMock *mock_storage.MockClient) {
+					storageMock.EXPECT().
+						GetPaymentInfo(gomock.Any(), "", refID).
+						Times(1).
+						Return(resp, nil)
+				},
+				BankMockOutcomes: func(bankMock *mock_bank.MockClient) {
+				},
+			},
+			want: resp,
+			err:  nil,
+		},
+		{
+			name: "error getting payment by merchant reference",
+			args: args{
+				request: &protos.GetPaymentRequest{
+					MerchantReference: "order-1234",
+				},
+				storageMockOutcomes: func(storageMock *mock_storage.MockClient) {
+					storageMock.EXPECT().
+						GetPaymentByReference(gomock.Any(), "", "order-1234").
+						Times(1).
+						Return(nil, fmt.Errorf("connection refused"))
+				},
+				BankMockOutcomes: func(bankMock *mock_bank.MockClient) {
+				},
+			},
+			err: fmt.Errorf("rpc error: code = Internal desc = error getting payment info"),
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -266,3 +490,837 @@ func Test_server_GetPayment(t *testing.T) {
 		})
 	}
 }
+
+func Test_server_CapturePayment(t *testing.T) {
+	mockController := gomock.NewController(t)
+
+	storageMock := mock_storage.NewMockClient(mockController)
+
+	bankMock := mock_bank.NewMockClient(mockController)
+
+	defer mockController.Finish()
+
+	refID := "825ca1787c9d4672991848a5bfbc1057"
+
+	approved := &protos.GetPaymentResponse{
+		Ref:              refID,
+		Amount:           20.5,
+		AmountMinorUnits: 2050,
+		Currency:         "GBP",
+		Status:           protos.Status_APPROVED,
+	}
+
+	type args struct {
+		request             *protos.CapturePaymentRequest
+		storageMockOutcomes func(storageMock *mock_storage.MockClient)
+		BankMockOutcomes    func(bankMock *mock_bank.MockClient)
+	}
+
+	tests := []struct {
+		name string
+		args args
+		want *protos.CapturePaymentResponse
+		err  error
+	}{
+		{
+			name: "successfully captures the full amount",
+			args: args{
+				request: &protos.CapturePaymentRequest{Ref: refID},
+				storageMockOutcomes: func(storageMock *mock_storage.MockClient) {
+					storageMock.EXPECT().
+						GetPaymentInfo(gomock.Any(), "", refID).
+						Times(1).
+						Return(approved, nil)
+					storageMock.EXPECT().
+						UpdatePaymentStatus(gomock.Any(), model.StatusUpdate{RefID: refID, Status: protos.Status_CAPTURED, Reason: "captured", Source: protos.StatusSource_OPERATOR, CapturedAmount: 2050}).
+						Times(1).
+						Return(nil)
+				},
+				BankMockOutcomes: func(bankMock *mock_bank.MockClient) {
+					bankMock.EXPECT().
+						Capture(gomock.Any(), model.Modification{RefID: refID, Amount: money.New(2050, "GBP")}).
+						Times(1).
+						Return("00", "captured", nil)
+				},
+			},
+			want: &protos.CapturePaymentResponse{
+				Reference:                refID,
+				Status:                   protos.Status_CAPTURED,
+				StatusReason:             "captured",
+				CapturedAmount:           20.5,
+				CapturedAmountMinorUnits: 2050,
+			},
+		},
+		{
+			name: "successfully captures a partial amount",
+			args: args{
+				request: &protos.CapturePaymentRequest{Ref: refID, Amount: 10},
+				storageMockOutcomes: func(storageMock *mock_storage.MockClient) {
+					storageMock.EXPECT().
+						GetPaymentInfo(gomock.Any(), "", refID).
+						Times(1).
+						Return(approved, nil)
+					storageMock.EXPECT().
+						UpdatePaymentStatus(gomock.Any(), model.StatusUpdate{RefID: refID, Status: protos.Status_CAPTURED, Reason: "captured", Source: protos.StatusSource_OPERATOR, CapturedAmount: 1000}).
+						Times(1).
+						Return(nil)
+				},
+				BankMockOutcomes: func(bankMock *mock_bank.MockClient) {
+					bankMock.EXPECT().
+						Capture(gomock.Any(), model.Modification{RefID: refID, Amount: money.New(1000, "GBP")}).
+						Times(1).
+						Return("00", "captured", nil)
+				},
+			},
+			want: &protos.CapturePaymentResponse{
+				Reference:                refID,
+				Status:                   protos.Status_CAPTURED,
+				StatusReason:             "captured",
+				CapturedAmount:           10,
+				CapturedAmountMinorUnits: 1000,
+			},
+		},
+		{
+			name: "payment voided concurrently",
+			args: args{
+				request: &protos.CapturePaymentRequest{Ref: refID},
+				storageMockOutcomes: func(storageMock *mock_storage.MockClient) {
+					storageMock.EXPECT().
+						GetPaymentInfo(gomock.Any(), "", refID).
+						Times(1).
+						Return(approved, nil)
+					storageMock.EXPECT().
+						UpdatePaymentStatus(gomock.Any(), model.StatusUpdate{RefID: refID, Status: protos.Status_CAPTURED, Reason: "captured", Source: protos.StatusSource_OPERATOR, CapturedAmount: 2050}).
+						Times(1).
+						Return(&statemachine.TransitionError{From: protos.Status_VOIDED, To: protos.Status_CAPTURED})
+				},
+				BankMockOutcomes: func(bankMock *mock_bank.MockClient) {
+					bankMock.EXPECT().
+						Capture(gomock.Any(), model.Modification{RefID: refID, Amount: money.New(2050, "GBP")}).
+						Times(1).
+						Return("00", "captured", nil)
+				},
+			},
+			err: fmt.Errorf("illegal status transition from VOIDED to CAPTURED"),
+		},
+		{
+			name: "captures a partial amount in minor units",
+			args: args{
+				request: &protos.CapturePaymentRequest{Ref: refID, AmountMinorUnits: 1001},
+				storageMockOutcomes: func(storageMock *mock_storage.MockClient) {
+					storageMock.EXPECT().
+						GetPaymentInfo(gomock.Any(), "", refID).
+						Times(1).
+						Return(approved, nil)
+					storageMock.EXPECT().
+						UpdatePaymentStatus(gomock.Any(), model.StatusUpdate{RefID: refID, Status: protos.Status_CAPTURED, Reason: "captured", Source: protos.StatusSource_OPERATOR, CapturedAmount: 1001}).
+						Times(1).
+						Return(nil)
+				},
+				BankMockOutcomes: func(bankMock *mock_bank.MockClient) {
+					bankMock.EXPECT().
+						Capture(gomock.Any(), model.Modification{RefID: refID, Amount: money.New(1001, "GBP")}).
+						Times(1).
+						Return("00", "captured", nil)
+				},
+			},
+			want: &protos.CapturePaymentResponse{
+				Reference:                refID,
+				Status:                   protos.Status_CAPTURED,
+				StatusReason:             "captured",
+				CapturedAmount:           10.01,
+				CapturedAmountMinorUnits: 1001,
+			},
+		},
+		{
+			name: "amount with fractions of a penny",
+			args: args{
+				request: &protos.CapturePaymentRequest{Ref: refID, Amount: 10.001},
+				storageMockOutcomes: func(storageMock *mock_storage.MockClient) {
+					storageMock.EXPECT().
+						GetPaymentInfo(gomock.Any(), "", refID).
+						Times(1).
+						Return(approved, nil)
+				},
+				BankMockOutcomes: func(bankMock *mock_bank.MockClient) {
+				},
+			},
+			err: fmt.Errorf("rpc error: code = InvalidArgument desc = invalid amount"),
+		},
+		{
+			name: "amount greater than authorized",
+			args: args{
+				request: &protos.CapturePaymentRequest{Ref: refID, Amount: 30},
+				storageMockOutcomes: func(storageMock *mock_storage.MockClient) {
+					storageMock.EXPECT().
+						GetPaymentInfo(gomock.Any(), "", refID).
+						Times(1).
+						Return(approved, nil)
+				},
+				BankMockOutcomes: func(bankMock *mock_bank.MockClient) {
+				},
+			},
+			err: fmt.Errorf("rpc error: code = InvalidArgument desc = invalid amount"),
+		},
+		{
+			name: "payment not found",
+			args: args{
+				request: &protos.CapturePaymentRequest{Ref: refID},
+				storageMockOutcomes: func(storageMock *mock_storage.MockClient) {
+					storageMock.EXPECT().
+						GetPaymentInfo(gomock.Any(), "", refID).
+						Times(1).
+						Return(&protos.GetPaymentResponse{Status: protos.Status_UNKNOWN, StatusReason: "transaction does not exist"}, nil)
+				},
+				BankMockOutcomes: func(bankMock *mock_bank.MockClient) {
+				},
+			},
+			err: fmt.Errorf("rpc error: code = NotFound desc = payment not found"),
+		},
+		{
+			name: "rejected payment cannot be captured",
+			args: args{
+				request: &protos.CapturePaymentRequest{Ref: refID},
+				storageMockOutcomes: func(storageMock *mock_storage.MockClient) {
+					storageMock.EXPECT().
+						GetPaymentInfo(gomock.Any(), "", refID).
+						Times(1).
+						Return(&protos.GetPaymentResponse{Ref: refID, Status: protos.Status_REJECTED}, nil)
+				},
+				BankMockOutcomes: func(bankMock *mock_bank.MockClient) {
+				},
+			},
+			err: fmt.Errorf("illegal status transition from REJECTED to CAPTURED"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.args.storageMockOutcomes(storageMock)
+			tt.args.BankMockOutcomes(bankMock)
+
+			s := New(storageMock, bankMock)
+
+			got, err := s.CapturePayment(context.Background(), tt.args.request)
+			if err != nil {
+				assert.Equal(t, tt.err.Error(), err.Error())
+
+				return
+			}
+
+			assert.Equal(t, tt.want.Reference, got.Reference)
+			assert.Equal(t, tt.want.Status, got.Status)
+			assert.Equal(t, tt.want.StatusReason, got.StatusReason)
+			assert.Equal(t, tt.want.CapturedAmount, got.CapturedAmount)
+			assert.Equal(t, tt.want.CapturedAmountMinorUnits, got.CapturedAmountMinorUnits)
+		})
+	}
+}
+
+func Test_server_VoidPayment(t *testing.T) {
+	mockController := gomock.NewController(t)
+
+	storageMock := mock_storage.NewMockClient(mockController)
+
+	bankMock := mock_bank.NewMockClient(mockController)
+
+	defer mockController.Finish()
+
+	refID := "825ca1787c9d4672991848a5bfbc1057"
+
+	type args struct {
+		request             *protos.VoidPaymentRequest
+		storageMockOutcomes func(storageMock *mock_storage.MockClient)
+		BankMockOutcomes    func(bankMock *mock_bank.MockClient)
+	}
+
+	tests := []struct {
+		name string
+		args args
+		want *protos.VoidPaymentResponse
+		err  error
+	}{
+		{
+			name: "successfully voids an approved payment",
+			args: args{
+				request: &protos.VoidPaymentRequest{Ref: refID},
+				storageMockOutcomes: func(storageMock *mock_storage.MockClient) {
+					storageMock.EXPECT().
+						GetPaymentInfo(gomock.Any(), "", refID).
+						Times(1).
+						Return(&protos.GetPaymentResponse{Ref: refID, Amount: 20.5, AmountMinorUnits: 2050, Currency: "GBP", Status: protos.Status_APPROVED}, nil)
+					storageMock.EXPECT().
+						UpdatePaymentStatus(gomock.Any(), model.StatusUpdate{RefID: refID, Status: protos.Status_VOIDED, Reason: "voided", Source: protos.StatusSource_OPERATOR}).
+						Times(1).
+						Return(nil)
+				},
+				BankMockOutcomes: func(bankMock *mock_bank.MockClient) {
+					bankMock.EXPECT().
+						Void(gomock.Any(), model.Modification{RefID: refID, Amount: money.New(2050, "GBP")}).
+						Times(1).
+						Return("00", "voided", nil)
+				},
+			},
+			want: &protos.VoidPaymentResponse{
+				Reference:    refID,
+				Status:       protos.Status_VOIDED,
+				StatusReason: "voided",
+			},
+		},
+		{
+			name: "bank declines the void",
+			args: args{
+				request: &protos.VoidPaymentRequest{Ref: refID},
+				storageMockOutcomes: func(storageMock *mock_storage.MockClient) {
+					storageMock.EXPECT().
+						GetPaymentInfo(gomock.Any(), "", refID).
+						Times(1).
+						Return(&protos.GetPaymentResponse{Ref: refID, Amount: 20.5, AmountMinorUnits: 2050, Currency: "GBP", Status: protos.Status_APPROVED, StatusReason: "approved and completed successfully"}, nil)
+				},
+				BankMockOutcomes: func(bankMock *mock_bank.MockClient) {
+					bankMock.EXPECT().
+						Void(gomock.Any(), model.Modification{RefID: refID, Amount: money.New(2050, "GBP")}).
+						Times(1).
+						Return("12", "authorization already expired", nil)
+				},
+			},
+			want: &protos.VoidPaymentResponse{
+				Reference:    refID,
+				Status:       protos.Status_APPROVED,
+				StatusReason: "approved and completed successfully",
+				Error:        &protos.Error{Code: "MODIFICATION_DECLINED", Reason: "authorization already expired", BankCode: "12"},
+			},
+		},
+		{
+			name: "captured payment cannot be voided",
+			args: args{
+				request: &protos.VoidPaymentRequest{Ref: refID},
+				storageMockOutcomes: func(storageMock *mock_storage.MockClient) {
+					storageMock.EXPECT().
+						GetPaymentInfo(gomock.Any(), "", refID).
+						Times(1).
+						Return(&protos.GetPaymentResponse{Ref: refID, Status: protos.Status_CAPTURED}, nil)
+				},
+				BankMockOutcomes: func(bankMock *mock_bank.MockClient) {
+				},
+			},
+			err: fmt.Errorf("illegal status transition from CAPTURED to VOIDED"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.args.storageMockOutcomes(storageMock)
+			tt.args.BankMockOutcomes(bankMock)
+
+			s := New(storageMock, bankMock)
+
+			got, err := s.VoidPayment(context.Background(), tt.args.request)
+			if err != nil {
+				assert.Equal(t, tt.err.Error(), err.Error())
+
+				return
+			}
+
+			assert.Equal(t, tt.want.Reference, got.Reference)
+			assert.Equal(t, tt.want.Status, got.Status)
+			assert.Equal(t, tt.want.StatusReason, got.StatusReason)
+			assert.Equal(t, tt.want.GetError().GetCode(), got.GetError().GetCode())
+			assert.Equal(t, tt.want.GetError().GetBankCode(), got.GetError().GetBankCode())
+		})
+	}
+}
+
+func Test_server_RefundPayment(t *testing.T) {
+	mockController := gomock.NewController(t)
+
+	storageMock := mock_storage.NewMockClient(mockController)
+
+	bankMock := mock_bank.NewMockClient(mockController)
+
+	defer mockController.Finish()
+
+	refID := "825ca1787c9d4672991848a5bfbc1057"
+
+	captured := &protos.GetPaymentResponse{
+		Ref:                      refID,
+		Amount:                   20.5,
+		AmountMinorUnits:         2050,
+		Currency:                 "GBP",
+		Status:                   protos.Status_CAPTURED,
+		CapturedAmount:           20.5,
+		CapturedAmountMinorUnits: 2050,
+	}
+
+	type args struct {
+		request             *protos.RefundPaymentRequest
+		storageMockOutcomes func(storageMock *mock_storage.MockClient)
+		BankMockOutcomes    func(bankMock *mock_bank.MockClient)
+	}
+
+	tests := []struct {
+		name string
+		args args
+		want *protos.RefundPaymentResponse
+		err  error
+	}{
+		{
+			name: "partially refunds a captured payment",
+			args: args{
+				request: &protos.RefundPaymentRequest{Ref: refID, Amount: 5},
+				storageMockOutcomes: func(storageMock *mock_storage.MockClient) {
+					storageMock.EXPECT().
+						GetPaymentInfo(gomock.Any(), "", refID).
+						Times(1).
+						Return(captured, nil)
+					storageMock.EXPECT().
+						ReserveRefund(gomock.Any(), refID, int64(500)).
+						Times(1).
+						Return(int64(0), nil)
+					storageMock.EXPECT().
+						UpdatePaymentStatus(gomock.Any(), model.StatusUpdate{RefID: refID, Status: protos.Status_PARTIALLY_REFUNDED, Reason: "refunded", Source: protos.StatusSource_OPERATOR, RefundedAmount: 500}).
+						Times(1).
+						Return(nil)
+				},
+				BankMockOutcomes: func(bankMock *mock_bank.MockClient) {
+					bankMock.EXPECT().
+						Refund(gomock.Any(), model.Modification{RefID: refID, Amount: money.New(500, "GBP")}).
+						Times(1).
+						Return("00", "refunded", nil)
+				},
+			},
+			want: &protos.RefundPaymentResponse{
+				Reference:                refID,
+				Status:                   protos.Status_PARTIALLY_REFUNDED,
+				StatusReason:             "refunded",
+				RefundedAmount:           5,
+				RefundedAmountMinorUnits: 500,
+			},
+		},
+		{
+			name: "concurrent refund was stored first",
+			args: args{
+				request: &protos.RefundPaymentRequest{Ref: refID, Amount: 5},
+				storageMockOutcomes: func(storageMock *mock_storage.MockClient) {
+					storageMock.EXPECT().
+						GetPaymentInfo(gomock.Any(), "", refID).
+						Times(1).
+						Return(captured, nil)
+					storageMock.EXPECT().
+						ReserveRefund(gomock.Any(), refID, int64(500)).
+						Times(1).
+						Return(int64(0), storage.ErrRefundExceedsCaptured)
+				},
+				BankMockOutcomes: func(bankMock *mock_bank.MockClient) {
+				},
+			},
+			err: fmt.Errorf("rpc error: code = InvalidArgument desc = invalid amount"),
+		},
+		{
+			name: "concurrent refund is in progress",
+			args: args{
+				request: &protos.RefundPaymentRequest{Ref: refID, Amount: 5},
+				storageMockOutcomes: func(storageMock *mock_storage.MockClient) {
+					storageMock.EXPECT().
+						GetPaymentInfo(gomock.Any(), "", refID).
+						Times(1).
+						Return(captured, nil)
+					storageMock.EXPECT().
+						ReserveRefund(gomock.Any(), refID, int64(500)).
+						Times(1).
+						Return(int64(0), storage.ErrRefundInProgress)
+				},
+				BankMockOutcomes: func(bankMock *mock_bank.MockClient) {
+				},
+			},
+			err: fmt.Errorf("rpc error: code = Aborted desc = another refund of the payment is in progress"),
+		},
+		{
+			name: "refund stored concurrently leaves the rest to refund",
+			args: args{
+				request: &protos.RefundPaymentRequest{Ref: refID, Amount: 5},
+				storageMockOutcomes: func(storageMock *mock_storage.MockClient) {
+					storageMock.EXPECT().
+						GetPaymentInfo(gomock.Any(), "", refID).
+						Times(1).
+						Return(captured, nil)
+					storageMock.EXPECT().
+						ReserveRefund(gomock.Any(), refID, int64(500)).
+						Times(1).
+						Return(int64(1550), nil)
+					storageMock.EXPECT().
+						UpdatePaymentStatus(gomock.Any(), model.StatusUpdate{RefID: refID, Status: protos.Status_REFUNDED, Reason: "refunded", Source: protos.StatusSource_OPERATOR, RefundedAmount: 500}).
+						Times(1).
+						Return(nil)
+				},
+				BankMockOutcomes: func(bankMock *mock_bank.MockClient) {
+					bankMock.EXPECT().
+						Refund(gomock.Any(), model.Modification{RefID: refID, Amount: money.New(500, "GBP")}).
+						Times(1).
+						Return("00", "refunded", nil)
+				},
+			},
+			want: &protos.RefundPaymentResponse{
+				Reference:                refID,
+				Status:                   protos.Status_REFUNDED,
+				StatusReason:             "refunded",
+				RefundedAmount:           20.5,
+				RefundedAmountMinorUnits: 2050,
+			},
+		},
+		{
+			name: "declined refund releases its reservation",
+			args: args{
+				request: &protos.RefundPaymentRequest{Ref: refID, Amount: 5},
+				storageMockOutcomes: func(storageMock *mock_storage.MockClient) {
+					storageMock.EXPECT().
+						GetPaymentInfo(gomock.Any(), "", refID).
+						Times(1).
+						Return(captured, nil)
+					storageMock.EXPECT().
+						ReserveRefund(gomock.Any(), refID, int64(500)).
+						Times(1).
+						Return(int64(0), nil)
+					storageMock.EXPECT().
+						ReleaseRefund(gomock.Any(), refID).
+						Times(1).
+						Return(nil)
+				},
+				BankMockOutcomes: func(bankMock *mock_bank.MockClient) {
+					bankMock.EXPECT().
+						Refund(gomock.Any(), gomock.Any()).
+						Times(1).
+						Return("05", "declined", nil)
+				},
+			},
+			want: &protos.RefundPaymentResponse{
+				Reference: refID,
+				Status:    protos.Status_CAPTURED,
+			},
+		},
+		{
+			name: "refund the breaker did not send releases its reservation",
+			args: args{
+				request: &protos.RefundPaymentRequest{Ref: refID, Amount: 5},
+				storageMockOutcomes: func(storageMock *mock_storage.MockClient) {
+					storageMock.EXPECT().
+						GetPaymentInfo(gomock.Any(), "", refID).
+						Times(1).
+						Return(captured, nil)
+					storageMock.EXPECT().
+						ReserveRefund(gomock.Any(), refID, int64(500)).
+						Times(1).
+						Return(int64(0), nil)
+					storageMock.EXPECT().
+						ReleaseRefund(gomock.Any(), refID).
+						Times(1).
+						Return(nil)
+				},
+				BankMockOutcomes: func(bankMock *mock_bank.MockClient) {
+					bankMock.EXPECT().
+						Refund(gomock.Any(), gomock.Any()).
+						Times(1).
+						Return("", "", breaker.ErrOpen)
+				},
+			},
+			err: fmt.Errorf("rpc error: code = Unavailable desc = acquiring bank is unavailable"),
+		},
+		{
+			name: "refund that failed after it was sent keeps its reservation",
+			args: args{
+				request: &protos.RefundPaymentRequest{Ref: refID, Amount: 5},
+				storageMockOutcomes: func(storageMock *mock_storage.MockClient) {
+					storageMock.EXPECT().
+						GetPaymentInfo(gomock.Any(), "", refID).
+						Times(1).
+						Return(captured, nil)
+					storageMock.EXPECT().
+						ReserveRefund(gomock.Any(), refID, int64(500)).
+						Times(1).
+						Return(int64(0), nil)
+				},
+				BankMockOutcomes: func(bankMock *mock_bank.MockClient) {
+					bankMock.EXPECT().
+						Refund(gomock.Any(), gomock.Any()).
+						Times(1).
+						Return("", "", fmt.Errorf("unexpected response status code: 500"))
+				},
+			},
+			err: fmt.Errorf("rpc error: code = Internal desc = refund payment"),
+		},
+		{
+			name: "refunds the remaining amount of a partially refunded payment",
+			args: args{
+				request: &protos.RefundPaymentRequest{Ref: refID},
+				storageMockOutcomes: func(storageMock *mock_storage.MockClient) {
+					storageMock.EXPECT().
+						GetPaymentInfo(gomock.Any(), "", refID).
+						Times(1).
+						Return(&protos.GetPaymentResponse{
+							Ref:                      refID,
+							Amount:                   20.5,
+							AmountMinorUnits:         2050,
+							Currency:                 "GBP",
+							Status:                   protos.Status_PARTIALLY_REFUNDED,
+							CapturedAmount:           20.5,
+							CapturedAmountMinorUnits: 2050,
+							RefundedAmount:           5,
+							RefundedAmountMinorUnits: 500,
+						}, nil)
+					storageMock.EXPECT().
+						ReserveRefund(gomock.Any(), refID, int64(1550)).
+						Times(1).
+						Return(int64(500), nil)
+					storageMock.EXPECT().
+						UpdatePaymentStatus(gomock.Any(), model.StatusUpdate{RefID: refID, Status: protos.Status_REFUNDED, Reason: "refunded", Source: protos.StatusSource_OPERATOR, RefundedAmount: 1550}).
+						Times(1).
+						Return(nil)
+				},
+				BankMockOutcomes: func(bankMock *mock_bank.MockClient) {
+					bankMock.EXPECT().
+						Refund(gomock.Any(), model.Modification{RefID: refID, Amount: money.New(1550, "GBP")}).
+						Times(1).
+						Return("00", "refunded", nil)
+				},
+			},
+			want: &protos.RefundPaymentResponse{
+				Reference:                refID,
+				Status:                   protos.Status_REFUNDED,
+				StatusReason:             "refunded",
+				RefundedAmount:           20.5,
+				RefundedAmountMinorUnits: 2050,
+			},
+		},
+		{
+			name: "refund more than captured",
+			args: args{
+				request: &protos.RefundPaymentRequest{Ref: refID, Amount: 25},
+				storageMockOutcomes: func(storageMock *mock_storage.MockClient) {
+					storageMock.EXPECT().
+						GetPaymentInfo(gomock.Any(), "", refID).
+						Times(1).
+						Return(captured, nil)
+				},
+				BankMockOutcomes: func(bankMock *mock_bank.MockClient) {
+				},
+			},
+			err: fmt.Errorf("rpc error: code = InvalidArgument desc = invalid amount"),
+		},
+		{
+			name: "approved payment has not been captured",
+			args: args{
+				request: &protos.RefundPaymentRequest{Ref: refID},
+				storageMockOutcomes: func(storageMock *mock_storage.MockClient) {
+					storageMock.EXPECT().
+						GetPaymentInfo(gomock.Any(), "", refID).
+						Times(1).
+						Return(&protos.GetPaymentResponse{Ref: refID, Status: protos.Status_APPROVED}, nil)
+				},
+				BankMockOutcomes: func(bankMock *mock_bank.MockClient) {
+				},
+			},
+			err: fmt.Errorf("illegal status transition from APPROVED to REFUNDED"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.args.storageMockOutcomes(storageMock)
+			tt.args.BankMockOutcomes(bankMock)
+
+			s := New(storageMock, bankMock)
+
+			got, err := s.RefundPayment(context.Background(), tt.args.request)
+			if err != nil {
+				assert.Equal(t, tt.err.Error(), err.Error())
+
+				return
+			}
+
+			assert.Equal(t, tt.want.Reference, got.Reference)
+			assert.Equal(t, tt.want.Status, got.Status)
+			assert.Equal(t, tt.want.StatusReason, got.StatusReason)
+			assert.Equal(t, tt.want.RefundedAmount, got.RefundedAmount)
+			assert.Equal(t, tt.want.RefundedAmountMinorUnits, got.RefundedAmountMinorUnits)
+		})
+	}
+}
+
+func Test_server_GetPaymentHistory(t *testing.T) {
+	mockController := gomock.NewController(t)
+
+	storageMock := mock_storage.NewMockClient(mockController)
+
+	bankMock := mock_bank.NewMockClient(mockController)
+
+	defer mockController.Finish()
+
+	refID := "825ca1787c9d4672991848a5bfbc1057"
+
+	history := []*protos.PaymentStatusChange{
+		{
+			NewStatus: protos.Status_APPROVED,
+			Reason:    "approved and completed successfully",
+			Source:    protos.StatusSource_BANK,
+		},
+		{
+			OldStatus: protos.Status_APPROVED,
+			NewStatus: protos.Status_CAPTURED,
+			Reason:    "captured",
+			Source:    protos.StatusSource_OPERATOR,
+		},
+	}
+
+	tests := []struct {
+		name                string
+		request             *protos.GetPaymentHistoryRequest
+		storageMockOutcomes func(storageMock *mock_storage.MockClient)
+		want                *protos.GetPaymentHistoryResponse
+		err                 error
+	}{
+		{
+			name:    "successfully get payment history",
+			request: &protos.GetPaymentHistoryRequest{Ref: refID},
+			storageMockOutcomes: func(storageMock *mock_storage.MockClient) {
+				storageMock.EXPECT().
+					GetPaymentHistory(gomock.Any(), "", refID).
+					Times(1).
+					Return(history, nil)
+			},
+			want: &protos.GetPaymentHistoryResponse{Ref: refID, History: history},
+		},
+		{
+			name:    "payment does not exist",
+			request: &protos.GetPaymentHistoryRequest{Ref: refID},
+			storageMockOutcomes: func(storageMock *mock_storage.MockClient) {
+				storageMock.EXPECT().
+					GetPaymentHistory(gomock.Any(), "", refID).
+					Times(1).
+					Return([]*protos.PaymentStatusChange{}, nil)
+			},
+			err: fmt.Errorf("rpc error: code = NotFound desc = payment not found"),
+		},
+		{
+			name:                "missing ref",
+			request:             &protos.GetPaymentHistoryRequest{},
+			storageMockOutcomes: func(storageMock *mock_storage.MockClient) {},
+			err:                 fmt.Errorf("rpc error: code = InvalidArgument desc = missing parameter"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.storageMockOutcomes(storageMock)
+
+			s := New(storageMock, bankMock)
+
+			got, err := s.GetPaymentHistory(context.Background(), tt.request)
+			if err != nil {
+				assert.Equal(t, tt.err.Error(), err.Error())
+
+				return
+			}
+
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_server_ProcessPayment_Currency(t *testing.T) {
+	mockController := gomock.NewController(t)
+
+	storageMock := mock_storage.NewMockClient(mockController)
+
+	bankMock := mock_bank.NewMockClient(mockController)
+
+	defer mockController.Finish()
+
+	policy, err := currency.NewPolicy(map[string]currency.Rule{
+		"GBP": {MinAmount: 100, MaxAmount: 100000},
+		"JPY": {},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	newRequest := func(amount float64, code string) *protos.ProcessPaymentRequest {
+		return &protos.ProcessPaymentRequest{
+			BillingDetails: &protos.BillingDetails{
+				Name:    "Bruce",
+				Surname: "Wayne",
+			},
+			CardNumber:  "378282246310005",
+			Expiry:      "04/30",
+			Amount:      amount,
+			Currency:    code,
+			Cvv:         3421,
+			PaymentType: protos.PaymentType_CARD,
+			CardType:    protos.CardType_AMERICAN_EXPRESS,
+		}
+	}
+
+	approve := func(req *protos.ProcessPaymentRequest, amount money.Money) func() {
+		return func() {
+			bankMock.EXPECT().
+				Validate(gomock.Any(), model.ConvertToCardDetails(req)).
+				Times(1).
+				Return(true, nil)
+			bankMock.EXPECT().
+				Authorize(gomock.Any(), gomock.Any()).
+				Times(1).
+				Return("00", "approved and completed successfully", nil)
+			expectStored(storageMock, "", req, amount, model.Authorization{Acquirer: bank.DefaultAcquirer, Status: protos.Status_APPROVED, Reason: "approved and completed successfully", ResponseCode: "00"})
+		}
+	}
+
+	normalized := newRequest(20.5, "gbp ")
+	yen := newRequest(1500, "JPY")
+
+	tests := []struct {
+		name     string
+		request  *protos.ProcessPaymentRequest
+		outcomes func()
+		err      error
+	}{
+		{
+			name:     "currency is normalized",
+			request:  normalized,
+			outcomes: approve(normalized, money.New(2050, "GBP")),
+		},
+		{
+			name:     "zero decimal currency",
+			request:  yen,
+			outcomes: approve(yen, money.New(1500, "JPY")),
+		},
+		{
+			name:     "unknown currency",
+			request:  newRequest(20.5, "XYZ"),
+			outcomes: func() {},
+			err:      fmt.Errorf(`rpc error: code = InvalidArgument desc = unknown currency: "XYZ"`),
+		},
+		{
+			name:     "currency not accepted",
+			request:  newRequest(20.5, "USD"),
+			outcomes: func() {},
+			err:      fmt.Errorf("rpc error: code = InvalidArgument desc = currency is not accepted: USD"),
+		},
+		{
+			name:     "amount above the currency maximum",
+			request:  newRequest(1000.01, "GBP"),
+			outcomes: func() {},
+			err:      fmt.Errorf("rpc error: code = InvalidArgument desc = amount is above the maximum of 100000 GBP minor units"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.outcomes()
+
+			s := New(storageMock, bankMock, WithCurrencyPolicy(policy))
+
+			_, err := s.ProcessPayment(context.Background(), tt.request)
+			if tt.err != nil {
+				assert.EqualError(t, err, tt.err.Error())
+
+				return
+			}
+
+			assert.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,109 @@
+package server
+
+import (
+	"errors"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+
+	"payments_gateway/card"
+	"payments_gateway/errcodes"
+	protos "payments_gateway/protos"
+	"payments_gateway/vault"
+)
+
+// field is a request field that must be set, name is the field name in the request
+type field struct {
+	name string
+	set  bool
+}
+
+// missingParameters returns an InvalidArgument error naming every field that is not set,
+// or nil when all of them are
+func missingParameters(fields ...field) error {
+	var violations []*errdetails.BadRequest_FieldViolation
+
+	for _, f := range fields {
+		if !f.set {
+			violations = append(violations, errcodes.FieldViolation(f.name, "must be set"))
+		}
+	}
+
+	if len(violations) == 0 {
+		return nil
+	}
+
+	return errcodes.Error(codes.InvalidArgument, errcodes.MissingParameter, "missing parameter", violations...)
+}
+
+// validatePaymentRequest checks the fields required to process a payment are set. Payments
+// made with a card token must not also set the card details stored with the token
+func validatePaymentRequest(request *protos.ProcessPaymentRequest) error {
+	if request.GetCardToken() == "" {
+		return missingParameters(
+			field{name: "currency", set: request.GetCurrency() != ""},
+			field{name: "card_number", set: request.GetCardNumber() != ""},
+			field{name: "cvv", set: request.GetCvv() != 0},
+			field{name: "expiry", set: request.GetExpiry() != ""},
+		)
+	}
+
+	if err := missingParameters(field{name: "currency", set: request.GetCurrency() != ""}); err != nil {
+		return err
+	}
+
+	var violations []*errdetails.BadRequest_FieldViolation
+
+	if !vault.IsToken(request.GetCardToken()) {
+		violations = append(violations, errcodes.FieldViolation("card_token", "is not a card token"))
+	}
+
+	if request.GetCardNumber() != "" {
+		violations = append(violations, errcodes.FieldViolation("card_number", "must not be set with a card_token"))
+	}
+
+	if request.GetExpiry() != "" {
+		violations = append(violations, errcodes.FieldViolation("expiry", "must not be set with a card_token"))
+	}
+
+	if len(violations) > 0 {
+		return errcodes.Error(codes.InvalidArgument, errcodes.InvalidCardDetails, "invalid card details", violations...)
+	}
+
+	return nil
+}
+
+// validateRef checks the payment reference of a request is set
+func validateRef(ref string) error {
+	return missingParameters(field{name: "ref", set: ref != ""})
+}
+
+// invalidAmount returns an InvalidArgument error for the amount field of a request
+func invalidAmount(field, description string) error {
+	return errcodes.Error(codes.InvalidArgument, errcodes.InvalidAmount, "invalid amount", errcodes.FieldViolation(field, description))
+}
+
+// amountField is the name of the field the amount of a request was taken from
+func amountField(minorUnits int64) string {
+	if minorUnits != 0 {
+		return "amount_minor_units"
+	}
+
+	return "amount"
+}
+
+// invalidCard converts the error returned by card.Validate into an InvalidArgument error
+// with a field violation for every invalid field
+func invalidCard(err error) error {
+	var fieldErrs card.Errors
+	if !errors.As(err, &fieldErrs) {
+		return errcodes.Error(codes.InvalidArgument, errcodes.InvalidCardDetails, err.Error())
+	}
+
+	violations := make([]*errdetails.BadRequest_FieldViolation, 0, len(fieldErrs))
+	for _, fieldErr := range fieldErrs {
+		violations = append(violations, errcodes.FieldViolation(fieldErr.Field, fieldErr.Description))
+	}
+
+	return errcodes.Error(codes.InvalidArgument, errcodes.InvalidCardDetails, err.Error(), violations...)
+}
@@ -0,0 +1,140 @@
+package server
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"payments_gateway/aquiring-bank/mocks"
+	"payments_gateway/currency"
+	"payments_gateway/errcodes"
+	protos "payments_gateway/protos"
+	"payments_gateway/storage/mocks"
+)
+
+func Test_server_ProcessPayment_FieldViolations(t *testing.T) {
+	mockController := gomock.NewController(t)
+
+	storageMock := mock_storage.NewMockClient(mockController)
+
+	bankMock := mock_bank.NewMockClient(mockController)
+
+	defer mockController.Finish()
+
+	policy, err := currency.NewPolicy(map[string]currency.Rule{
+		"GBP": {MinAmount: 100, MaxAmount: 100000},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	newRequest := func(change func(r *protos.ProcessPaymentRequest)) *protos.ProcessPaymentRequest {
+		r := &protos.ProcessPaymentRequest{
+			BillingDetails: &protos.BillingDetails{
+				Name:    "Bruce",
+				Surname: "Wayne",
+			},
+			CardNumber:  "378282246310005",
+			Expiry:      "04/30",
+			Amount:      20.5,
+			Currency:    "GBP",
+			Cvv:         3421,
+			PaymentType: protos.PaymentType_CARD,
+			CardType:    protos.CardType_AMERICAN_EXPRESS,
+		}
+		change(r)
+
+		return r
+	}
+
+	tests := []struct {
+		name       string
+		request    *protos.ProcessPaymentRequest
+		code       errcodes.Code
+		violations map[string]string
+	}{
+		{
+			name: "every missing field is named",
+			request: newRequest(func(r *protos.ProcessPaymentRequest) {
+				r.CardNumber = ""
+				r.Cvv = 0
+			}),
+			code: errcodes.MissingParameter,
+			violations: map[string]string{
+				"card_number": "must be set",
+				"cvv":         "must be set",
+			},
+		},
+		{
+			name:       "missing amount",
+			request:    newRequest(func(r *protos.ProcessPaymentRequest) { r.Amount = 0 }),
+			code:       errcodes.MissingParameter,
+			violations: map[string]string{"amount": "must be set"},
+		},
+		{
+			name:       "amount in minor units out of range",
+			request:    newRequest(func(r *protos.ProcessPaymentRequest) { r.AmountMinorUnits = 50 }),
+			code:       errcodes.AmountOutOfRange,
+			violations: map[string]string{"amount_minor_units": "amount is below the minimum of 100 GBP minor units"},
+		},
+		{
+			name:       "unknown currency",
+			request:    newRequest(func(r *protos.ProcessPaymentRequest) { r.Currency = "ABC" }),
+			code:       errcodes.UnsupportedCurrency,
+			violations: map[string]string{"currency": `unknown currency: "ABC"`},
+		},
+		{
+			name:       "currency not accepted",
+			request:    newRequest(func(r *protos.ProcessPaymentRequest) { r.Currency = "EUR" }),
+			code:       errcodes.CurrencyNotAccepted,
+			violations: map[string]string{"currency": "currency is not accepted: EUR"},
+		},
+		{
+			name: "invalid card details",
+			request: newRequest(func(r *protos.ProcessPaymentRequest) {
+				r.CardType = protos.CardType_VISA
+				r.Expiry = "1/30"
+			}),
+			code: errcodes.InvalidCardDetails,
+			violations: map[string]string{
+				"card_type": "card number belongs to AMERICAN_EXPRESS",
+				"expiry":    "must be formatted as MM/YY",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := New(storageMock, bankMock, WithCurrencyPolicy(policy))
+
+			_, err := s.ProcessPayment(context.Background(), tt.request)
+
+			assert.Equal(t, codes.InvalidArgument, status.Code(err))
+			assert.Equal(t, tt.code, errcodes.FromError(err))
+
+			violations := map[string]string{}
+			for _, v := range errcodes.FieldViolations(err) {
+				violations[v.GetField()] = v.GetDescription()
+			}
+
+			assert.Equal(t, tt.violations, violations)
+		})
+	}
+}
+
+func Test_server_GetPayment_MissingRef(t *testing.T) {
+	s := New(nil, nil)
+
+	_, err := s.GetPayment(context.Background(), &protos.GetPaymentRequest{})
+
+	assert.Equal(t, "rpc error: code = InvalidArgument desc = missing parameter", err.Error())
+	assert.Equal(t, errcodes.MissingParameter, errcodes.FromError(err))
+
+	violations := errcodes.FieldViolations(err)
+	if assert.Len(t, violations, 1) {
+		assert.Equal(t, "ref", violations[0].GetField())
+	}
+}
@@ -0,0 +1,162 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/proto"
+
+	"payments_gateway/auth"
+	"payments_gateway/card"
+	"payments_gateway/errcodes"
+	"payments_gateway/model"
+	protos "payments_gateway/protos"
+	"payments_gateway/vault"
+)
+
+var (
+	_errVaultNotConfigured = errcodes.Error(codes.FailedPrecondition, errcodes.VaultNotConfigured, "card vault is not configured")
+	_errCardTokenNotFound  = errcodes.Error(codes.NotFound, errcodes.CardTokenNotFound, "card token not found")
+	_errTokenizingCard     = errcodes.Error(codes.Internal, errcodes.Internal, "error tokenizing card")
+	_errDetokenizingCard   = errcodes.Error(codes.Internal, errcodes.Internal, "error detokenizing card")
+	_errDeletingToken      = errcodes.Error(codes.Internal, errcodes.Internal, "error deleting card token")
+)
+
+// WithVault enables TokenizeCard, DeleteToken and payments made with a card token
+func WithVault(v *vault.Vault) Option {
+	return func(s *server) {
+		s.vault = v
+	}
+}
+
+// TokenizeCard stores a card in the vault and returns a token that can be charged in its place.
+// The CVV is never stored, so it has to be sent with every payment that needs it
+func (s *server) TokenizeCard(ctx context.Context, request *protos.TokenizeCardRequest) (*protos.TokenizeCardResponse, error) {
+	if s.vault == nil {
+		return nil, _errVaultNotConfigured
+	}
+
+	if err := missingParameters(
+		field{name: "card_number", set: request.GetCardNumber() != ""},
+		field{name: "expiry", set: request.GetExpiry() != ""},
+	); err != nil {
+		log.WithField("request", request).Warn("request contains invalid parameters")
+
+		return nil, err
+	}
+
+	cardDetails := model.Card{
+		Name:     request.GetBillingDetails().GetName(),
+		Surname:  request.GetBillingDetails().GetSurname(),
+		Postcode: request.GetBillingDetails().GetPostcode(),
+		CardType: request.GetCardType().String(),
+		CardNum:  request.GetCardNumber(),
+		Expiry:   request.GetExpiry(),
+	}
+
+	if err := card.Validate(cardDetails, time.Now()); err != nil {
+		log.WithField("card_type", cardDetails.CardType).WithError(err).Warn("request contains invalid card details")
+
+		return nil, invalidCard(err)
+	}
+
+	vaulted, err := s.vault.Tokenize(ctx, auth.MerchantID(ctx), cardDetails)
+	if err != nil {
+		log.WithError(err).Error("tokenizing card")
+
+		return nil, _errTokenizingCard
+	}
+
+	return &protos.TokenizeCardResponse{
+		CardToken:        vaulted.Token,
+		MaskedCardNumber: vaulted.MaskedCardNumber,
+		CardType:         request.GetCardType(),
+		Expiry:           vaulted.Expiry,
+	}, nil
+}
+
+// DeleteToken removes a card from the vault, payments made with the token are kept
+func (s *server) DeleteToken(ctx context.Context, request *protos.DeleteTokenRequest) (*protos.DeleteTokenResponse, error) {
+	if s.vault == nil {
+		return nil, _errVaultNotConfigured
+	}
+
+	if err := missingParameters(field{name: "card_token", set: request.GetCardToken() != ""}); err != nil {
+		return nil, err
+	}
+
+	if err := s.vault.Delete(ctx, auth.MerchantID(ctx), request.GetCardToken()); err != nil {
+		if errors.Is(err, vault.ErrTokenNotFound) {
+			return nil, _errCardTokenNotFound
+		}
+
+		log.WithField("card_token", request.GetCardToken()).WithError(err).Error("deleting card token")
+
+		return nil, _errDeletingToken
+	}
+
+	return &protos.DeleteTokenResponse{CardToken: request.GetCardToken()}, nil
+}
+
+// cardDetails returns the card a payment is made with, decrypting it from the vault when
+// the payment is made with a card token
+func (s *server) cardDetails(ctx context.Context, request *protos.ProcessPaymentRequest) (model.Card, error) {
+	if request.GetCardToken() == "" {
+		return model.ConvertToCardDetails(request), nil
+	}
+
+	if s.vault == nil {
+		return model.Card{}, _errVaultNotConfigured
+	}
+
+	cardDetails, err := s.vault.Detokenize(ctx, auth.MerchantID(ctx), request.GetCardToken())
+	if err != nil {
+		if errors.Is(err, vault.ErrTokenNotFound) {
+			return model.Card{}, _errCardTokenNotFound
+		}
+
+		log.WithField("card_token", request.GetCardToken()).WithError(err).Error("detokenizing card")
+
+		return model.Card{}, _errDetokenizingCard
+	}
+
+	cardDetails.Cvv = request.GetCvv()
+
+	return cardDetails, nil
+}
+
+// paymentRecord is the request stored for a payment. Payments made with a card token are
+// stored with the card details kept with the token, the storage masks the card number like
+// the number of any other payment
+func paymentRecord(request *protos.ProcessPaymentRequest, cardDetails model.Card) *protos.ProcessPaymentRequest {
+	if request.GetCardToken() == "" {
+		return request
+	}
+
+	record := proto.Clone(request).(*protos.ProcessPaymentRequest)
+	record.CardNumber = cardDetails.CardNum
+	record.Expiry = cardDetails.Expiry
+	record.CardType = protos.CardType(protos.CardType_value[cardDetails.CardType])
+	record.Cvv = 0
+
+	if record.BillingDetails == nil {
+		record.BillingDetails = &protos.BillingDetails{}
+	}
+
+	if record.BillingDetails.Name == "" {
+		record.BillingDetails.Name = cardDetails.Name
+	}
+
+	if record.BillingDetails.Surname == "" {
+		record.BillingDetails.Surname = cardDetails.Surname
+	}
+
+	if record.BillingDetails.Postcode == "" {
+		record.BillingDetails.Postcode = cardDetails.Postcode
+	}
+
+	return record
+}
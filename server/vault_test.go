@@ -0,0 +1,272 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	bank "payments_gateway/aquiring-bank"
+	"payments_gateway/aquiring-bank/mocks"
+	"payments_gateway/model"
+	"payments_gateway/money"
+	protos "payments_gateway/protos"
+	"payments_gateway/storage/mocks"
+	"payments_gateway/vault"
+)
+
+func Test_server_TokenizeCard(t *testing.T) {
+	mockController := gomock.NewController(t)
+
+	storageMock := mock_storage.NewMockClient(mockController)
+
+	bankMock := mock_bank.NewMockClient(mockController)
+
+	defer mockController.Finish()
+
+	v, err := vault.New(storageMock, bytes.Repeat([]byte{1}, 32))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	req := &protos.TokenizeCardRequest{
+		BillingDetails: &protos.BillingDetails{Name: "Bruce", Surname: "Wayne"},
+		CardNumber:     "378282246310005",
+		Expiry:         "04/30",
+		CardType:       protos.CardType_AMERICAN_EXPRESS,
+	}
+
+	tests := []struct {
+		name                string
+		request             *protos.TokenizeCardRequest
+		vault               *vault.Vault
+		storageMockOutcomes func(storageMock *mock_storage.MockClient)
+		want                *protos.TokenizeCardResponse
+		err                 error
+	}{
+		{
+			name:    "card is stored encrypted",
+			request: req,
+			vault:   v,
+			storageMockOutcomes: func(storageMock *mock_storage.MockClient) {
+				storageMock.EXPECT().
+					AddVaultedCard(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil)
+			},
+			want: &protos.TokenizeCardResponse{
+				MaskedCardNumber: "3782XXXXXXX0005",
+				CardType:         protos.CardType_AMERICAN_EXPRESS,
+				Expiry:           "04/30",
+			},
+		},
+		{
+			name: "invalid card is not stored",
+			request: &protos.TokenizeCardRequest{
+				CardNumber: "378282246310006",
+				Expiry:     "04/30",
+				CardType:   protos.CardType_AMERICAN_EXPRESS,
+			},
+			vault:               v,
+			storageMockOutcomes: func(storageMock *mock_storage.MockClient) {},
+			err:                 fmt.Errorf("rpc error: code = InvalidArgument desc = card_number: failed the Luhn check"),
+		},
+		{
+			name:                "missing card number",
+			request:             &protos.TokenizeCardRequest{Expiry: "04/30"},
+			vault:               v,
+			storageMockOutcomes: func(storageMock *mock_storage.MockClient) {},
+			err:                 fmt.Errorf("rpc error: code = InvalidArgument desc = missing parameter"),
+		},
+		{
+			name:                "vault is not configured",
+			request:             req,
+			storageMockOutcomes: func(storageMock *mock_storage.MockClient) {},
+			err:                 fmt.Errorf("rpc error: code = FailedPrecondition desc = card vault is not configured"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.storageMockOutcomes(storageMock)
+
+			s := New(storageMock, bankMock, WithVault(tt.vault))
+
+			got, err := s.TokenizeCard(context.Background(), tt.request)
+			if tt.err != nil {
+				assert.Equal(t, tt.err.Error(), err.Error())
+
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.True(t, vault.IsToken(got.GetCardToken()))
+			assert.Equal(t, tt.want.GetMaskedCardNumber(), got.GetMaskedCardNumber())
+			assert.Equal(t, tt.want.GetCardType(), got.GetCardType())
+			assert.Equal(t, tt.want.GetExpiry(), got.GetExpiry())
+		})
+	}
+}
+
+func Test_server_DeleteToken(t *testing.T) {
+	mockController := gomock.NewController(t)
+
+	storageMock := mock_storage.NewMockClient(mockController)
+
+	bankMock := mock_bank.NewMockClient(mockController)
+
+	defer mockController.Finish()
+
+	v, err := vault.New(storageMock, bytes.Repeat([]byte{1}, 32))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	storageMock.EXPECT().DeleteVaultedCard(gomock.Any(), "", "tok_1").Times(1).Return(true, nil)
+	storageMock.EXPECT().DeleteVaultedCard(gomock.Any(), "", "tok_2").Times(1).Return(false, nil)
+
+	s := New(storageMock, bankMock, WithVault(v))
+
+	got, err := s.DeleteToken(context.Background(), &protos.DeleteTokenRequest{CardToken: "tok_1"})
+	assert.NoError(t, err)
+	assert.Equal(t, "tok_1", got.GetCardToken())
+
+	_, err = s.DeleteToken(context.Background(), &protos.DeleteTokenRequest{CardToken: "tok_2"})
+	assert.Equal(t, "rpc error: code = NotFound desc = card token not found", err.Error())
+}
+
+func Test_server_ProcessPayment_CardToken(t *testing.T) {
+	mockController := gomock.NewController(t)
+
+	storageMock := mock_storage.NewMockClient(mockController)
+
+	bankMock := mock_bank.NewMockClient(mockController)
+
+	defer mockController.Finish()
+
+	v, err := vault.New(storageMock, bytes.Repeat([]byte{1}, 32))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var stored model.VaultedCard
+
+	storageMock.EXPECT().
+		AddVaultedCard(gomock.Any(), gomock.Any()).
+		Times(1).
+		DoAndReturn(func(_ context.Context, c model.VaultedCard) error {
+			stored = c
+
+			return nil
+		})
+
+	cardDetails := model.Card{
+		Name:     "Bruce",
+		Surname:  "Wayne",
+		CardType: protos.CardType_AMERICAN_EXPRESS.String(),
+		CardNum:  "378282246310005",
+		Expiry:   "04/30",
+	}
+
+	if _, err := v.Tokenize(context.Background(), "", cardDetails); err != nil {
+		t.Fatal(err)
+	}
+
+	req := &protos.ProcessPaymentRequest{
+		CardToken:   stored.Token,
+		Amount:      20.5,
+		Currency:    "GBP",
+		Cvv:         3421,
+		PaymentType: protos.PaymentType_CARD,
+	}
+
+	// the bank receives the decrypted card and the CVV of the request
+	charged := cardDetails
+	charged.Cvv = 3421
+
+	// the record carries the decrypted card number, the storage masks it
+	record := &protos.ProcessPaymentRequest{
+		BillingDetails: &protos.BillingDetails{Name: "Bruce", Surname: "Wayne"},
+		CardNumber:     "378282246310005",
+		Expiry:         "04/30",
+		CardToken:      stored.Token,
+		Amount:         20.5,
+		Currency:       "GBP",
+		PaymentType:    protos.PaymentType_CARD,
+		CardType:       protos.CardType_AMERICAN_EXPRESS,
+	}
+
+	tests := []struct {
+		name     string
+		request  *protos.ProcessPaymentRequest
+		outcomes func()
+		want     protos.Status
+		err      error
+	}{
+		{
+			name:    "payment made with a card token",
+			request: req,
+			outcomes: func() {
+				storageMock.EXPECT().
+					GetVaultedCard(gomock.Any(), "", stored.Token).
+					Times(1).
+					Return(&stored, nil)
+				bankMock.EXPECT().
+					Validate(gomock.Any(), charged).
+					Times(1).
+					Return(true, nil)
+				bankMock.EXPECT().
+					Authorize(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return("00", "approved and completed successfully", nil)
+				expectStored(storageMock, "", record, money.New(2050, "GBP"), model.Authorization{Acquirer: bank.DefaultAcquirer, Status: protos.Status_APPROVED, Reason: "approved and completed successfully", ResponseCode: "00"})
+			},
+			want: protos.Status_APPROVED,
+		},
+		{
+			name: "unknown card token",
+			request: &protos.ProcessPaymentRequest{
+				CardToken: "tok_unknown",
+				Amount:    20.5,
+				Currency:  "GBP",
+			},
+			outcomes: func() {
+				storageMock.EXPECT().
+					GetVaultedCard(gomock.Any(), "", "tok_unknown").
+					Times(1).
+					Return(nil, nil)
+			},
+			err: fmt.Errorf("rpc error: code = NotFound desc = card token not found"),
+		},
+		{
+			name: "card token with card details",
+			request: &protos.ProcessPaymentRequest{
+				CardToken:  stored.Token,
+				CardNumber: "378282246310005",
+				Amount:     20.5,
+				Currency:   "GBP",
+			},
+			outcomes: func() {},
+			err:      fmt.Errorf("rpc error: code = InvalidArgument desc = invalid card details"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.outcomes()
+
+			s := New(storageMock, bankMock, WithVault(v))
+
+			got, err := s.ProcessPayment(context.Background(), tt.request)
+			if tt.err != nil {
+				assert.Equal(t, tt.err.Error(), err.Error())
+
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got.GetStatus())
+		})
+	}
+}
@@ -0,0 +1,159 @@
+package server
+
+import (
+	"context"
+
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+
+	"payments_gateway/auth"
+	"payments_gateway/errcodes"
+	"payments_gateway/model"
+	protos "payments_gateway/protos"
+	"payments_gateway/watch"
+)
+
+var (
+	_errWatchNotConfigured = errcodes.Error(codes.Unimplemented, errcodes.WatchUnavailable, "watching payments is not configured")
+	_errWatchUnavailable   = errcodes.Error(codes.Unavailable, errcodes.WatchUnavailable, "payment status changes cannot be watched")
+	_errWatchInterrupted   = errcodes.Error(codes.Unavailable, errcodes.WatchUnavailable, "watch was interrupted")
+)
+
+// WithWatchHub enables WatchPayment and WatchPayments, the hub must be running
+func WithWatchHub(hub *watch.Hub) Option {
+	return func(s *server) {
+		s.watchHub = hub
+	}
+}
+
+// WatchPayment sends the latest status change of a payment of the merchant, then every change to
+// its status as it is committed by any gateway, until the client cancels the call
+func (s *server) WatchPayment(request *protos.WatchPaymentRequest, stream protos.Payments_WatchPaymentServer) error {
+	ctx := stream.Context()
+
+	if s.watchHub == nil {
+		return _errWatchNotConfigured
+	}
+
+	if err := validateRef(request.GetRef()); err != nil {
+		log.WithField("request", request).Warn("request contains invalid parameters")
+
+		return err
+	}
+
+	merchantID := auth.MerchantID(ctx)
+
+	// the watch starts before the latest change is read so no change is missed in between
+	watcher, err := s.watchHub.Watch(func(event *model.StatusChangeEvent) bool {
+		return event.RefID == request.GetRef() && event.MerchantID == merchantID
+	})
+	if err != nil {
+		log.WithField("ref", request.GetRef()).WithError(err).Error("watching payment")
+
+		return _errWatchUnavailable
+	}
+
+	defer watcher.Close()
+
+	latest, err := s.dbClient.GetLatestStatusChange(ctx, merchantID, request.GetRef())
+	if err != nil {
+		log.WithField("ref", request.GetRef()).WithError(err).Error("getting latest payment status change")
+
+		return _errGettingPaymentHistory
+	}
+
+	if latest == nil {
+		return _errPaymentNotFound
+	}
+
+	if err := stream.Send(statusEvent(latest)); err != nil {
+		return err
+	}
+
+	return streamStatusChanges(ctx, watcher, latest.ID, stream.Send)
+}
+
+// WatchPayments sends every status change of the payments of the merchant matching the filters of
+// the request as it is committed by any gateway, until the client cancels the call
+func (s *server) WatchPayments(request *protos.WatchPaymentsRequest, stream protos.Payments_WatchPaymentsServer) error {
+	ctx := stream.Context()
+
+	if s.watchHub == nil {
+		return _errWatchNotConfigured
+	}
+
+	if err := validateWatchFilter(request); err != nil {
+		log.WithField("request", request).Warn("request contains invalid filters")
+
+		return err
+	}
+
+	merchantID := auth.MerchantID(ctx)
+
+	statuses := make(map[protos.Status]bool, len(request.GetStatuses()))
+	for _, status := range request.GetStatuses() {
+		statuses[status] = true
+	}
+
+	watcher, err := s.watchHub.Watch(func(event *model.StatusChangeEvent) bool {
+		return event.MerchantID == merchantID &&
+			(len(statuses) == 0 || statuses[event.Change.GetNewStatus()]) &&
+			(request.GetMerchantReference() == "" || event.MerchantReference == request.GetMerchantReference())
+	})
+	if err != nil {
+		log.WithError(err).Error("watching payments")
+
+		return _errWatchUnavailable
+	}
+
+	defer watcher.Close()
+
+	return streamStatusChanges(ctx, watcher, 0, stream.Send)
+}
+
+// streamStatusChanges sends the changes of a watcher after the change with the ID after until the
+// client cancels the call, or the watcher is stopped and the client has to watch again
+func streamStatusChanges(ctx context.Context, watcher *watch.Watcher, after int64, send func(*protos.PaymentStatusEvent) error) error {
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case event, ok := <-watcher.Events():
+			if !ok {
+				log.WithError(watcher.Err()).Warn("payment watch stopped")
+
+				return _errWatchInterrupted
+			}
+
+			// changes committed before the latest change was read may be sent by the hub as well
+			if event.ID <= after {
+				continue
+			}
+
+			if err := send(statusEvent(event)); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// validateWatchFilter returns an InvalidArgument error when a status filter is not a payment status
+func validateWatchFilter(request *protos.WatchPaymentsRequest) error {
+	for _, status := range request.GetStatuses() {
+		if _, ok := protos.Status_name[int32(status)]; !ok || status == protos.Status_UNKNOWN {
+			return errcodes.Error(codes.InvalidArgument, errcodes.InvalidFilter, "invalid filter",
+				errcodes.FieldViolation("statuses", "is not a payment status"))
+		}
+	}
+
+	return nil
+}
+
+func statusEvent(event *model.StatusChangeEvent) *protos.PaymentStatusEvent {
+	return &protos.PaymentStatusEvent{
+		Ref:               event.RefID,
+		MerchantReference: event.MerchantReference,
+		Change:            event.Change,
+		Sequence:          event.ID,
+	}
+}
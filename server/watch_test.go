@@ -0,0 +1,289 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+
+	"payments_gateway/aquiring-bank/mocks"
+	"payments_gateway/auth"
+	"payments_gateway/model"
+	protos "payments_gateway/protos"
+	"payments_gateway/storage/mocks"
+	"payments_gateway/watch"
+)
+
+// fakeWatchStream is the server stream of a watch call, the events sent on it are buffered in sent
+type fakeWatchStream struct {
+	grpc.ServerStream
+	ctx  context.Context
+	sent chan *protos.PaymentStatusEvent
+}
+
+func newFakeWatchStream(ctx context.Context) *fakeWatchStream {
+	return &fakeWatchStream{ctx: ctx, sent: make(chan *protos.PaymentStatusEvent, 10)}
+}
+
+func (f *fakeWatchStream) Context() context.Context {
+	return f.ctx
+}
+
+func (f *fakeWatchStream) Send(event *protos.PaymentStatusEvent) error {
+	f.sent <- event
+
+	return nil
+}
+
+// startWatchHub runs a hub listening to the changes sent to changes until the listener is sent an error.
+// The hub is stopped when the test ends, before the cleanup of the mock controller checks the listener
+// was closed, so tests using it must not call Finish themselves
+func startWatchHub(t *testing.T, mockController *gomock.Controller, storageMock *mock_storage.MockClient) (*watch.Hub, chan<- *model.StatusChangeEvent, chan<- error) {
+	changes := make(chan *model.StatusChangeEvent)
+	fail := make(chan error)
+
+	listener := mock_storage.NewMockStatusListener(mockController)
+
+	listener.EXPECT().Next(gomock.Any()).AnyTimes().
+		DoAndReturn(func(ctx context.Context) (*model.StatusChangeEvent, error) {
+			select {
+			case event := <-changes:
+				return event, nil
+			case err := <-fail:
+				return nil, err
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			}
+		})
+	listener.EXPECT().Close().Times(1)
+
+	storageMock.EXPECT().ListenStatusChanges(gomock.Any()).Return(listener, nil)
+
+	hub := watch.NewHub(storageMock, watch.Options{Buffer: 10, RetryWait: time.Hour})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+
+	go func() {
+		hub.Run(ctx)
+		close(done)
+	}()
+
+	t.Cleanup(func() {
+		cancel()
+		<-done
+	})
+
+	assert.Eventually(t, hub.Listening, time.Second, time.Millisecond)
+
+	return hub, changes, fail
+}
+
+func statusChange(id int64, merchantID, refID, reference string, oldStatus, newStatus protos.Status) *model.StatusChangeEvent {
+	return &model.StatusChangeEvent{
+		ID:                id,
+		RefID:             refID,
+		MerchantID:        merchantID,
+		MerchantReference: reference,
+		Change: &protos.PaymentStatusChange{
+			OldStatus: oldStatus,
+			NewStatus: newStatus,
+			Source:    protos.StatusSource_BANK,
+		},
+	}
+}
+
+func Test_server_WatchPayment(t *testing.T) {
+	mockController := gomock.NewController(t)
+
+	storageMock := mock_storage.NewMockClient(mockController)
+
+	bankMock := mock_bank.NewMockClient(mockController)
+
+	hub, changes, _ := startWatchHub(t, mockController, storageMock)
+
+	ctx, cancel := context.WithCancel(auth.NewContext(context.Background(), &model.Merchant{ID: "merchant-1"}))
+	defer cancel()
+
+	storageMock.EXPECT().GetLatestStatusChange(gomock.Any(), "merchant-1", "ref1").
+		DoAndReturn(func(context.Context, string, string) (*model.StatusChangeEvent, error) {
+			// committed after the watch started, it is both the latest change and sent by the hub
+			changes <- statusChange(2, "merchant-1", "ref1", "order-1", protos.Status_UNKNOWN, protos.Status_PENDING)
+
+			return statusChange(2, "merchant-1", "ref1", "order-1", protos.Status_UNKNOWN, protos.Status_PENDING), nil
+		})
+
+	stream := newFakeWatchStream(ctx)
+	errs := make(chan error)
+
+	go func() {
+		errs <- New(storageMock, bankMock, WithWatchHub(hub)).WatchPayment(&protos.WatchPaymentRequest{Ref: "ref1"}, stream)
+	}()
+
+	assert.Equal(t, int64(2), (<-stream.sent).GetSequence())
+
+	changes <- statusChange(3, "merchant-1", "ref2", "order-2", protos.Status_UNKNOWN, protos.Status_APPROVED)
+	changes <- statusChange(3, "merchant-2", "ref1", "order-1", protos.Status_PENDING, protos.Status_APPROVED)
+	changes <- statusChange(4, "merchant-1", "ref1", "order-1", protos.Status_PENDING, protos.Status_APPROVED)
+
+	got := <-stream.sent
+
+	assert.Equal(t, (&protos.PaymentStatusEvent{
+		Ref:               "ref1",
+		MerchantReference: "order-1",
+		Change: &protos.PaymentStatusChange{
+			OldStatus: protos.Status_PENDING,
+			NewStatus: protos.Status_APPROVED,
+			Source:    protos.StatusSource_BANK,
+		},
+		Sequence: 4,
+	}).String(), got.String())
+
+	cancel()
+
+	assert.NoError(t, <-errs)
+	assert.Empty(t, stream.sent)
+	assert.Eventually(t, func() bool { return hub.Watchers() == 0 }, time.Second, time.Millisecond)
+}
+
+func Test_server_WatchPayment_Errors(t *testing.T) {
+	mockController := gomock.NewController(t)
+
+	storageMock := mock_storage.NewMockClient(mockController)
+
+	bankMock := mock_bank.NewMockClient(mockController)
+
+	ctx := auth.NewContext(context.Background(), &model.Merchant{ID: "merchant-1"})
+
+	hub, _, _ := startWatchHub(t, mockController, storageMock)
+
+	tests := []struct {
+		name                string
+		request             *protos.WatchPaymentRequest
+		hub                 *watch.Hub
+		storageMockOutcomes func(storageMock *mock_storage.MockClient)
+		err                 error
+	}{
+		{
+			name:    "watching not configured",
+			request: &protos.WatchPaymentRequest{Ref: "ref1"},
+			err:     fmt.Errorf("rpc error: code = Unimplemented desc = watching payments is not configured"),
+		},
+		{
+			name:    "missing ref",
+			request: &protos.WatchPaymentRequest{},
+			hub:     hub,
+			err:     fmt.Errorf("rpc error: code = InvalidArgument desc = missing parameter"),
+		},
+		{
+			name:    "hub not listening",
+			request: &protos.WatchPaymentRequest{Ref: "ref1"},
+			hub:     watch.NewHub(storageMock, watch.DefaultOptions()),
+			err:     fmt.Errorf("rpc error: code = Unavailable desc = payment status changes cannot be watched"),
+		},
+		{
+			name:    "payment not found",
+			request: &protos.WatchPaymentRequest{Ref: "ref1"},
+			hub:     hub,
+			storageMockOutcomes: func(storageMock *mock_storage.MockClient) {
+				storageMock.EXPECT().GetLatestStatusChange(gomock.Any(), "merchant-1", "ref1").Return(nil, nil)
+			},
+			err: fmt.Errorf("rpc error: code = NotFound desc = payment not found"),
+		},
+		{
+			name:    "storage error",
+			request: &protos.WatchPaymentRequest{Ref: "ref1"},
+			hub:     hub,
+			storageMockOutcomes: func(storageMock *mock_storage.MockClient) {
+				storageMock.EXPECT().GetLatestStatusChange(gomock.Any(), "merchant-1", "ref1").Return(nil, errors.New("connection refused"))
+			},
+			err: fmt.Errorf("rpc error: code = Internal desc = error getting payment history"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.storageMockOutcomes != nil {
+				tt.storageMockOutcomes(storageMock)
+			}
+
+			s := New(storageMock, bankMock)
+			if tt.hub != nil {
+				s = New(storageMock, bankMock, WithWatchHub(tt.hub))
+			}
+
+			err := s.WatchPayment(tt.request, newFakeWatchStream(ctx))
+
+			assert.EqualError(t, err, tt.err.Error())
+			assert.Equal(t, 0, hub.Watchers())
+		})
+	}
+}
+
+func Test_server_WatchPayments(t *testing.T) {
+	mockController := gomock.NewController(t)
+
+	storageMock := mock_storage.NewMockClient(mockController)
+
+	bankMock := mock_bank.NewMockClient(mockController)
+
+	hub, changes, fail := startWatchHub(t, mockController, storageMock)
+
+	ctx := auth.NewContext(context.Background(), &model.Merchant{ID: "merchant-1"})
+
+	stream := newFakeWatchStream(ctx)
+	errs := make(chan error)
+
+	request := &protos.WatchPaymentsRequest{
+		Statuses:          []protos.Status{protos.Status_CAPTURED, protos.Status_REFUNDED},
+		MerchantReference: "order-1",
+	}
+
+	go func() {
+		errs <- New(storageMock, bankMock, WithWatchHub(hub)).WatchPayments(request, stream)
+	}()
+
+	assert.Eventually(t, func() bool { return hub.Watchers() == 1 }, time.Second, time.Millisecond)
+
+	changes <- statusChange(1, "merchant-1", "ref1", "order-1", protos.Status_PENDING, protos.Status_APPROVED)
+	changes <- statusChange(2, "merchant-1", "ref1", "order-1", protos.Status_APPROVED, protos.Status_CAPTURED)
+	changes <- statusChange(3, "merchant-2", "ref2", "order-1", protos.Status_APPROVED, protos.Status_CAPTURED)
+	changes <- statusChange(4, "merchant-1", "ref3", "order-3", protos.Status_APPROVED, protos.Status_CAPTURED)
+	changes <- statusChange(5, "merchant-1", "ref1", "order-1", protos.Status_CAPTURED, protos.Status_REFUNDED)
+
+	// the watch ends when the hub stops listening, changes may have been missed
+	fail <- errors.New("connection reset by peer")
+
+	assert.EqualError(t, <-errs, "rpc error: code = Unavailable desc = watch was interrupted")
+
+	close(stream.sent)
+
+	var got []int64
+	for event := range stream.sent {
+		got = append(got, event.GetSequence())
+	}
+
+	assert.Equal(t, []int64{2, 5}, got)
+}
+
+func Test_server_WatchPayments_InvalidFilter(t *testing.T) {
+	mockController := gomock.NewController(t)
+
+	storageMock := mock_storage.NewMockClient(mockController)
+
+	bankMock := mock_bank.NewMockClient(mockController)
+
+	hub, _, _ := startWatchHub(t, mockController, storageMock)
+
+	err := New(storageMock, bankMock, WithWatchHub(hub)).WatchPayments(
+		&protos.WatchPaymentsRequest{Statuses: []protos.Status{protos.Status_UNKNOWN}},
+		newFakeWatchStream(context.Background()),
+	)
+
+	assert.EqualError(t, err, "rpc error: code = InvalidArgument desc = invalid filter")
+	assert.Equal(t, 0, hub.Watchers())
+}
@@ -0,0 +1,238 @@
+package server
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+
+	"payments_gateway/auth"
+	"payments_gateway/errcodes"
+	"payments_gateway/model"
+	protos "payments_gateway/protos"
+	identifier "payments_gateway/utils"
+	"payments_gateway/webhook"
+)
+
+// _maxWebhookURL is the longest webhook endpoint URL accepted
+const _maxWebhookURL = 2048
+
+var (
+	_errCreatingEndpoint      = errcodes.Error(codes.Internal, errcodes.Internal, "error creating webhook endpoint")
+	_errDeletingEndpoint      = errcodes.Error(codes.Internal, errcodes.Internal, "error deleting webhook endpoint")
+	_errListingDeliveries     = errcodes.Error(codes.Internal, errcodes.Internal, "error listing webhook deliveries")
+	_errRedeliveringWebhook   = errcodes.Error(codes.Internal, errcodes.Internal, "error redelivering webhook")
+	_errEndpointNotFound      = errcodes.Error(codes.NotFound, errcodes.WebhookEndpointNotFound, "webhook endpoint not found")
+	_errDeliveryNotFound      = errcodes.Error(codes.NotFound, errcodes.WebhookDeliveryNotFound, "webhook delivery not found")
+	_errInvalidDeliveryFilter = errcodes.Error(codes.InvalidArgument, errcodes.InvalidFilter, "invalid filter",
+		errcodes.FieldViolation("page_size", "must not be negative"))
+)
+
+// CreateWebhookEndpoint registers a URL the payment status changes of the merchant are sent to. The
+// secret the events are signed with is only ever returned here
+func (s *server) CreateWebhookEndpoint(ctx context.Context, request *protos.CreateWebhookEndpointRequest) (*protos.CreateWebhookEndpointResponse, error) {
+	if err := missingParameters(field{name: "url", set: request.GetUrl() != ""}); err != nil {
+		return nil, err
+	}
+
+	if err := validateWebhookEndpoint(request); err != nil {
+		log.WithField("url", request.GetUrl()).Warn("invalid webhook endpoint")
+
+		return nil, err
+	}
+
+	if err := s.checkWebhookHost(ctx, request.GetUrl()); err != nil {
+		return nil, err
+	}
+
+	secret, err := webhook.GenerateSecret()
+	if err != nil {
+		log.WithError(err).Error("generating webhook secret")
+
+		return nil, _errCreatingEndpoint
+	}
+
+	endpoint := model.WebhookEndpoint{
+		ID:         identifier.NewUUID(),
+		MerchantID: auth.MerchantID(ctx),
+		URL:        request.GetUrl(),
+		Secret:     secret,
+		Statuses:   request.GetStatuses(),
+	}
+
+	if err := s.dbClient.AddWebhookEndpoint(ctx, endpoint); err != nil {
+		log.WithField("url", endpoint.URL).WithError(err).Error("adding webhook endpoint")
+
+		return nil, _errCreatingEndpoint
+	}
+
+	return &protos.CreateWebhookEndpointResponse{
+		EndpointId: endpoint.ID,
+		Url:        endpoint.URL,
+		Statuses:   endpoint.Statuses,
+		Secret:     endpoint.Secret,
+	}, nil
+}
+
+// validateWebhookEndpoint returns an InvalidArgument error naming every invalid field of an endpoint.
+// Events carry payment details so they are only sent over https
+func validateWebhookEndpoint(request *protos.CreateWebhookEndpointRequest) error {
+	var violations []*errdetails.BadRequest_FieldViolation
+
+	endpoint, err := url.Parse(request.GetUrl())
+
+	switch {
+	case len(request.GetUrl()) > _maxWebhookURL:
+		violations = append(violations, errcodes.FieldViolation("url", "must be at most 2048 characters"))
+	case err != nil || endpoint.Scheme != "https" || endpoint.Host == "":
+		violations = append(violations, errcodes.FieldViolation("url", "must be an absolute https URL"))
+	case endpoint.User != nil:
+		violations = append(violations, errcodes.FieldViolation("url", "must not hold credentials"))
+	}
+
+	for _, status := range request.GetStatuses() {
+		if _, ok := protos.Status_name[int32(status)]; !ok || status == protos.Status_UNKNOWN {
+			violations = append(violations, errcodes.FieldViolation("statuses", "is not a payment status"))
+
+			break
+		}
+	}
+
+	if len(violations) > 0 {
+		return errcodes.Error(codes.InvalidArgument, errcodes.InvalidWebhookEndpoint, "invalid webhook endpoint", violations...)
+	}
+
+	return nil
+}
+
+// checkWebhookHost rejects an endpoint whose host is, or resolves to, an address that is not public, so
+// merchants cannot have the gateway send requests to its own network
+func (s *server) checkWebhookHost(ctx context.Context, rawURL string) error {
+	endpoint, err := url.Parse(rawURL)
+	if err != nil {
+		return err
+	}
+
+	if err := webhook.CheckHost(ctx, s.webhookResolver, endpoint.Hostname()); err != nil {
+		log.WithField("url", rawURL).WithError(err).Warn("webhook endpoint host is not public")
+
+		return errcodes.Error(codes.InvalidArgument, errcodes.InvalidWebhookEndpoint, "invalid webhook endpoint",
+			errcodes.FieldViolation("url", "host must resolve to public addresses only"))
+	}
+
+	return nil
+}
+
+// DeleteWebhookEndpoint stops payment status changes being sent to an endpoint of the merchant,
+// including the changes still waiting to be sent
+func (s *server) DeleteWebhookEndpoint(ctx context.Context, request *protos.DeleteWebhookEndpointRequest) (*protos.DeleteWebhookEndpointResponse, error) {
+	if err := missingParameters(field{name: "endpoint_id", set: request.GetEndpointId() != ""}); err != nil {
+		return nil, err
+	}
+
+	deleted, err := s.dbClient.DeleteWebhookEndpoint(ctx, auth.MerchantID(ctx), request.GetEndpointId())
+	if err != nil {
+		log.WithField("endpoint_id", request.GetEndpointId()).WithError(err).Error("deleting webhook endpoint")
+
+		return nil, _errDeletingEndpoint
+	}
+
+	if !deleted {
+		return nil, _errEndpointNotFound
+	}
+
+	return &protos.DeleteWebhookEndpointResponse{EndpointId: request.GetEndpointId()}, nil
+}
+
+// ListWebhookDeliveries returns the webhook deliveries of the merchant matching the filters of the
+// request a page at a time, newest first. Dead lettered deliveries are listed with the DEAD_LETTER status
+func (s *server) ListWebhookDeliveries(ctx context.Context, request *protos.ListWebhookDeliveriesRequest) (*protos.ListWebhookDeliveriesResponse, error) {
+	if request.GetPageSize() < 0 {
+		return nil, _errInvalidDeliveryFilter
+	}
+
+	filters, err := filtersHash(request)
+	if err != nil {
+		log.WithError(err).Error("hashing list filters")
+
+		return nil, _errListingDeliveries
+	}
+
+	filter := model.WebhookDeliveryFilter{
+		Status:     request.GetStatus(),
+		EndpointID: request.GetEndpointId(),
+		RefID:      request.GetRef(),
+	}
+
+	if request.GetPageToken() != "" {
+		token, err := decodePageToken(request.GetPageToken(), filters)
+		if err != nil {
+			return nil, err
+		}
+
+		if filter.Before, err = strconv.ParseInt(token.ID, 10, 64); err != nil {
+			return nil, _errInvalidToken
+		}
+	}
+
+	pageSize := int(request.GetPageSize())
+	if pageSize == 0 {
+		pageSize = _defaultPageSize
+	} else if pageSize > _maxPageSize {
+		pageSize = _maxPageSize
+	}
+
+	// one more delivery than the page holds tells whether there is a next page
+	filter.Limit = pageSize + 1
+
+	deliveries, err := s.dbClient.ListWebhookDeliveries(ctx, auth.MerchantID(ctx), filter)
+	if err != nil {
+		log.WithError(err).Error("listing webhook deliveries")
+
+		return nil, _errListingDeliveries
+	}
+
+	resp := &protos.ListWebhookDeliveriesResponse{Deliveries: deliveries}
+
+	if len(deliveries) > pageSize {
+		resp.Deliveries = deliveries[:pageSize]
+
+		resp.NextPageToken, err = encodePageToken(pageToken{ID: resp.Deliveries[pageSize-1].GetDeliveryId(), Filters: filters})
+		if err != nil {
+			log.WithError(err).Error("encoding page token")
+
+			return nil, _errListingDeliveries
+		}
+	}
+
+	return resp, nil
+}
+
+// RedeliverWebhook attempts a webhook delivery of the merchant again with a new set of retries,
+// typically one that was dead lettered while the endpoint was down
+func (s *server) RedeliverWebhook(ctx context.Context, request *protos.RedeliverWebhookRequest) (*protos.RedeliverWebhookResponse, error) {
+	if err := missingParameters(field{name: "delivery_id", set: request.GetDeliveryId() != ""}); err != nil {
+		return nil, err
+	}
+
+	deliveryID, err := strconv.ParseInt(request.GetDeliveryId(), 10, 64)
+	if err != nil {
+		return nil, _errDeliveryNotFound
+	}
+
+	delivery, err := s.dbClient.RedeliverWebhook(ctx, auth.MerchantID(ctx), deliveryID)
+	if err != nil {
+		log.WithField("delivery_id", request.GetDeliveryId()).WithError(err).Error("redelivering webhook")
+
+		return nil, _errRedeliveringWebhook
+	}
+
+	if delivery == nil {
+		return nil, _errDeliveryNotFound
+	}
+
+	return &protos.RedeliverWebhookResponse{Delivery: delivery}, nil
+}
@@ -0,0 +1,136 @@
+package settlement
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	bank "payments_gateway/aquiring-bank"
+	"payments_gateway/storage"
+	identifier "payments_gateway/utils"
+)
+
+// DefaultBatchSize is the number of payments submitted to an acquirer in one batch
+const DefaultBatchSize = 500
+
+// DefaultStaleAfter is how long a batch may stay SUBMITTING before it is failed
+const DefaultStaleAfter = time.Hour
+
+// Summary counts what a settlement run did
+type Summary struct {
+	Batches       int
+	Settled       int
+	Failed        int
+	FailedBatches int
+	StaleBatches  int
+}
+
+// Settler submits the captured payments of every acquirer for settlement in batches
+type Settler struct {
+	store      storage.Client
+	acquirers  *bank.Registry
+	batchSize  int
+	staleAfter time.Duration
+	newID      func() string
+}
+
+// New creates a settler, batchSize defaults to DefaultBatchSize and staleAfter to DefaultStaleAfter
+// when not positive. staleAfter must be longer than submitting a batch can take
+func New(store storage.Client, acquirers *bank.Registry, batchSize int, staleAfter time.Duration) *Settler {
+	if batchSize <= 0 {
+		batchSize = DefaultBatchSize
+	}
+
+	if staleAfter <= 0 {
+		staleAfter = DefaultStaleAfter
+	}
+
+	return &Settler{
+		store:      store,
+		acquirers:  acquirers,
+		batchSize:  batchSize,
+		staleAfter: staleAfter,
+		newID:      identifier.NewUUID,
+	}
+}
+
+// Run settles the captured payments of every acquirer. An acquirer failing a batch is not sent
+// any more batches until the next run, its payments are claimed again then. Batches left SUBMITTING
+// for staleAfter by a run that stopped are failed first, so their payments are settled again
+func (s *Settler) Run(ctx context.Context) (Summary, error) {
+	var summary Summary
+
+	stale, err := s.store.FailStaleSettlementBatches(ctx, s.staleAfter, fmt.Sprintf("still submitting after %s", s.staleAfter))
+	if err != nil {
+		return summary, fmt.Errorf("failing stale settlement batches: %w", err)
+	}
+
+	for _, batchID := range stale {
+		log.WithField("batch_id", batchID).Warn("failed settlement batch left submitting, its payments are settled again")
+	}
+
+	summary.StaleBatches = len(stale)
+
+	for _, name := range s.acquirers.Names() {
+		client, _ := s.acquirers.Get(name)
+
+		if err := s.settle(ctx, name, client, &summary); err != nil {
+			return summary, err
+		}
+	}
+
+	return summary, nil
+}
+
+// settle submits batches to an acquirer until none of its payments are left to settle
+func (s *Settler) settle(ctx context.Context, acquirer string, client bank.Client, summary *Summary) error {
+	// payments failed by the acquirer in this run are not submitted again until the next run
+	var submitted []string
+
+	for {
+		batchID := s.newID()
+
+		batch, err := s.store.CreateSettlementBatch(ctx, batchID, acquirer, submitted, s.batchSize)
+		if err != nil {
+			return fmt.Errorf("creating settlement batch for acquirer %s: %w", acquirer, err)
+		}
+
+		if batch == nil {
+			return nil
+		}
+
+		submitted = append(submitted, batchID)
+		summary.Batches++
+
+		logger := log.WithField("acquirer", acquirer).WithField("batch_id", batchID)
+
+		failures, err := client.Submit(ctx, batch.Transactions)
+		if err != nil {
+			logger.WithError(err).Error("submitting settlement batch")
+
+			summary.FailedBatches++
+
+			if err := s.store.FailSettlementBatch(ctx, batchID, err.Error()); err != nil {
+				return fmt.Errorf("failing settlement batch %s: %w", batchID, err)
+			}
+
+			return nil
+		}
+
+		result, err := s.store.CompleteSettlementBatch(ctx, batchID, failures)
+		if err != nil {
+			return fmt.Errorf("completing settlement batch %s: %w", batchID, err)
+		}
+
+		summary.Settled += result.Settled
+		summary.Failed += result.Failed
+
+		logger.WithField("settled", result.Settled).WithField("failed", result.Failed).Info("settled batch")
+
+		if len(batch.Transactions) < s.batchSize {
+			return nil
+		}
+	}
+}
@@ -0,0 +1,132 @@
+package settlement
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	bank "payments_gateway/aquiring-bank"
+	"payments_gateway/aquiring-bank/mocks"
+	"payments_gateway/model"
+	"payments_gateway/money"
+	"payments_gateway/storage/mocks"
+)
+
+func batch(id string, refIDs ...string) *model.SettlementBatch {
+	b := &model.SettlementBatch{ID: id, Acquirer: bank.DefaultAcquirer}
+
+	for _, refID := range refIDs {
+		b.Transactions = append(b.Transactions, &model.Transaction{RefID: refID, Amount: money.New(2050, "GBP")})
+	}
+
+	return b
+}
+
+func TestSettler_Run(t *testing.T) {
+	tests := []struct {
+		name    string
+		prepare func(store *mock_storage.MockClient, client *mock_bank.MockClient)
+		stale   []string
+		want    Summary
+		err     error
+	}{
+		{
+			name: "nothing to settle",
+			prepare: func(store *mock_storage.MockClient, client *mock_bank.MockClient) {
+				store.EXPECT().CreateSettlementBatch(gomock.Any(), "batch-1", bank.DefaultAcquirer, nil, 2).Return(nil, nil)
+			},
+		},
+		{
+			name: "full batch followed by a partial one",
+			prepare: func(store *mock_storage.MockClient, client *mock_bank.MockClient) {
+				gomock.InOrder(
+					store.EXPECT().CreateSettlementBatch(gomock.Any(), "batch-1", bank.DefaultAcquirer, nil, 2).
+						Return(batch("batch-1", "ref1", "ref2"), nil),
+					client.EXPECT().Submit(gomock.Any(), batch("batch-1", "ref1", "ref2").Transactions).
+						Return(map[string]string{"ref2": "card expired"}, nil),
+					store.EXPECT().CompleteSettlementBatch(gomock.Any(), "batch-1", map[string]string{"ref2": "card expired"}).
+						Return(model.SettlementResult{Settled: 1, Failed: 1}, nil),
+					store.EXPECT().CreateSettlementBatch(gomock.Any(), "batch-2", bank.DefaultAcquirer, []string{"batch-1"}, 2).
+						Return(batch("batch-2", "ref3"), nil),
+					client.EXPECT().Submit(gomock.Any(), gomock.Any()).Return(map[string]string{}, nil),
+					store.EXPECT().CompleteSettlementBatch(gomock.Any(), "batch-2", map[string]string{}).
+						Return(model.SettlementResult{Settled: 1}, nil),
+				)
+			},
+			want: Summary{Batches: 2, Settled: 2, Failed: 1},
+		},
+		{
+			name: "failed submit stops the acquirer until the next run",
+			prepare: func(store *mock_storage.MockClient, client *mock_bank.MockClient) {
+				store.EXPECT().CreateSettlementBatch(gomock.Any(), "batch-1", bank.DefaultAcquirer, nil, 2).
+					Return(batch("batch-1", "ref1", "ref2"), nil)
+				client.EXPECT().Submit(gomock.Any(), gomock.Any()).Return(nil, errors.New("unexpected response status code: 500"))
+				store.EXPECT().FailSettlementBatch(gomock.Any(), "batch-1", "unexpected response status code: 500").Return(nil)
+			},
+			want: Summary{Batches: 1, FailedBatches: 1},
+		},
+		{
+			name:  "stale batch is failed before settling",
+			stale: []string{"batch-0"},
+			prepare: func(store *mock_storage.MockClient, client *mock_bank.MockClient) {
+				store.EXPECT().CreateSettlementBatch(gomock.Any(), "batch-1", bank.DefaultAcquirer, nil, 2).
+					Return(batch("batch-1", "ref1"), nil)
+				client.EXPECT().Submit(gomock.Any(), gomock.Any()).Return(map[string]string{}, nil)
+				store.EXPECT().CompleteSettlementBatch(gomock.Any(), "batch-1", map[string]string{}).
+					Return(model.SettlementResult{Settled: 1}, nil)
+			},
+			want: Summary{Batches: 1, Settled: 1, StaleBatches: 1},
+		},
+		{
+			name: "storage error",
+			prepare: func(store *mock_storage.MockClient, client *mock_bank.MockClient) {
+				store.EXPECT().CreateSettlementBatch(gomock.Any(), "batch-1", bank.DefaultAcquirer, nil, 2).
+					Return(nil, errors.New("connection refused"))
+			},
+			err: errors.New("creating settlement batch for acquirer default: connection refused"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockController := gomock.NewController(t)
+
+			defer mockController.Finish()
+
+			store := mock_storage.NewMockClient(mockController)
+			client := mock_bank.NewMockClient(mockController)
+
+			store.EXPECT().FailStaleSettlementBatches(gomock.Any(), time.Hour, "still submitting after 1h0m0s").Return(tt.stale, nil)
+
+			tt.prepare(store, client)
+
+			acquirers := bank.NewRegistry()
+			if err := acquirers.Register(bank.DefaultAcquirer, client); err != nil {
+				t.Fatal(err)
+			}
+
+			s := New(store, acquirers, 2, time.Hour)
+
+			n := 0
+			s.newID = func() string {
+				n++
+
+				return fmt.Sprintf("batch-%d", n)
+			}
+
+			got, err := s.Run(context.Background())
+			if tt.err != nil {
+				assert.Equal(t, tt.err.Error(), err.Error())
+
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
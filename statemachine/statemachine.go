@@ -0,0 +1,121 @@
+package statemachine
+
+import (
+	"fmt"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"payments_gateway/errcodes"
+	protos "payments_gateway/protos"
+)
+
+// New is the status of a payment that has not been stored yet. Every payment
+// starts here, so the status it is first stored with must be a legal transition from New
+const New = protos.Status_UNKNOWN
+
+// transitions lists every status a payment is allowed to move to from a given status.
+// APPROVED is the status of an authorized payment and COMPLETED of a settled one
+var transitions = map[protos.Status][]protos.Status{
+	New: {
+		protos.Status_CARD_VERIFIED,
+		protos.Status_APPROVED,
+		protos.Status_REJECTED,
+		protos.Status_PENDING,
+		protos.Status_COMPLETED,
+		protos.Status_UNKNOWN_RESPONSE_CODE,
+		protos.Status_AUTHORIZING,
+	},
+	// an authorization the outcome of which was not received is reversed
+	protos.Status_AUTHORIZING: {
+		protos.Status_APPROVED,
+		protos.Status_REJECTED,
+		protos.Status_PENDING,
+		protos.Status_COMPLETED,
+		protos.Status_UNKNOWN_RESPONSE_CODE,
+		protos.Status_REVERSED,
+	},
+	protos.Status_CARD_VERIFIED: {
+		protos.Status_APPROVED,
+		protos.Status_REJECTED,
+		protos.Status_PENDING,
+		protos.Status_COMPLETED,
+	},
+	// a pending payment that is not resolved with the acquirer in time expires
+	protos.Status_PENDING: {
+		protos.Status_APPROVED,
+		protos.Status_REJECTED,
+		protos.Status_COMPLETED,
+		protos.Status_EXPIRED,
+	},
+	// the outcome of the authorization is unknown until it is resolved with the acquirer, it is
+	// reversed when the acquirer gives no outcome in time
+	protos.Status_UNKNOWN_RESPONSE_CODE: {
+		protos.Status_APPROVED,
+		protos.Status_REJECTED,
+		protos.Status_COMPLETED,
+		protos.Status_REVERSED,
+	},
+	protos.Status_APPROVED: {
+		protos.Status_CAPTURED,
+		protos.Status_VOIDED,
+	},
+	protos.Status_CAPTURED: {
+		protos.Status_COMPLETED,
+		protos.Status_PARTIALLY_REFUNDED,
+		protos.Status_REFUNDED,
+	},
+	protos.Status_COMPLETED: {
+		protos.Status_PARTIALLY_REFUNDED,
+		protos.Status_REFUNDED,
+	},
+	protos.Status_PARTIALLY_REFUNDED: {
+		protos.Status_PARTIALLY_REFUNDED,
+		protos.Status_REFUNDED,
+	},
+}
+
+// TransitionError is returned when a payment is asked to make an illegal status transition.
+// It converts to a FailedPrecondition gRPC status
+type TransitionError struct {
+	From protos.Status
+	To   protos.Status
+}
+
+func (e *TransitionError) Error() string {
+	if e.From == New {
+		return fmt.Sprintf("payment cannot be created with status %s", e.To)
+	}
+
+	return fmt.Sprintf("illegal status transition from %s to %s", e.From, e.To)
+}
+
+// GRPCStatus allows the error to be returned as is from a gRPC handler
+func (e *TransitionError) GRPCStatus() *status.Status {
+	return errcodes.Status(codes.FailedPrecondition, errcodes.IllegalStatusTransition, e.Error())
+}
+
+// CanTransition reports whether a payment in status from may move to status to
+func CanTransition(from, to protos.Status) bool {
+	for _, next := range transitions[from] {
+		if next == to {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Transition returns a *TransitionError when a payment in status from may not move to status to
+func Transition(from, to protos.Status) error {
+	if !CanTransition(from, to) {
+		return &TransitionError{From: from, To: to}
+	}
+
+	return nil
+}
+
+// IsFinal reports whether no further transitions are possible from status
+func IsFinal(s protos.Status) bool {
+	return len(transitions[s]) == 0
+}
@@ -0,0 +1,136 @@
+package statemachine
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	protos "payments_gateway/protos"
+)
+
+func TestTransition(t *testing.T) {
+	tests := []struct {
+		name string
+		from protos.Status
+		to   protos.Status
+		err  error
+	}{
+		{
+			name: "new payment is authorized",
+			from: New,
+			to:   protos.Status_APPROVED,
+		},
+		{
+			name: "verified card is authorized",
+			from: protos.Status_CARD_VERIFIED,
+			to:   protos.Status_APPROVED,
+		},
+		{
+			name: "new payment is stored before it is authorized",
+			from: New,
+			to:   protos.Status_AUTHORIZING,
+		},
+		{
+			name: "authorizing payment is approved",
+			from: protos.Status_AUTHORIZING,
+			to:   protos.Status_APPROVED,
+		},
+		{
+			name: "authorizing payment is reversed",
+			from: protos.Status_AUTHORIZING,
+			to:   protos.Status_REVERSED,
+		},
+		{
+			name: "pending payment is approved",
+			from: protos.Status_PENDING,
+			to:   protos.Status_APPROVED,
+		},
+		{
+			name: "pending payment expires",
+			from: protos.Status_PENDING,
+			to:   protos.Status_EXPIRED,
+		},
+		{
+			name: "payment with an unknown response code is resolved",
+			from: protos.Status_UNKNOWN_RESPONSE_CODE,
+			to:   protos.Status_REJECTED,
+		},
+		{
+			name: "payment with an unknown response code is reversed",
+			from: protos.Status_UNKNOWN_RESPONSE_CODE,
+			to:   protos.Status_REVERSED,
+		},
+		{
+			name: "authorized payment is captured",
+			from: protos.Status_APPROVED,
+			to:   protos.Status_CAPTURED,
+		},
+		{
+			name: "captured payment is settled",
+			from: protos.Status_CAPTURED,
+			to:   protos.Status_COMPLETED,
+		},
+		{
+			name: "partially refunded payment is refunded again",
+			from: protos.Status_PARTIALLY_REFUNDED,
+			to:   protos.Status_PARTIALLY_REFUNDED,
+		},
+		{
+			name: "new payment cannot be captured",
+			from: New,
+			to:   protos.Status_CAPTURED,
+			err:  errors.New("payment cannot be created with status CAPTURED"),
+		},
+		{
+			name: "rejected payment cannot be captured",
+			from: protos.Status_REJECTED,
+			to:   protos.Status_CAPTURED,
+			err:  errors.New("illegal status transition from REJECTED to CAPTURED"),
+		},
+		{
+			name: "captured payment cannot be voided",
+			from: protos.Status_CAPTURED,
+			to:   protos.Status_VOIDED,
+			err:  errors.New("illegal status transition from CAPTURED to VOIDED"),
+		},
+		{
+			name: "authorizing payment cannot be captured",
+			from: protos.Status_AUTHORIZING,
+			to:   protos.Status_CAPTURED,
+			err:  errors.New("illegal status transition from AUTHORIZING to CAPTURED"),
+		},
+		{
+			name: "refunded payment cannot be refunded",
+			from: protos.Status_REFUNDED,
+			to:   protos.Status_PARTIALLY_REFUNDED,
+			err:  errors.New("illegal status transition from REFUNDED to PARTIALLY_REFUNDED"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Transition(tt.from, tt.to)
+			if tt.err == nil {
+				assert.NoError(t, err)
+
+				return
+			}
+
+			assert.EqualError(t, err, tt.err.Error())
+			assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+		})
+	}
+}
+
+func TestIsFinal(t *testing.T) {
+	assert.True(t, IsFinal(protos.Status_REJECTED))
+	assert.True(t, IsFinal(protos.Status_VOIDED))
+	assert.True(t, IsFinal(protos.Status_REFUNDED))
+	assert.True(t, IsFinal(protos.Status_EXPIRED))
+	assert.True(t, IsFinal(protos.Status_REVERSED))
+	assert.False(t, IsFinal(protos.Status_AUTHORIZING))
+	assert.False(t, IsFinal(protos.Status_APPROVED))
+	assert.False(t, IsFinal(protos.Status_PENDING))
+}
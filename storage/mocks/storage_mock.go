@@ -6,8 +6,12 @@ package mock_storage
 
 import (
 	context "context"
+	model "payments_gateway/model"
+	money "payments_gateway/money"
 	protos_payments "payments_gateway/protos"
+	storage "payments_gateway/storage"
 	reflect "reflect"
+	time "time"
 
 	gomock "github.com/golang/mock/gomock"
 )
@@ -35,31 +39,638 @@ func (m *MockClient) EXPECT() *MockClientMockRecorder {
 	return m.recorder
 }
 
+// AddAPIKey mocks base method.
+func (m *MockClient) AddAPIKey(ctx context.Context, merchantID, keyHash string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddAPIKey", ctx, merchantID, keyHash)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddAPIKey indicates an expected call of AddAPIKey.
+func (mr *MockClientMockRecorder) AddAPIKey(ctx, merchantID, keyHash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddAPIKey", reflect.TypeOf((*MockClient)(nil).AddAPIKey), ctx, merchantID, keyHash)
+}
+
+// AddMerchant mocks base method.
+func (m *MockClient) AddMerchant(ctx context.Context, merchant model.Merchant) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddMerchant", ctx, merchant)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddMerchant indicates an expected call of AddMerchant.
+func (mr *MockClientMockRecorder) AddMerchant(ctx, merchant interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddMerchant", reflect.TypeOf((*MockClient)(nil).AddMerchant), ctx, merchant)
+}
+
 // AddPaymentInfo mocks base method.
-func (m *MockClient) AddPaymentInfo(ctx context.Context, refID string, request *protos_payments.ProcessPaymentRequest, code protos_payments.Status, reason string) error {
+func (m *MockClient) AddPaymentInfo(ctx context.Context, merchantID, refID string, request *protos_payments.ProcessPaymentRequest, amount money.Money, auth model.Authorization) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "AddPaymentInfo", ctx, refID, request, code, reason)
+	ret := m.ctrl.Call(m, "AddPaymentInfo", ctx, merchantID, refID, request, amount, auth)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // AddPaymentInfo indicates an expected call of AddPaymentInfo.
-func (mr *MockClientMockRecorder) AddPaymentInfo(ctx, refID, request, code, reason interface{}) *gomock.Call {
+func (mr *MockClientMockRecorder) AddPaymentInfo(ctx, merchantID, refID, request, amount, auth interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddPaymentInfo", reflect.TypeOf((*MockClient)(nil).AddPaymentInfo), ctx, merchantID, refID, request, amount, auth)
+}
+
+// AddReconciliationReport mocks base method.
+func (m *MockClient) AddReconciliationReport(ctx context.Context, report *model.ReconciliationReport) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddReconciliationReport", ctx, report)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddReconciliationReport indicates an expected call of AddReconciliationReport.
+func (mr *MockClientMockRecorder) AddReconciliationReport(ctx, report interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddReconciliationReport", reflect.TypeOf((*MockClient)(nil).AddReconciliationReport), ctx, report)
+}
+
+// AddVaultedCard mocks base method.
+func (m *MockClient) AddVaultedCard(ctx context.Context, card model.VaultedCard) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddVaultedCard", ctx, card)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddVaultedCard indicates an expected call of AddVaultedCard.
+func (mr *MockClientMockRecorder) AddVaultedCard(ctx, card interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddVaultedCard", reflect.TypeOf((*MockClient)(nil).AddVaultedCard), ctx, card)
+}
+
+// AddWebhookEndpoint mocks base method.
+func (m *MockClient) AddWebhookEndpoint(ctx context.Context, endpoint model.WebhookEndpoint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddWebhookEndpoint", ctx, endpoint)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddWebhookEndpoint indicates an expected call of AddWebhookEndpoint.
+func (mr *MockClientMockRecorder) AddWebhookEndpoint(ctx, endpoint interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddWebhookEndpoint", reflect.TypeOf((*MockClient)(nil).AddWebhookEndpoint), ctx, endpoint)
+}
+
+// ClaimPendingPayments mocks base method.
+func (m *MockClient) ClaimPendingPayments(ctx context.Context, minAge, reverseAfter, inquiryInterval, maxAge time.Duration, limit int) ([]*model.PendingPayment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimPendingPayments", ctx, minAge, reverseAfter, inquiryInterval, maxAge, limit)
+	ret0, _ := ret[0].([]*model.PendingPayment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimPendingPayments indicates an expected call of ClaimPendingPayments.
+func (mr *MockClientMockRecorder) ClaimPendingPayments(ctx, minAge, reverseAfter, inquiryInterval, maxAge, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimPendingPayments", reflect.TypeOf((*MockClient)(nil).ClaimPendingPayments), ctx, minAge, reverseAfter, inquiryInterval, maxAge, limit)
+}
+
+// ClaimWebhookDeliveries mocks base method.
+func (m *MockClient) ClaimWebhookDeliveries(ctx context.Context, limit int, lease time.Duration) ([]*model.WebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimWebhookDeliveries", ctx, limit, lease)
+	ret0, _ := ret[0].([]*model.WebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimWebhookDeliveries indicates an expected call of ClaimWebhookDeliveries.
+func (mr *MockClientMockRecorder) ClaimWebhookDeliveries(ctx, limit, lease interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimWebhookDeliveries", reflect.TypeOf((*MockClient)(nil).ClaimWebhookDeliveries), ctx, limit, lease)
+}
+
+// CompleteSettlementBatch mocks base method.
+func (m *MockClient) CompleteSettlementBatch(ctx context.Context, batchID string, failures map[string]string) (model.SettlementResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CompleteSettlementBatch", ctx, batchID, failures)
+	ret0, _ := ret[0].(model.SettlementResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CompleteSettlementBatch indicates an expected call of CompleteSettlementBatch.
+func (mr *MockClientMockRecorder) CompleteSettlementBatch(ctx, batchID, failures interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompleteSettlementBatch", reflect.TypeOf((*MockClient)(nil).CompleteSettlementBatch), ctx, batchID, failures)
+}
+
+// CompleteWebhookDelivery mocks base method.
+func (m *MockClient) CompleteWebhookDelivery(ctx context.Context, attempt model.WebhookAttempt) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CompleteWebhookDelivery", ctx, attempt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CompleteWebhookDelivery indicates an expected call of CompleteWebhookDelivery.
+func (mr *MockClientMockRecorder) CompleteWebhookDelivery(ctx, attempt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompleteWebhookDelivery", reflect.TypeOf((*MockClient)(nil).CompleteWebhookDelivery), ctx, attempt)
+}
+
+// CreateIdempotencyKey mocks base method.
+func (m *MockClient) CreateIdempotencyKey(ctx context.Context, merchantID, key, requestHash string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateIdempotencyKey", ctx, merchantID, key, requestHash)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateIdempotencyKey indicates an expected call of CreateIdempotencyKey.
+func (mr *MockClientMockRecorder) CreateIdempotencyKey(ctx, merchantID, key, requestHash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateIdempotencyKey", reflect.TypeOf((*MockClient)(nil).CreateIdempotencyKey), ctx, merchantID, key, requestHash)
+}
+
+// CreateSettlementBatch mocks base method.
+func (m *MockClient) CreateSettlementBatch(ctx context.Context, batchID, acquirer string, exclude []string, limit int) (*model.SettlementBatch, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSettlementBatch", ctx, batchID, acquirer, exclude, limit)
+	ret0, _ := ret[0].(*model.SettlementBatch)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateSettlementBatch indicates an expected call of CreateSettlementBatch.
+func (mr *MockClientMockRecorder) CreateSettlementBatch(ctx, batchID, acquirer, exclude, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSettlementBatch", reflect.TypeOf((*MockClient)(nil).CreateSettlementBatch), ctx, batchID, acquirer, exclude, limit)
+}
+
+// DeleteIdempotencyKey mocks base method.
+func (m *MockClient) DeleteIdempotencyKey(ctx context.Context, merchantID, key string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteIdempotencyKey", ctx, merchantID, key)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteIdempotencyKey indicates an expected call of DeleteIdempotencyKey.
+func (mr *MockClientMockRecorder) DeleteIdempotencyKey(ctx, merchantID, key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteIdempotencyKey", reflect.TypeOf((*MockClient)(nil).DeleteIdempotencyKey), ctx, merchantID, key)
+}
+
+// DeleteVaultedCard mocks base method.
+func (m *MockClient) DeleteVaultedCard(ctx context.Context, merchantID, token string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteVaultedCard", ctx, merchantID, token)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteVaultedCard indicates an expected call of DeleteVaultedCard.
+func (mr *MockClientMockRecorder) DeleteVaultedCard(ctx, merchantID, token interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddPaymentInfo", reflect.TypeOf((*MockClient)(nil).AddPaymentInfo), ctx, refID, request, code, reason)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteVaultedCard", reflect.TypeOf((*MockClient)(nil).DeleteVaultedCard), ctx, merchantID, token)
+}
+
+// DeleteWebhookEndpoint mocks base method.
+func (m *MockClient) DeleteWebhookEndpoint(ctx context.Context, merchantID, endpointID string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteWebhookEndpoint", ctx, merchantID, endpointID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteWebhookEndpoint indicates an expected call of DeleteWebhookEndpoint.
+func (mr *MockClientMockRecorder) DeleteWebhookEndpoint(ctx, merchantID, endpointID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteWebhookEndpoint", reflect.TypeOf((*MockClient)(nil).DeleteWebhookEndpoint), ctx, merchantID, endpointID)
+}
+
+// FailSettlementBatch mocks base method.
+func (m *MockClient) FailSettlementBatch(ctx context.Context, batchID, reason string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FailSettlementBatch", ctx, batchID, reason)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// FailSettlementBatch indicates an expected call of FailSettlementBatch.
+func (mr *MockClientMockRecorder) FailSettlementBatch(ctx, batchID, reason interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FailSettlementBatch", reflect.TypeOf((*MockClient)(nil).FailSettlementBatch), ctx, batchID, reason)
+}
+
+// FailStaleSettlementBatches mocks base method.
+func (m *MockClient) FailStaleSettlementBatches(ctx context.Context, staleAfter time.Duration, reason string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FailStaleSettlementBatches", ctx, staleAfter, reason)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FailStaleSettlementBatches indicates an expected call of FailStaleSettlementBatches.
+func (mr *MockClientMockRecorder) FailStaleSettlementBatches(ctx, staleAfter, reason interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FailStaleSettlementBatches", reflect.TypeOf((*MockClient)(nil).FailStaleSettlementBatches), ctx, staleAfter, reason)
+}
+
+// GetIdempotencyKey mocks base method.
+func (m *MockClient) GetIdempotencyKey(ctx context.Context, merchantID, key string) (*model.IdempotencyRecord, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetIdempotencyKey", ctx, merchantID, key)
+	ret0, _ := ret[0].(*model.IdempotencyRecord)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetIdempotencyKey indicates an expected call of GetIdempotencyKey.
+func (mr *MockClientMockRecorder) GetIdempotencyKey(ctx, merchantID, key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetIdempotencyKey", reflect.TypeOf((*MockClient)(nil).GetIdempotencyKey), ctx, merchantID, key)
+}
+
+// GetLatestStatusChange mocks base method.
+func (m *MockClient) GetLatestStatusChange(ctx context.Context, merchantID, refID string) (*model.StatusChangeEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLatestStatusChange", ctx, merchantID, refID)
+	ret0, _ := ret[0].(*model.StatusChangeEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLatestStatusChange indicates an expected call of GetLatestStatusChange.
+func (mr *MockClientMockRecorder) GetLatestStatusChange(ctx, merchantID, refID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLatestStatusChange", reflect.TypeOf((*MockClient)(nil).GetLatestStatusChange), ctx, merchantID, refID)
+}
+
+// GetMerchant mocks base method.
+func (m *MockClient) GetMerchant(ctx context.Context, merchantID string) (*model.Merchant, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMerchant", ctx, merchantID)
+	ret0, _ := ret[0].(*model.Merchant)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMerchant indicates an expected call of GetMerchant.
+func (mr *MockClientMockRecorder) GetMerchant(ctx, merchantID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMerchant", reflect.TypeOf((*MockClient)(nil).GetMerchant), ctx, merchantID)
+}
+
+// GetMerchantByAPIKey mocks base method.
+func (m *MockClient) GetMerchantByAPIKey(ctx context.Context, keyHash string) (*model.Merchant, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMerchantByAPIKey", ctx, keyHash)
+	ret0, _ := ret[0].(*model.Merchant)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMerchantByAPIKey indicates an expected call of GetMerchantByAPIKey.
+func (mr *MockClientMockRecorder) GetMerchantByAPIKey(ctx, keyHash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMerchantByAPIKey", reflect.TypeOf((*MockClient)(nil).GetMerchantByAPIKey), ctx, keyHash)
+}
+
+// GetPaymentByReference mocks base method.
+func (m *MockClient) GetPaymentByReference(ctx context.Context, merchantID, reference string) (*protos_payments.GetPaymentResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPaymentByReference", ctx, merchantID, reference)
+	ret0, _ := ret[0].(*protos_payments.GetPaymentResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPaymentByReference indicates an expected call of GetPaymentByReference.
+func (mr *MockClientMockRecorder) GetPaymentByReference(ctx, merchantID, reference interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPaymentByReference", reflect.TypeOf((*MockClient)(nil).GetPaymentByReference), ctx, merchantID, reference)
+}
+
+// GetPaymentHistory mocks base method.
+func (m *MockClient) GetPaymentHistory(ctx context.Context, merchantID, refID string) ([]*protos_payments.PaymentStatusChange, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPaymentHistory", ctx, merchantID, refID)
+	ret0, _ := ret[0].([]*protos_payments.PaymentStatusChange)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPaymentHistory indicates an expected call of GetPaymentHistory.
+func (mr *MockClientMockRecorder) GetPaymentHistory(ctx, merchantID, refID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPaymentHistory", reflect.TypeOf((*MockClient)(nil).GetPaymentHistory), ctx, merchantID, refID)
 }
 
 // GetPaymentInfo mocks base method.
-func (m *MockClient) GetPaymentInfo(ctx context.Context, refId string) (*protos_payments.GetPaymentResponse, error) {
+func (m *MockClient) GetPaymentInfo(ctx context.Context, merchantID, refID string) (*protos_payments.GetPaymentResponse, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetPaymentInfo", ctx, refId)
+	ret := m.ctrl.Call(m, "GetPaymentInfo", ctx, merchantID, refID)
 	ret0, _ := ret[0].(*protos_payments.GetPaymentResponse)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetPaymentInfo indicates an expected call of GetPaymentInfo.
-func (mr *MockClientMockRecorder) GetPaymentInfo(ctx, refId interface{}) *gomock.Call {
+func (mr *MockClientMockRecorder) GetPaymentInfo(ctx, merchantID, refID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPaymentInfo", reflect.TypeOf((*MockClient)(nil).GetPaymentInfo), ctx, merchantID, refID)
+}
+
+// GetReconciliationReport mocks base method.
+func (m *MockClient) GetReconciliationReport(ctx context.Context, reportID string) (*model.ReconciliationReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReconciliationReport", ctx, reportID)
+	ret0, _ := ret[0].(*model.ReconciliationReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReconciliationReport indicates an expected call of GetReconciliationReport.
+func (mr *MockClientMockRecorder) GetReconciliationReport(ctx, reportID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReconciliationReport", reflect.TypeOf((*MockClient)(nil).GetReconciliationReport), ctx, reportID)
+}
+
+// GetSettlementAmounts mocks base method.
+func (m *MockClient) GetSettlementAmounts(ctx context.Context, acquirer string, refIDs []string) (map[string]money.Money, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSettlementAmounts", ctx, acquirer, refIDs)
+	ret0, _ := ret[0].(map[string]money.Money)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSettlementAmounts indicates an expected call of GetSettlementAmounts.
+func (mr *MockClientMockRecorder) GetSettlementAmounts(ctx, acquirer, refIDs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSettlementAmounts", reflect.TypeOf((*MockClient)(nil).GetSettlementAmounts), ctx, acquirer, refIDs)
+}
+
+// GetVaultedCard mocks base method.
+func (m *MockClient) GetVaultedCard(ctx context.Context, merchantID, token string) (*model.VaultedCard, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetVaultedCard", ctx, merchantID, token)
+	ret0, _ := ret[0].(*model.VaultedCard)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetVaultedCard indicates an expected call of GetVaultedCard.
+func (mr *MockClientMockRecorder) GetVaultedCard(ctx, merchantID, token interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVaultedCard", reflect.TypeOf((*MockClient)(nil).GetVaultedCard), ctx, merchantID, token)
+}
+
+// ListPayments mocks base method.
+func (m *MockClient) ListPayments(ctx context.Context, merchantID string, filter model.PaymentFilter) ([]*protos_payments.GetPaymentResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPayments", ctx, merchantID, filter)
+	ret0, _ := ret[0].([]*protos_payments.GetPaymentResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPayments indicates an expected call of ListPayments.
+func (mr *MockClientMockRecorder) ListPayments(ctx, merchantID, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPayments", reflect.TypeOf((*MockClient)(nil).ListPayments), ctx, merchantID, filter)
+}
+
+// ListWebhookDeliveries mocks base method.
+func (m *MockClient) ListWebhookDeliveries(ctx context.Context, merchantID string, filter model.WebhookDeliveryFilter) ([]*protos_payments.WebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListWebhookDeliveries", ctx, merchantID, filter)
+	ret0, _ := ret[0].([]*protos_payments.WebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListWebhookDeliveries indicates an expected call of ListWebhookDeliveries.
+func (mr *MockClientMockRecorder) ListWebhookDeliveries(ctx, merchantID, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListWebhookDeliveries", reflect.TypeOf((*MockClient)(nil).ListWebhookDeliveries), ctx, merchantID, filter)
+}
+
+// ListenStatusChanges mocks base method.
+func (m *MockClient) ListenStatusChanges(ctx context.Context) (storage.StatusListener, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListenStatusChanges", ctx)
+	ret0, _ := ret[0].(storage.StatusListener)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListenStatusChanges indicates an expected call of ListenStatusChanges.
+func (mr *MockClientMockRecorder) ListenStatusChanges(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListenStatusChanges", reflect.TypeOf((*MockClient)(nil).ListenStatusChanges), ctx)
+}
+
+// RedeliverWebhook mocks base method.
+func (m *MockClient) RedeliverWebhook(ctx context.Context, merchantID string, deliveryID int64) (*protos_payments.WebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RedeliverWebhook", ctx, merchantID, deliveryID)
+	ret0, _ := ret[0].(*protos_payments.WebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RedeliverWebhook indicates an expected call of RedeliverWebhook.
+func (mr *MockClientMockRecorder) RedeliverWebhook(ctx, merchantID, deliveryID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RedeliverWebhook", reflect.TypeOf((*MockClient)(nil).RedeliverWebhook), ctx, merchantID, deliveryID)
+}
+
+// RelayOutbox mocks base method.
+func (m *MockClient) RelayOutbox(ctx context.Context, limit int, publish func([]*model.OutboxEvent) int) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RelayOutbox", ctx, limit, publish)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RelayOutbox indicates an expected call of RelayOutbox.
+func (mr *MockClientMockRecorder) RelayOutbox(ctx, limit, publish interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RelayOutbox", reflect.TypeOf((*MockClient)(nil).RelayOutbox), ctx, limit, publish)
+}
+
+// ReleaseMerchantReference mocks base method.
+func (m *MockClient) ReleaseMerchantReference(ctx context.Context, merchantID, reference string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReleaseMerchantReference", ctx, merchantID, reference)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReleaseMerchantReference indicates an expected call of ReleaseMerchantReference.
+func (mr *MockClientMockRecorder) ReleaseMerchantReference(ctx, merchantID, reference interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReleaseMerchantReference", reflect.TypeOf((*MockClient)(nil).ReleaseMerchantReference), ctx, merchantID, reference)
+}
+
+// ReleaseRefund mocks base method.
+func (m *MockClient) ReleaseRefund(ctx context.Context, refID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReleaseRefund", ctx, refID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReleaseRefund indicates an expected call of ReleaseRefund.
+func (mr *MockClientMockRecorder) ReleaseRefund(ctx, refID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReleaseRefund", reflect.TypeOf((*MockClient)(nil).ReleaseRefund), ctx, refID)
+}
+
+// ReserveMerchantReference mocks base method.
+func (m *MockClient) ReserveMerchantReference(ctx context.Context, merchantID, reference string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReserveMerchantReference", ctx, merchantID, reference)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReserveMerchantReference indicates an expected call of ReserveMerchantReference.
+func (mr *MockClientMockRecorder) ReserveMerchantReference(ctx, merchantID, reference interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReserveMerchantReference", reflect.TypeOf((*MockClient)(nil).ReserveMerchantReference), ctx, merchantID, reference)
+}
+
+// ReserveRefund mocks base method.
+func (m *MockClient) ReserveRefund(ctx context.Context, refID string, amount int64) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReserveRefund", ctx, refID, amount)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReserveRefund indicates an expected call of ReserveRefund.
+func (mr *MockClientMockRecorder) ReserveRefund(ctx, refID, amount interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReserveRefund", reflect.TypeOf((*MockClient)(nil).ReserveRefund), ctx, refID, amount)
+}
+
+// RevokeAPIKey mocks base method.
+func (m *MockClient) RevokeAPIKey(ctx context.Context, keyHash string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeAPIKey", ctx, keyHash)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RevokeAPIKey indicates an expected call of RevokeAPIKey.
+func (mr *MockClientMockRecorder) RevokeAPIKey(ctx, keyHash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeAPIKey", reflect.TypeOf((*MockClient)(nil).RevokeAPIKey), ctx, keyHash)
+}
+
+// SaveIdempotentResponse mocks base method.
+func (m *MockClient) SaveIdempotentResponse(ctx context.Context, merchantID, key string, response *protos_payments.ProcessPaymentResponse) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveIdempotentResponse", ctx, merchantID, key, response)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveIdempotentResponse indicates an expected call of SaveIdempotentResponse.
+func (mr *MockClientMockRecorder) SaveIdempotentResponse(ctx, merchantID, key, response interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveIdempotentResponse", reflect.TypeOf((*MockClient)(nil).SaveIdempotentResponse), ctx, merchantID, key, response)
+}
+
+// UpdateAuthorization mocks base method.
+func (m *MockClient) UpdateAuthorization(ctx context.Context, refID string, auth model.Authorization) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateAuthorization", ctx, refID, auth)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateAuthorization indicates an expected call of UpdateAuthorization.
+func (mr *MockClientMockRecorder) UpdateAuthorization(ctx, refID, auth interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAuthorization", reflect.TypeOf((*MockClient)(nil).UpdateAuthorization), ctx, refID, auth)
+}
+
+// UpdatePaymentStatus mocks base method.
+func (m *MockClient) UpdatePaymentStatus(ctx context.Context, update model.StatusUpdate) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePaymentStatus", ctx, update)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdatePaymentStatus indicates an expected call of UpdatePaymentStatus.
+func (mr *MockClientMockRecorder) UpdatePaymentStatus(ctx, update interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePaymentStatus", reflect.TypeOf((*MockClient)(nil).UpdatePaymentStatus), ctx, update)
+}
+
+// MockStatusListener is a mock of StatusListener interface.
+type MockStatusListener struct {
+	ctrl     *gomock.Controller
+	recorder *MockStatusListenerMockRecorder
+}
+
+// MockStatusListenerMockRecorder is the mock recorder for MockStatusListener.
+type MockStatusListenerMockRecorder struct {
+	mock *MockStatusListener
+}
+
+// NewMockStatusListener creates a new mock instance.
+func NewMockStatusListener(ctrl *gomock.Controller) *MockStatusListener {
+	mock := &MockStatusListener{ctrl: ctrl}
+	mock.recorder = &MockStatusListenerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockStatusListener) EXPECT() *MockStatusListenerMockRecorder {
+	return m.recorder
+}
+
+// Close mocks base method.
+func (m *MockStatusListener) Close() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Close")
+}
+
+// Close indicates an expected call of Close.
+func (mr *MockStatusListenerMockRecorder) Close() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Close", reflect.TypeOf((*MockStatusListener)(nil).Close))
+}
+
+// Next mocks base method.
+func (m *MockStatusListener) Next(ctx context.Context) (*model.StatusChangeEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Next", ctx)
+	ret0, _ := ret[0].(*model.StatusChangeEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Next indicates an expected call of Next.
+func (mr *MockStatusListenerMockRecorder) Next(ctx interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPaymentInfo", reflect.TypeOf((*MockClient)(nil).GetPaymentInfo), ctx, refId)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Next", reflect.TypeOf((*MockStatusListener)(nil).Next), ctx)
 }
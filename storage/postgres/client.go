@@ -11,12 +11,17 @@ import (
 	log "github.com/sirupsen/logrus"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
+	"payments_gateway/model"
+	"payments_gateway/money"
 	protos "payments_gateway/protos"
+	"payments_gateway/statemachine"
 	"payments_gateway/storage"
 )
 
 type PgPool interface {
 	pgxtype.Querier
+	BeginFunc(ctx context.Context, f func(pgx.Tx) error) error
+	Config() *pgxpool.Config
 	Close()
 }
 
@@ -28,43 +33,108 @@ var _ storage.Client = (*PgxStorage)(nil)
 
 var (
 	errInsertingPaymentInfo = errors.New("error inserting payment info")
+	errMissingStatusSource  = errors.New("status update has no source")
 )
 
 func New(pool PgPool) *PgxStorage {
 	return &PgxStorage{pool: pool}
 }
 
-// AddPaymentInfo adds payment information from the transactions to the DB
-func (p *PgxStorage) AddPaymentInfo(ctx context.Context, refID string, request *protos.ProcessPaymentRequest, status protos.Status, reason string) error {
+// AddPaymentInfo adds payment information of a merchant from the transactions to the DB
+func (p *PgxStorage) AddPaymentInfo(ctx context.Context, merchantID, refID string, request *protos.ProcessPaymentRequest, amount money.Money, auth model.Authorization) error {
+	if err := statemachine.Transition(statemachine.New, auth.Status); err != nil {
+		return err
+	}
+
 	maskedCard := maskCardNumber(request.GetCardNumber(), 'X')
 
-	_, err := p.pool.Exec(ctx,
-		_insertPaymentInfo,
-		convertStringToPgType(refID),
-		convertStringToPgType(request.GetBillingDetails().GetName()),
-		convertStringToPgType(request.GetBillingDetails().GetSurname()),
-		convertStringToPgType(request.GetBillingDetails().GetEmail()),
-		convertStringToPgType(request.GetBillingDetails().GetPhone()),
-		convertStringToPgType(request.GetBillingDetails().GetAddressLine_1()),
-		convertStringToPgType(request.GetBillingDetails().GetAddressLine_2()),
-		convertStringToPgType(request.GetBillingDetails().GetPostcode()),
-		convertStringToPgType(maskedCard),
-		convertStringToPgType(request.GetCurrency()),
-		convertFloatToPgType(request.GetAmount()),
-		convertEnumToPgType(request.GetPaymentType()),
-		convertEnumToPgType(status),
-		convertStringToPgType(reason),
-	)
+	return p.pool.BeginFunc(ctx, func(tx pgx.Tx) error {
+		tag, err := tx.Exec(ctx,
+			_insertPaymentInfo,
+			convertStringToPgType(refID),
+			convertStringToPgType(request.GetBillingDetails().GetName()),
+			convertStringToPgType(request.GetBillingDetails().GetSurname()),
+			convertStringToPgType(request.GetBillingDetails().GetEmail()),
+			convertStringToPgType(request.GetBillingDetails().GetPhone()),
+			convertStringToPgType(request.GetBillingDetails().GetAddressLine_1()),
+			convertStringToPgType(request.GetBillingDetails().GetAddressLine_2()),
+			convertStringToPgType(request.GetBillingDetails().GetPostcode()),
+			convertStringToPgType(maskedCard),
+			convertStringToPgType(amount.Currency),
+			convertInt64ToPgType(amount.Amount),
+			convertEnumToPgType(request.GetPaymentType()),
+			convertEnumToPgType(auth.Status),
+			convertStringToPgType(auth.Reason),
+			convertStringToPgType(request.GetCardToken()),
+			convertStringToPgType(auth.Acquirer),
+			convertStringToPgType(auth.ResponseCode),
+			convertMerchantToPgType(merchantID),
+			convertStringToPgType(request.GetMerchantReference()),
+			convertMetadataToPgType(request.GetMetadata()),
+		)
+
+		if err != nil {
+			return err
+		}
 
-	if err != nil {
-		return err
+		// the payment already exists so its history has already been started
+		if tag.RowsAffected() == 0 {
+			return nil
+		}
+
+		return insertStatusHistory(ctx, tx, model.StatusUpdate{
+			RefID:  refID,
+			Status: auth.Status,
+			Reason: auth.Reason,
+			Source: authorizationSource(auth),
+		}, statemachine.New)
+	})
+}
+
+// UpdateAuthorization stores the outcome of authorizing a payment stored as AUTHORIZING: its status,
+// reason and response code, and the acquirer that authorized it. Only the acquirer is updated when
+// auth.Status is still AUTHORIZING, as when the payment fails over before it is sent
+func (p *PgxStorage) UpdateAuthorization(ctx context.Context, refID string, auth model.Authorization) error {
+	return p.pool.BeginFunc(ctx, func(tx pgx.Tx) error {
+		tag, err := tx.Exec(ctx,
+			_updateAuthorization,
+			convertStringToPgType(refID),
+			convertStringToPgType(auth.Acquirer),
+			convertStringToPgType(auth.ResponseCode),
+		)
+		if err != nil {
+			return err
+		}
+
+		if tag.RowsAffected() == 0 {
+			return storage.ErrNotAuthorizing
+		}
+
+		if auth.Status == protos.Status_AUTHORIZING {
+			return nil
+		}
+
+		return updatePaymentStatus(ctx, tx, model.StatusUpdate{
+			RefID:  refID,
+			Status: auth.Status,
+			Reason: auth.Reason,
+			Source: authorizationSource(auth),
+		})
+	})
+}
+
+// authorizationSource is who set the status of an authorization, the acquirer unless stated otherwise
+func authorizationSource(auth model.Authorization) protos.StatusSource {
+	if auth.Source == protos.StatusSource_UNSPECIFIED_SOURCE {
+		return protos.StatusSource_BANK
 	}
 
-	return nil
+	return auth.Source
 }
 
-// GetPaymentInfo retrieves payment information from the transactions to the DB
-func (p *PgxStorage) GetPaymentInfo(ctx context.Context, referenceId string) (*protos.GetPaymentResponse, error) {
+// GetPaymentInfo retrieves payment information from the transactions to the DB, payments of
+// other merchants do not exist for the merchant
+func (p *PgxStorage) GetPaymentInfo(ctx context.Context, merchantID, referenceId string) (*protos.GetPaymentResponse, error) {
 	id := pgtype.Text{}
 
 	err := id.Set(referenceId)
@@ -72,33 +142,69 @@ func (p *PgxStorage) GetPaymentInfo(ctx context.Context, referenceId string) (*p
 		return nil, err
 	}
 
-	result := p.pool.QueryRow(ctx, _getPaymentInfo, id)
+	return getPayment(p.pool.QueryRow(ctx, _getPaymentInfo, id, convertMerchantToPgType(merchantID)))
+}
 
-	var refId, name, surname, email, phone, address1, address2, postcode, cardNo, currency, status_reason pgtype.Varchar
+// GetPaymentByReference retrieves the latest payment of a merchant made with a merchant reference
+func (p *PgxStorage) GetPaymentByReference(ctx context.Context, merchantID, reference string) (*protos.GetPaymentResponse, error) {
+	return getPayment(p.pool.QueryRow(ctx, _getPaymentByReference, convertStringToPgType(reference), convertMerchantToPgType(merchantID)))
+}
 
-	var amount pgtype.Float8
+// getPayment reads a single payment, a payment that does not exist has the UNKNOWN status
+func getPayment(row pgx.Row) (*protos.GetPaymentResponse, error) {
+	resp, err := scanPayment(row)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return &protos.GetPaymentResponse{Status: protos.Status_UNKNOWN, PaymentType: protos.PaymentType_UNDEFINED, StatusReason: "transaction does not exist"}, nil
+		}
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// scanPayment reads a payment selected with the columns of _getPaymentInfo
+func scanPayment(row pgx.Row) (*protos.GetPaymentResponse, error) {
+	var refId, name, surname, email, phone, address1, address2, postcode, cardNo, currency, status_reason, cardToken, acquirer, responseCode, settlementBatchID, settlementFailure, merchantReference pgtype.Varchar
+
+	var amount, capturedAmount, refundedAmount pgtype.Int8
 
 	var status, paymentType pgtype.Varchar
 
 	var insertTime, updatedTime pgtype.Timestamp
 
-	if err := result.Scan(&refId, &name, &surname, &email, &phone, &address1, &address2, &postcode, &cardNo, &currency, &amount, &paymentType, &status, &status_reason, &insertTime, &updatedTime); err != nil {
-		if err.Error() == "no rows in result set" {
-			return &protos.GetPaymentResponse{Ref: refId.String, Status: protos.Status_UNKNOWN, PaymentType: protos.PaymentType_UNDEFINED, StatusReason: "transaction does not exist"}, nil
-		}
+	var metadata map[string]string
+
+	if err := row.Scan(&refId, &name, &surname, &email, &phone, &address1, &address2, &postcode, &cardNo, &currency, &amount, &paymentType, &status, &status_reason, &insertTime, &updatedTime, &capturedAmount, &refundedAmount, &cardToken, &acquirer, &responseCode, &settlementBatchID, &settlementFailure, &merchantReference, &metadata); err != nil {
 		return nil, err
 	}
 
+	if len(metadata) == 0 {
+		metadata = nil
+	}
+
 	return &protos.GetPaymentResponse{
-		Ref:              refId.String,
-		CardNumber:       cardNo.String,
-		Amount:           amount.Float,
-		Currency:         currency.String,
-		PaymentType:      protos.PaymentType(protos.PaymentType_value[paymentType.String]),
-		Status:           protos.Status(protos.Status_value[status.String]),
-		StatusReason:     status_reason.String,
-		UpdatedTimestamp: timestamppb.New(updatedTime.Time),
-		InsertTimestamp:  timestamppb.New(insertTime.Time),
+		Ref:                      refId.String,
+		CardNumber:               cardNo.String,
+		Amount:                   money.New(amount.Int, currency.String).Major(),
+		AmountMinorUnits:         amount.Int,
+		Currency:                 currency.String,
+		PaymentType:              protos.PaymentType(protos.PaymentType_value[paymentType.String]),
+		Status:                   protos.Status(protos.Status_value[status.String]),
+		StatusReason:             status_reason.String,
+		UpdatedTimestamp:         timestamppb.New(updatedTime.Time),
+		InsertTimestamp:          timestamppb.New(insertTime.Time),
+		CapturedAmount:           money.New(capturedAmount.Int, currency.String).Major(),
+		RefundedAmount:           money.New(refundedAmount.Int, currency.String).Major(),
+		CapturedAmountMinorUnits: capturedAmount.Int,
+		RefundedAmountMinorUnits: refundedAmount.Int,
+		CardToken:                cardToken.String,
+		Acquirer:                 acquirer.String,
+		ResponseCode:             responseCode.String,
+		SettlementBatchId:        settlementBatchID.String,
+		SettlementFailureReason:  settlementFailure.String,
+		MerchantReference:        merchantReference.String,
+		Metadata:                 metadata,
 		BillingDetails: &protos.BillingDetails{
 			Name:          name.String,
 			Surname:       surname.String,
@@ -112,6 +218,190 @@ func (p *PgxStorage) GetPaymentInfo(ctx context.Context, referenceId string) (*p
 
 }
 
+// UpdatePaymentStatus moves a stored payment to a new status and adds any captured
+// or refunded amount to the payment totals. The current status is locked while the
+// transition is checked so concurrent updates cannot skip a state
+func (p *PgxStorage) UpdatePaymentStatus(ctx context.Context, update model.StatusUpdate) error {
+	if update.Source == protos.StatusSource_UNSPECIFIED_SOURCE {
+		return errMissingStatusSource
+	}
+
+	return p.pool.BeginFunc(ctx, func(tx pgx.Tx) error {
+		return updatePaymentStatus(ctx, tx, update)
+	})
+}
+
+// updatePaymentStatus locks the current status and amounts of a payment, checks the transition and
+// that refunds never exceed the captured amount, and stores the update and its history within tx
+func updatePaymentStatus(ctx context.Context, tx pgx.Tx, update model.StatusUpdate) error {
+	var current pgtype.Varchar
+
+	var captured, refunded pgtype.Int8
+
+	if err := tx.QueryRow(ctx, _lockPaymentStatus, convertStringToPgType(update.RefID)).Scan(&current, &captured, &refunded); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return storage.ErrPaymentNotFound
+		}
+
+		return err
+	}
+
+	currentStatus := protos.Status(protos.Status_value[current.String])
+
+	if err := statemachine.Transition(currentStatus, update.Status); err != nil {
+		return err
+	}
+
+	// the refundable amount checked before the refund was sent may have been refunded concurrently
+	if refunded.Int+update.RefundedAmount > captured.Int+update.CapturedAmount {
+		return storage.ErrRefundExceedsCaptured
+	}
+
+	_, err := tx.Exec(ctx,
+		_updatePaymentStatus,
+		convertStringToPgType(update.RefID),
+		convertEnumToPgType(update.Status),
+		convertStringToPgType(update.Reason),
+		pgtype.Int8{Int: update.CapturedAmount, Status: pgtype.Present},
+		pgtype.Int8{Int: update.RefundedAmount, Status: pgtype.Present},
+	)
+
+	if err != nil {
+		return err
+	}
+
+	return insertStatusHistory(ctx, tx, update, currentStatus)
+}
+
+// ReserveRefund reserves amount minor units of a payment for a refund about to be sent to its acquirer
+// and returns the amount refunded so far. It fails with storage.ErrRefundInProgress while another refund
+// is reserved and storage.ErrRefundExceedsCaptured when the refunds would exceed the captured amount.
+// Storing the refund with UpdatePaymentStatus releases the reservation
+func (p *PgxStorage) ReserveRefund(ctx context.Context, refID string, amount int64) (int64, error) {
+	var refunded pgtype.Int8
+
+	err := p.pool.BeginFunc(ctx, func(tx pgx.Tx) error {
+		var current pgtype.Varchar
+
+		var captured, pending pgtype.Int8
+
+		if err := tx.QueryRow(ctx, _lockPaymentRefund, convertStringToPgType(refID)).Scan(&current, &captured, &refunded, &pending); err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return storage.ErrPaymentNotFound
+			}
+
+			return err
+		}
+
+		// partial and full refunds are allowed from the same statuses
+		if err := statemachine.Transition(protos.Status(protos.Status_value[current.String]), protos.Status_REFUNDED); err != nil {
+			return err
+		}
+
+		if pending.Status == pgtype.Present {
+			return storage.ErrRefundInProgress
+		}
+
+		if refunded.Int+amount > captured.Int {
+			return storage.ErrRefundExceedsCaptured
+		}
+
+		_, err := tx.Exec(ctx, _reserveRefund, convertStringToPgType(refID), pgtype.Int8{Int: amount, Status: pgtype.Present})
+
+		return err
+	})
+
+	return refunded.Int, err
+}
+
+// ReleaseRefund releases the refund reserved for a payment, e.g. because its acquirer declined it
+func (p *PgxStorage) ReleaseRefund(ctx context.Context, refID string) error {
+	_, err := p.pool.Exec(ctx, _releaseRefund, convertStringToPgType(refID))
+
+	return err
+}
+
+// GetPaymentHistory retrieves every status change of a payment of a merchant, oldest first
+func (p *PgxStorage) GetPaymentHistory(ctx context.Context, merchantID, refID string) ([]*protos.PaymentStatusChange, error) {
+	rows, err := p.pool.Query(ctx, _getStatusHistory, convertStringToPgType(refID), convertMerchantToPgType(merchantID))
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	history := make([]*protos.PaymentStatusChange, 0)
+
+	for rows.Next() {
+		var oldStatus, newStatus, reason, source pgtype.Varchar
+
+		var insertTime pgtype.Timestamp
+
+		if err := rows.Scan(&oldStatus, &newStatus, &reason, &source, &insertTime); err != nil {
+			return nil, err
+		}
+
+		history = append(history, &protos.PaymentStatusChange{
+			OldStatus: protos.Status(protos.Status_value[oldStatus.String]),
+			NewStatus: protos.Status(protos.Status_value[newStatus.String]),
+			Reason:    reason.String,
+			Source:    protos.StatusSource(protos.StatusSource_value[source.String]),
+			Timestamp: timestamppb.New(insertTime.Time),
+		})
+	}
+
+	return history, rows.Err()
+}
+
+// insertStatusHistory appends a status change to the payment history, a payment being
+// created has no previous status. The change is queued for the webhook endpoints of the
+// merchant, written to the outbox and notified to the watchers in the same transaction so no
+// change is stored without being sent
+func insertStatusHistory(ctx context.Context, tx pgx.Tx, update model.StatusUpdate, previous protos.Status) error {
+	oldStatus := convertEnumToPgType(previous)
+	if previous == statemachine.New {
+		oldStatus.Status = pgtype.Null
+	}
+
+	var historyID pgtype.Int8
+
+	if err := tx.QueryRow(ctx,
+		_insertStatusHistory,
+		convertStringToPgType(update.RefID),
+		oldStatus,
+		convertEnumToPgType(update.Status),
+		convertStringToPgType(update.Reason),
+		convertEnumToPgType(update.Source),
+	).Scan(&historyID); err != nil {
+		return err
+	}
+
+	if _, err := tx.Exec(ctx, _insertWebhookDeliveries, historyID, convertStringToPgType(update.RefID), convertEnumToPgType(update.Status)); err != nil {
+		return err
+	}
+
+	eventType := model.EventPaymentStatusChanged
+	if previous == statemachine.New {
+		eventType = model.EventPaymentCreated
+	}
+
+	if _, err := tx.Exec(ctx,
+		_insertOutboxEvent,
+		convertStringToPgType(update.RefID),
+		convertStringToPgType(eventType),
+		historyID,
+		oldStatus,
+		convertStringToPgType(update.Reason),
+		convertEnumToPgType(update.Source),
+	); err != nil {
+		return err
+	}
+
+	_, err := tx.Exec(ctx, _notifyStatusChange, historyID)
+
+	return err
+}
+
 // CreatePgPool a pgx connection pool to connect and perform operations on the DB
 func CreatePgPool(ctx context.Context, postgresURL string, poolMaxConnections int, poolMinConnections int) (PgPool, error) {
 	log.WithFields(
@@ -158,8 +448,8 @@ func convertStringToPgType(value string) pgtype.Varchar {
 	return pgValue
 }
 
-func convertFloatToPgType(value float64) pgtype.Float8 {
-	pgValue := pgtype.Float8{Float: value, Status: pgtype.Present}
+func convertInt64ToPgType(value int64) pgtype.Int8 {
+	pgValue := pgtype.Int8{Int: value, Status: pgtype.Present}
 	if value == 0 {
 		pgValue.Status = pgtype.Null
 	}
@@ -167,6 +457,24 @@ func convertFloatToPgType(value float64) pgtype.Float8 {
 	return pgValue
 }
 
+// convertMerchantToPgType converts a merchant ID, unlike other strings an empty ID is not NULL
+// as it is the merchant of payments made before merchants existed
+func convertMerchantToPgType(merchantID string) pgtype.Varchar {
+	return pgtype.Varchar{String: merchantID, Status: pgtype.Present}
+}
+
+// convertMetadataToPgType converts the metadata of a payment, payments without any have an empty object
+func convertMetadataToPgType(metadata map[string]string) pgtype.JSONB {
+	if metadata == nil {
+		metadata = map[string]string{}
+	}
+
+	pgValue := pgtype.JSONB{}
+	_ = pgValue.Set(metadata)
+
+	return pgValue
+}
+
 func convertEnumToPgType(value fmt.Stringer) pgtype.Varchar {
 	return pgtype.Varchar{
 		String: value.String(),
@@ -25,7 +25,7 @@ func Test_maskCardNumber(t *testing.T) {
 			want: "3782XXXXXXX0005",
 		},
 		{
-			name: "card has less than 6 digits", // TODO need this in validation of the request
+			name: "card has less than 6 digits", // rejected by card.Validate before a payment is stored
 			args: args{
 				in: "378005",
 				r:  'X',
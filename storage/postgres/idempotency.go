@@ -0,0 +1,91 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgtype"
+	"github.com/jackc/pgx/v4"
+	"google.golang.org/protobuf/proto"
+
+	"payments_gateway/model"
+	protos "payments_gateway/protos"
+)
+
+// CreateIdempotencyKey reserves an idempotency key for a merchant, it reports false
+// when the key has already been used
+func (p *PgxStorage) CreateIdempotencyKey(ctx context.Context, merchantID, key, requestHash string) (bool, error) {
+	tag, err := p.pool.Exec(ctx,
+		_insertIdempotencyKey,
+		convertMerchantToPgType(merchantID),
+		convertStringToPgType(key),
+		convertStringToPgType(requestHash),
+	)
+
+	if err != nil {
+		return false, err
+	}
+
+	return tag.RowsAffected() == 1, nil
+}
+
+// GetIdempotencyKey retrieves the request made with an idempotency key, nil is returned when the key is unused
+func (p *PgxStorage) GetIdempotencyKey(ctx context.Context, merchantID, key string) (*model.IdempotencyRecord, error) {
+	var requestHash pgtype.Varchar
+
+	var response pgtype.Bytea
+
+	err := p.pool.QueryRow(ctx, _getIdempotencyKey, convertMerchantToPgType(merchantID), convertStringToPgType(key)).
+		Scan(&requestHash, &response)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	record := &model.IdempotencyRecord{
+		MerchantID:  merchantID,
+		Key:         key,
+		RequestHash: requestHash.String,
+	}
+
+	if response.Status == pgtype.Present {
+		record.Response = &protos.ProcessPaymentResponse{}
+		if err := proto.Unmarshal(response.Bytes, record.Response); err != nil {
+			return nil, err
+		}
+	}
+
+	return record, nil
+}
+
+// SaveIdempotentResponse stores the response returned for the request made with an idempotency key
+func (p *PgxStorage) SaveIdempotentResponse(ctx context.Context, merchantID, key string, response *protos.ProcessPaymentResponse) error {
+	body, err := proto.Marshal(response)
+	if err != nil {
+		return err
+	}
+
+	_, err = p.pool.Exec(ctx,
+		_updateIdempotentResponse,
+		convertMerchantToPgType(merchantID),
+		convertStringToPgType(key),
+		pgtype.Bytea{Bytes: body, Status: pgtype.Present},
+	)
+
+	return err
+}
+
+// DeleteIdempotencyKey releases an idempotency key whose request failed so it can be retried.
+// Keys that already have a response are kept
+func (p *PgxStorage) DeleteIdempotencyKey(ctx context.Context, merchantID, key string) error {
+	_, err := p.pool.Exec(ctx,
+		_deleteIdempotencyKey,
+		convertMerchantToPgType(merchantID),
+		convertStringToPgType(key),
+	)
+
+	return err
+}
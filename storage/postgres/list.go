@@ -0,0 +1,128 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgtype"
+
+	"payments_gateway/model"
+	protos "payments_gateway/protos"
+)
+
+// ListPayments returns the payments of a merchant matching a filter, in the order of the filter and
+// starting after its cursor
+func (p *PgxStorage) ListPayments(ctx context.Context, merchantID string, filter model.PaymentFilter) ([]*protos.GetPaymentResponse, error) {
+	query, args := listPaymentsQuery(merchantID, filter)
+
+	rows, err := p.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var payments []*protos.GetPaymentResponse
+
+	for rows.Next() {
+		payment, err := scanPayment(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		payments = append(payments, payment)
+	}
+
+	return payments, rows.Err()
+}
+
+// listPaymentsQuery adds a condition to _listPayments for every filter that is set. The cursor is
+// compared as a row so the payment_details_merchant_insert_idx index serves every page
+func listPaymentsQuery(merchantID string, filter model.PaymentFilter) (string, []interface{}) {
+	args := []interface{}{convertMerchantToPgType(merchantID)}
+
+	var query strings.Builder
+
+	query.WriteString(_listPayments)
+
+	where := func(condition string, values ...interface{}) {
+		placeholders := make([]interface{}, len(values))
+		for i, value := range values {
+			args = append(args, value)
+			placeholders[i] = fmt.Sprintf("$%d", len(args))
+		}
+
+		query.WriteString("AND ")
+		query.WriteString(fmt.Sprintf(condition, placeholders...))
+		query.WriteString("\n")
+	}
+
+	if len(filter.Statuses) > 0 {
+		statuses := make([]string, 0, len(filter.Statuses))
+		for _, status := range filter.Statuses {
+			statuses = append(statuses, status.String())
+		}
+
+		var pgStatuses pgtype.VarcharArray
+		_ = pgStatuses.Set(statuses)
+
+		where("status = ANY(%s::varchar[]::payment_status[])", pgStatuses)
+	}
+
+	if !filter.From.IsZero() {
+		where("insert_timestamp >= %s", convertTimeToPgType(filter.From))
+	}
+
+	if !filter.To.IsZero() {
+		where("insert_timestamp < %s", convertTimeToPgType(filter.To))
+	}
+
+	if filter.Currency != "" {
+		where("currency = %s", convertStringToPgType(filter.Currency))
+	}
+
+	if filter.MinAmount != 0 {
+		where("amount_minor_units >= %s", convertInt64ToPgType(filter.MinAmount))
+	}
+
+	if filter.MaxAmount != 0 {
+		where("amount_minor_units <= %s", convertInt64ToPgType(filter.MaxAmount))
+	}
+
+	if filter.CardLastFour != "" {
+		where("right(card_number, 4) = %s", convertStringToPgType(filter.CardLastFour))
+	}
+
+	if filter.Email != "" {
+		where("lower(email) = lower(%s)", convertStringToPgType(filter.Email))
+	}
+
+	if filter.Reference != "" {
+		where("merchant_reference = %s", convertStringToPgType(filter.Reference))
+	}
+
+	if len(filter.Metadata) > 0 {
+		where("metadata @> %s", convertMetadataToPgType(filter.Metadata))
+	}
+
+	order, comparison := "DESC", "<"
+	if filter.OldestFirst {
+		order, comparison = "ASC", ">"
+	}
+
+	if filter.After != nil {
+		where("(insert_timestamp, ref_id) "+comparison+" (%s, %s)", convertTimeToPgType(filter.After.InsertTime), convertStringToPgType(filter.After.RefID))
+	}
+
+	args = append(args, filter.Limit)
+	query.WriteString(fmt.Sprintf("ORDER BY insert_timestamp %s, ref_id %s\nLIMIT $%d", order, order, len(args)))
+
+	return query.String(), args
+}
+
+// convertTimeToPgType converts a time to the timestamps without time zone payments are stored with,
+// the database runs in UTC
+func convertTimeToPgType(value time.Time) pgtype.Timestamp {
+	return pgtype.Timestamp{Time: value.UTC(), Status: pgtype.Present}
+}
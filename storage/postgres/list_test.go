@@ -0,0 +1,94 @@
+package postgres
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jackc/pgtype"
+	"github.com/stretchr/testify/assert"
+
+	"payments_gateway/model"
+	protos "payments_gateway/protos"
+)
+
+func Test_listPaymentsQuery(t *testing.T) {
+	insertTime := time.Date(2022, 5, 1, 6, 0, 0, 0, time.UTC)
+
+	statuses := pgtype.VarcharArray{}
+	_ = statuses.Set([]string{"APPROVED", "CAPTURED"})
+
+	tests := []struct {
+		name       string
+		filter     model.PaymentFilter
+		conditions string
+		args       []interface{}
+	}{
+		{
+			name:       "no filters",
+			filter:     model.PaymentFilter{Limit: 51},
+			conditions: "ORDER BY insert_timestamp DESC, ref_id DESC\nLIMIT $2",
+			args:       []interface{}{convertMerchantToPgType("merchant-1"), 51},
+		},
+		{
+			name: "every filter",
+			filter: model.PaymentFilter{
+				Statuses:     []protos.Status{protos.Status_APPROVED, protos.Status_CAPTURED},
+				From:         insertTime,
+				To:           insertTime.Add(24 * time.Hour),
+				Currency:     "GBP",
+				MinAmount:    100,
+				MaxAmount:    5000,
+				CardLastFour: "0005",
+				Email:        "iam@batman.com",
+				Reference:    "order-1234",
+				Metadata:     map[string]string{"channel": "web"},
+				Limit:        11,
+			},
+			conditions: "AND status = ANY($2::varchar[]::payment_status[])\n" +
+				"AND insert_timestamp >= $3\n" +
+				"AND insert_timestamp < $4\n" +
+				"AND currency = $5\n" +
+				"AND amount_minor_units >= $6\n" +
+				"AND amount_minor_units <= $7\n" +
+				"AND right(card_number, 4) = $8\n" +
+				"AND lower(email) = lower($9)\n" +
+				"AND merchant_reference = $10\n" +
+				"AND metadata @> $11\n" +
+				"ORDER BY insert_timestamp DESC, ref_id DESC\nLIMIT $12",
+			args: []interface{}{
+				convertMerchantToPgType("merchant-1"),
+				statuses,
+				convertTimeToPgType(insertTime),
+				convertTimeToPgType(insertTime.Add(24 * time.Hour)),
+				convertStringToPgType("GBP"),
+				convertInt64ToPgType(100),
+				convertInt64ToPgType(5000),
+				convertStringToPgType("0005"),
+				convertStringToPgType("iam@batman.com"),
+				convertStringToPgType("order-1234"),
+				convertMetadataToPgType(map[string]string{"channel": "web"}),
+				11,
+			},
+		},
+		{
+			name:       "next page of the newest payments",
+			filter:     model.PaymentFilter{After: &model.PaymentCursor{InsertTime: insertTime, RefID: "ref1"}, Limit: 51},
+			conditions: "AND (insert_timestamp, ref_id) < ($2, $3)\nORDER BY insert_timestamp DESC, ref_id DESC\nLIMIT $4",
+			args:       []interface{}{convertMerchantToPgType("merchant-1"), convertTimeToPgType(insertTime), convertStringToPgType("ref1"), 51},
+		},
+		{
+			name:       "next page of the oldest payments",
+			filter:     model.PaymentFilter{Currency: "GBP", OldestFirst: true, After: &model.PaymentCursor{InsertTime: insertTime, RefID: "ref1"}, Limit: 51},
+			conditions: "AND currency = $2\nAND (insert_timestamp, ref_id) > ($3, $4)\nORDER BY insert_timestamp ASC, ref_id ASC\nLIMIT $5",
+			args:       []interface{}{convertMerchantToPgType("merchant-1"), convertStringToPgType("GBP"), convertTimeToPgType(insertTime), convertStringToPgType("ref1"), 51},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query, args := listPaymentsQuery("merchant-1", tt.filter)
+
+			assert.Equal(t, _listPayments+tt.conditions, query)
+			assert.Equal(t, tt.args, args)
+		})
+	}
+}
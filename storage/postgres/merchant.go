@@ -0,0 +1,91 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgtype"
+	"github.com/jackc/pgx/v4"
+
+	"payments_gateway/model"
+)
+
+// AddMerchant stores a new merchant
+func (p *PgxStorage) AddMerchant(ctx context.Context, merchant model.Merchant) error {
+	_, err := p.pool.Exec(ctx,
+		_insertMerchant,
+		convertStringToPgType(merchant.ID),
+		convertStringToPgType(merchant.Name),
+		pgtype.Bool{Bool: merchant.Operator, Status: pgtype.Present},
+	)
+
+	return err
+}
+
+// AddAPIKey stores the hash of a new API key of a merchant
+func (p *PgxStorage) AddAPIKey(ctx context.Context, merchantID, keyHash string) error {
+	_, err := p.pool.Exec(ctx, _insertAPIKey, convertStringToPgType(keyHash), convertStringToPgType(merchantID))
+
+	return err
+}
+
+// RevokeAPIKey stops an API key from authenticating its merchant, it reports false when no
+// unrevoked key has the hash
+func (p *PgxStorage) RevokeAPIKey(ctx context.Context, keyHash string) (bool, error) {
+	tag, err := p.pool.Exec(ctx, _revokeAPIKey, convertStringToPgType(keyHash))
+	if err != nil {
+		return false, err
+	}
+
+	return tag.RowsAffected() == 1, nil
+}
+
+// GetMerchantByAPIKey returns the merchant of an API key hash, nil is returned when no unrevoked
+// key has the hash
+func (p *PgxStorage) GetMerchantByAPIKey(ctx context.Context, keyHash string) (*model.Merchant, error) {
+	return scanMerchant(p.pool.QueryRow(ctx, _getMerchantByAPIKey, convertStringToPgType(keyHash)))
+}
+
+// GetMerchant returns a merchant by ID, nil is returned when it does not exist
+func (p *PgxStorage) GetMerchant(ctx context.Context, merchantID string) (*model.Merchant, error) {
+	return scanMerchant(p.pool.QueryRow(ctx, _getMerchant, convertStringToPgType(merchantID)))
+}
+
+func scanMerchant(row pgx.Row) (*model.Merchant, error) {
+	var merchantID, name pgtype.Varchar
+
+	var operator pgtype.Bool
+
+	err := row.Scan(&merchantID, &name, &operator)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return &model.Merchant{
+		ID:       merchantID.String,
+		Name:     name.String,
+		Operator: operator.Bool,
+	}, nil
+}
+
+// ReserveMerchantReference reserves a merchant reference for a new payment, it reports false when
+// the merchant already used the reference
+func (p *PgxStorage) ReserveMerchantReference(ctx context.Context, merchantID, reference string) (bool, error) {
+	tag, err := p.pool.Exec(ctx, _insertMerchantReference, convertMerchantToPgType(merchantID), convertStringToPgType(reference))
+	if err != nil {
+		return false, err
+	}
+
+	return tag.RowsAffected() == 1, nil
+}
+
+// ReleaseMerchantReference frees the reference of a payment that was not made
+func (p *PgxStorage) ReleaseMerchantReference(ctx context.Context, merchantID, reference string) error {
+	_, err := p.pool.Exec(ctx, _deleteMerchantReference, convertMerchantToPgType(merchantID), convertStringToPgType(reference))
+
+	return err
+}
@@ -0,0 +1,102 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/jackc/pgtype"
+	"github.com/jackc/pgx/v4"
+
+	"payments_gateway/model"
+	"payments_gateway/storage"
+)
+
+// RelayOutbox hands up to limit unpublished outbox events to publish, oldest first, and marks the
+// first events publish reports it published. Events are locked for a single relay at a time so the
+// events of a payment are never published out of order, storage.ErrOutboxLocked is returned while
+// another relay holds the lock. An event published before its mark is committed is published again
+func (p *PgxStorage) RelayOutbox(ctx context.Context, limit int, publish func(events []*model.OutboxEvent) int) (int, error) {
+	var published int
+
+	err := p.pool.BeginFunc(ctx, func(tx pgx.Tx) error {
+		published = 0
+
+		var locked pgtype.Bool
+
+		if err := tx.QueryRow(ctx, _lockOutboxRelay).Scan(&locked); err != nil {
+			return err
+		}
+
+		if !locked.Bool {
+			return storage.ErrOutboxLocked
+		}
+
+		events, err := unpublishedOutboxEvents(ctx, tx, limit)
+		if err != nil {
+			return err
+		}
+
+		if len(events) == 0 {
+			return nil
+		}
+
+		published = publish(events)
+		if published > len(events) {
+			published = len(events)
+		}
+
+		if published <= 0 {
+			published = 0
+
+			return nil
+		}
+
+		ids := make([]int64, 0, published)
+		for _, event := range events[:published] {
+			ids = append(ids, event.ID)
+		}
+
+		var pgIDs pgtype.Int8Array
+		if err := pgIDs.Set(ids); err != nil {
+			return err
+		}
+
+		_, err = tx.Exec(ctx, _markOutboxEventsPublished, pgIDs)
+
+		return err
+	})
+
+	return published, err
+}
+
+func unpublishedOutboxEvents(ctx context.Context, tx pgx.Tx, limit int) ([]*model.OutboxEvent, error) {
+	rows, err := tx.Query(ctx, _getUnpublishedOutboxEvents, pgtype.Int8{Int: int64(limit), Status: pgtype.Present})
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	var events []*model.OutboxEvent
+
+	for rows.Next() {
+		var id pgtype.Int8
+
+		var key, eventType, payload pgtype.Varchar
+
+		var insertTime pgtype.Timestamp
+
+		if err := rows.Scan(&id, &key, &eventType, &payload, &insertTime); err != nil {
+			return nil, err
+		}
+
+		events = append(events, &model.OutboxEvent{
+			ID:      id.Int,
+			Key:     key.String,
+			Type:    eventType.String,
+			Payload: []byte(payload.String),
+			Time:    insertTime.Time,
+		})
+	}
+
+	return events, rows.Err()
+}
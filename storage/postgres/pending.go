@@ -0,0 +1,57 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgtype"
+
+	"payments_gateway/model"
+	"payments_gateway/money"
+	protos "payments_gateway/protos"
+)
+
+// ClaimPendingPayments claims up to limit payments that have been PENDING or UNKNOWN_RESPONSE_CODE for
+// at least minAge or AUTHORIZING for at least reverseAfter, and were not claimed within inquiryInterval, oldest first.
+// Payments inserted more than maxAge ago are marked expired
+func (p *PgxStorage) ClaimPendingPayments(ctx context.Context, minAge, reverseAfter, inquiryInterval, maxAge time.Duration, limit int) ([]*model.PendingPayment, error) {
+	rows, err := p.pool.Query(ctx, _claimPendingPayments,
+		pgtype.Int8{Int: int64(limit), Status: pgtype.Present},
+		pgtype.Int8{Int: minAge.Milliseconds(), Status: pgtype.Present},
+		pgtype.Int8{Int: reverseAfter.Milliseconds(), Status: pgtype.Present},
+		pgtype.Int8{Int: inquiryInterval.Milliseconds(), Status: pgtype.Present},
+		pgtype.Int8{Int: maxAge.Milliseconds(), Status: pgtype.Present},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	var payments []*model.PendingPayment
+
+	for rows.Next() {
+		var refID, acquirer, status, currency pgtype.Varchar
+
+		var amount pgtype.Int8
+
+		var attempts pgtype.Int4
+
+		var expired pgtype.Bool
+
+		if err := rows.Scan(&refID, &acquirer, &status, &amount, &currency, &attempts, &expired); err != nil {
+			return nil, err
+		}
+
+		payments = append(payments, &model.PendingPayment{
+			RefID:    refID.String,
+			Acquirer: acquirer.String,
+			Status:   protos.Status(protos.Status_value[status.String]),
+			Amount:   money.New(amount.Int, currency.String),
+			Attempts: int(attempts.Int),
+			Expired:  expired.Bool,
+		})
+	}
+
+	return payments, rows.Err()
+}
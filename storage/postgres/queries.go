@@ -1,5 +1,8 @@
 package postgres
 
+// _statusChangesChannel is the channel every committed payment status change is notified on
+const _statusChangesChannel = "payment_status_changes"
+
 const (
 	_insertPaymentInfo = `INSERT INTO payment_details (
 ref_id,
@@ -12,11 +15,17 @@ address_line_2,
 postcode, 
 card_number,
 currency, 
-amount, 
+amount_minor_units,
 payment_type, 
 status,
-status_reason)
-VALUES($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14) 
+status_reason,
+card_token,
+acquirer,
+response_code,
+merchant_id,
+merchant_reference,
+metadata)
+VALUES($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, COALESCE($16, 'default'), $17, $18, $19, $20) 
 ON CONFLICT DO NOTHING;`
 
 	_getPaymentInfo = `
@@ -31,14 +40,620 @@ address_line_2,
 postcode, 
 card_number,
 currency, 
-amount, 
+amount_minor_units,
 payment_type, 
 status,
 status_reason,
 updated_timestamp,
-insert_timestamp
+insert_timestamp,
+captured_amount_minor_units,
+refunded_amount_minor_units,
+card_token,
+acquirer,
+response_code,
+settlement_batch_id,
+settlement_failure_reason,
+merchant_reference,
+metadata
+FROM payment_details 
+WHERE ref_id = $1 AND merchant_id = $2
+LIMIT 1
+`
+
+	_getPaymentByReference = `
+SELECT 
+ref_id,
+name, 
+surname, 
+email, 
+phone, 
+address_line_1, 
+address_line_2, 
+postcode, 
+card_number,
+currency, 
+amount_minor_units,
+payment_type, 
+status,
+status_reason,
+updated_timestamp,
+insert_timestamp,
+captured_amount_minor_units,
+refunded_amount_minor_units,
+card_token,
+acquirer,
+response_code,
+settlement_batch_id,
+settlement_failure_reason,
+merchant_reference,
+metadata
 FROM payment_details 
-WHERE ref_id = $1 
+WHERE merchant_reference = $1 AND merchant_id = $2
+ORDER BY insert_timestamp DESC
 LIMIT 1
+`
+
+	// conditions are added by listPaymentsQuery, followed by the order and limit
+	_listPayments = `
+SELECT 
+ref_id,
+name, 
+surname, 
+email, 
+phone, 
+address_line_1, 
+address_line_2, 
+postcode, 
+card_number,
+currency, 
+amount_minor_units,
+payment_type, 
+status,
+status_reason,
+updated_timestamp,
+insert_timestamp,
+captured_amount_minor_units,
+refunded_amount_minor_units,
+card_token,
+acquirer,
+response_code,
+settlement_batch_id,
+settlement_failure_reason,
+merchant_reference,
+metadata
+FROM payment_details 
+WHERE merchant_id = $1
+`
+
+	_lockPaymentStatus = `
+SELECT status, captured_amount_minor_units, refunded_amount_minor_units
+FROM payment_details
+WHERE ref_id = $1
+FOR UPDATE
+`
+
+	_updatePaymentStatus = `
+UPDATE payment_details
+SET status = $2,
+status_reason = $3,
+captured_amount_minor_units = captured_amount_minor_units + $4,
+refunded_amount_minor_units = refunded_amount_minor_units + $5,
+pending_refund_amount_minor_units = CASE WHEN $5 > 0 THEN NULL ELSE pending_refund_amount_minor_units END,
+updated_timestamp = CURRENT_TIMESTAMP
+WHERE ref_id = $1
+`
+
+	_lockPaymentRefund = `
+SELECT status, captured_amount_minor_units, refunded_amount_minor_units, pending_refund_amount_minor_units
+FROM payment_details
+WHERE ref_id = $1
+FOR UPDATE
+`
+
+	// a payment has at most one refund in flight, storing a refund releases it
+	_reserveRefund = `
+UPDATE payment_details
+SET pending_refund_amount_minor_units = $2
+WHERE ref_id = $1
+`
+
+	_releaseRefund = `
+UPDATE payment_details
+SET pending_refund_amount_minor_units = NULL
+WHERE ref_id = $1
+`
+
+	// the status of a payment is only authorized once, the acquirer changes when the payment fails over
+	// before it is sent
+	_updateAuthorization = `
+UPDATE payment_details
+SET acquirer = $2,
+response_code = $3
+WHERE ref_id = $1 AND status = 'AUTHORIZING'
+`
+
+	_insertStatusHistory = `INSERT INTO payment_status_history (
+ref_id,
+old_status,
+new_status,
+reason,
+source)
+VALUES($1, $2, $3, $4, $5)
+RETURNING id`
+
+	// every webhook endpoint of the merchant of the payment sent changes to the new status
+	_insertWebhookDeliveries = `
+INSERT INTO webhook_deliveries (endpoint_id, status_history_id)
+SELECT e.endpoint_id,
+$1
+FROM webhook_endpoints e
+JOIN payment_details p ON p.merchant_id = e.merchant_id
+WHERE p.ref_id = $2
+AND e.deleted_timestamp IS NULL
+AND (cardinality(e.statuses) = 0 OR $3 = ANY(e.statuses))
+`
+
+	// the event carries the payment as it is after the change
+	_insertOutboxEvent = `
+INSERT INTO outbox (aggregate_id, event_type, payload)
+SELECT ref_id,
+$2,
+json_build_object(
+'status_history_id', $3::bigint,
+'ref', ref_id,
+'merchant_id', merchant_id,
+'merchant_reference', merchant_reference,
+'old_status', $4::varchar,
+'status', status,
+'reason', $5::varchar,
+'source', $6::varchar,
+'amount_minor_units', amount_minor_units,
+'captured_amount_minor_units', captured_amount_minor_units,
+'refunded_amount_minor_units', refunded_amount_minor_units,
+'currency', currency,
+'acquirer', acquirer,
+'metadata', metadata,
+'updated_timestamp', updated_timestamp)
+FROM payment_details
+WHERE ref_id = $1
+`
+
+	// the notification is only sent when the transaction commits, a payload over 8000 bytes fails the
+	// transaction so the reason is cut short
+	_notifyStatusChange = `
+SELECT pg_notify('` + _statusChangesChannel + `', json_build_object(
+'id', h.id,
+'ref', h.ref_id,
+'merchant_id', p.merchant_id,
+'merchant_reference', p.merchant_reference,
+'old_status', h.old_status,
+'new_status', h.new_status,
+'reason', left(h.reason, 2000),
+'source', h.source,
+'timestamp', h.insert_timestamp)::text)
+FROM payment_status_history h
+JOIN payment_details p ON p.ref_id = h.ref_id
+WHERE h.id = $1
+`
+
+	_listenStatusChanges = `LISTEN ` + _statusChangesChannel
+
+	_getLatestStatusChange = `
+SELECT
+h.id,
+h.ref_id,
+p.merchant_id,
+p.merchant_reference,
+h.old_status,
+h.new_status,
+h.reason,
+h.source,
+h.insert_timestamp
+FROM payment_status_history h
+JOIN payment_details p ON p.ref_id = h.ref_id
+WHERE h.ref_id = $1 AND p.merchant_id = $2
+ORDER BY h.id DESC
+LIMIT 1
+`
+
+	_getStatusHistory = `
+SELECT
+h.old_status,
+h.new_status,
+h.reason,
+h.source,
+h.insert_timestamp
+FROM payment_status_history h
+JOIN payment_details p ON p.ref_id = h.ref_id
+WHERE h.ref_id = $1 AND p.merchant_id = $2
+ORDER BY h.id
+`
+
+	_insertIdempotencyKey = `INSERT INTO idempotency_keys (
+merchant_id,
+idempotency_key,
+request_hash)
+VALUES($1, $2, $3)
+ON CONFLICT DO NOTHING;`
+
+	_getIdempotencyKey = `
+SELECT
+request_hash,
+response
+FROM idempotency_keys
+WHERE merchant_id = $1 AND idempotency_key = $2
+`
+
+	_updateIdempotentResponse = `
+UPDATE idempotency_keys
+SET response = $3
+WHERE merchant_id = $1 AND idempotency_key = $2
+`
+
+	_deleteIdempotencyKey = `
+DELETE FROM idempotency_keys
+WHERE merchant_id = $1 AND idempotency_key = $2 AND response IS NULL
+`
+
+	_insertVaultedCard = `INSERT INTO card_vault (
+token,
+name,
+surname,
+postcode,
+card_type,
+expiry,
+masked_card_number,
+encrypted_card_number,
+wrapped_key,
+key_id,
+merchant_id)
+VALUES($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11);`
+
+	_getVaultedCard = `
+SELECT
+name,
+surname,
+postcode,
+card_type,
+expiry,
+masked_card_number,
+encrypted_card_number,
+wrapped_key,
+key_id
+FROM card_vault
+WHERE token = $1 AND merchant_id = $2
+`
+
+	_deleteVaultedCard = `
+DELETE FROM card_vault
+WHERE token = $1 AND merchant_id = $2
+`
+
+	_insertSettlementBatch = `INSERT INTO settlement_batches (
+batch_id,
+acquirer,
+status)
+VALUES($1, $2, 'SUBMITTING');`
+
+	// payments whose last batch failed, or that the acquirer failed, are claimed again by later runs.
+	// Partially refunded payments are settled for what is left of their captured amount
+	_claimUnsettledPayments = `
+UPDATE payment_details
+SET settlement_batch_id = $1,
+settlement_failure_reason = NULL,
+settlement_amount_minor_units = captured_amount_minor_units - refunded_amount_minor_units
+WHERE ref_id IN (
+SELECT p.ref_id
+FROM payment_details p
+LEFT JOIN settlement_batches b ON b.batch_id = p.settlement_batch_id
+WHERE p.status IN ('CAPTURED', 'PARTIALLY_REFUNDED')
+AND p.acquirer = $2
+AND (p.settlement_batch_id IS NULL
+OR ((b.status = 'FAILED' OR p.settlement_failure_reason IS NOT NULL) AND NOT p.settlement_batch_id = ANY($3::varchar[])))
+ORDER BY p.updated_timestamp
+LIMIT $4
+FOR UPDATE OF p SKIP LOCKED)
+RETURNING ref_id, name, surname, postcode, card_number, currency, settlement_amount_minor_units
+`
+
+	_lockSettlementBatchPayments = `
+SELECT ref_id,
+status,
+settlement_amount_minor_units,
+captured_amount_minor_units - refunded_amount_minor_units
+FROM payment_details
+WHERE settlement_batch_id = $1
+FOR UPDATE
+`
+
+	_setSettlementFailure = `
+UPDATE payment_details
+SET settlement_failure_reason = $2
+WHERE ref_id = $1
+`
+
+	_completeSettlementBatch = `
+UPDATE settlement_batches
+SET status = 'SUBMITTED',
+payment_count = $2,
+failure_count = $3,
+updated_timestamp = CURRENT_TIMESTAMP
+WHERE batch_id = $1
+`
+
+	_failSettlementBatch = `
+UPDATE settlement_batches
+SET status = 'FAILED',
+failure_reason = $2,
+payment_count = (SELECT count(*) FROM payment_details WHERE settlement_batch_id = $1),
+updated_timestamp = CURRENT_TIMESTAMP
+WHERE batch_id = $1
+`
+
+	// batches left SUBMITTING by a settlement job that stopped, their payments are claimed again once failed
+	_failStaleSettlementBatches = `
+UPDATE settlement_batches b
+SET status = 'FAILED',
+failure_reason = $2,
+payment_count = (SELECT count(*) FROM payment_details WHERE settlement_batch_id = b.batch_id),
+updated_timestamp = CURRENT_TIMESTAMP
+WHERE b.status = 'SUBMITTING'
+AND b.updated_timestamp <= CURRENT_TIMESTAMP - $1::float8 * interval '1 millisecond'
+RETURNING b.batch_id
+`
+
+	// payments of the acquirer settled in a submitted batch and not yet found in a settlement file,
+	// along with the payments the settlement file has lines for
+	_getSettlementAmounts = `
+SELECT p.ref_id,
+p.settlement_amount_minor_units,
+p.currency
+FROM payment_details p
+WHERE p.acquirer = $1
+AND p.settlement_batch_id IN (
+SELECT batch_id
+FROM settlement_batches
+WHERE acquirer = $1
+AND status = 'SUBMITTED')
+AND (p.ref_id = ANY($2::varchar[])
+OR (p.settlement_failure_reason IS NULL
+AND NOT EXISTS (
+SELECT 1
+FROM reconciliation_records r
+WHERE r.ref_id = p.ref_id
+AND r.result IN ('MATCHED', 'AMOUNT_MISMATCH'))))
+`
+
+	_insertReconciliationReport = `INSERT INTO reconciliation_reports (
+report_id,
+acquirer,
+file_name)
+VALUES($1, $2, $3)
+RETURNING insert_timestamp`
+
+	_insertReconciliationRecord = `INSERT INTO reconciliation_records (
+report_id,
+ref_id,
+result,
+gateway_amount_minor_units,
+gateway_currency,
+bank_amount_minor_units,
+bank_currency)
+VALUES($1, $2, $3, $4, $5, $6, $7)`
+
+	_getReconciliationReport = `
+SELECT acquirer,
+file_name,
+insert_timestamp
+FROM reconciliation_reports
+WHERE report_id = $1
+`
+
+	_getReconciliationRecords = `
+SELECT ref_id,
+result,
+gateway_amount_minor_units,
+gateway_currency,
+bank_amount_minor_units,
+bank_currency
+FROM reconciliation_records
+WHERE report_id = $1
+ORDER BY result, ref_id
+`
+
+	_insertMerchant = `INSERT INTO merchants (
+merchant_id,
+name,
+operator)
+VALUES($1, $2, $3);`
+
+	_insertAPIKey = `INSERT INTO merchant_api_keys (
+key_hash,
+merchant_id)
+VALUES($1, $2);`
+
+	_revokeAPIKey = `
+UPDATE merchant_api_keys
+SET revoked_timestamp = CURRENT_TIMESTAMP
+WHERE key_hash = $1 AND revoked_timestamp IS NULL
+`
+
+	_getMerchantByAPIKey = `
+SELECT m.merchant_id,
+m.name,
+m.operator
+FROM merchant_api_keys k
+JOIN merchants m ON m.merchant_id = k.merchant_id
+WHERE k.key_hash = $1 AND k.revoked_timestamp IS NULL
+`
+
+	_getMerchant = `
+SELECT merchant_id,
+name,
+operator
+FROM merchants
+WHERE merchant_id = $1
+`
+
+	_insertMerchantReference = `
+INSERT INTO merchant_references (merchant_id, merchant_reference)
+VALUES($1, $2)
+ON CONFLICT DO NOTHING
+`
+
+	_deleteMerchantReference = `
+DELETE FROM merchant_references
+WHERE merchant_id = $1 AND merchant_reference = $2
+`
+
+	_insertWebhookEndpoint = `INSERT INTO webhook_endpoints (
+endpoint_id,
+merchant_id,
+url,
+secret,
+statuses)
+VALUES($1, $2, $3, $4, $5);`
+
+	_deleteWebhookEndpoint = `
+UPDATE webhook_endpoints
+SET deleted_timestamp = CURRENT_TIMESTAMP
+WHERE endpoint_id = $1 AND merchant_id = $2 AND deleted_timestamp IS NULL
+`
+
+	// the conditions of ListWebhookDeliveries are appended to it
+	_listWebhookDeliveries = `
+SELECT d.delivery_id,
+d.endpoint_id,
+h.ref_id,
+h.old_status,
+h.new_status,
+d.status,
+d.attempts,
+d.last_response_code,
+d.last_error,
+d.next_attempt_timestamp,
+d.insert_timestamp,
+d.updated_timestamp
+FROM webhook_deliveries d
+JOIN webhook_endpoints e ON e.endpoint_id = d.endpoint_id
+JOIN payment_status_history h ON h.id = d.status_history_id
+WHERE e.merchant_id = $1
+`
+
+	_redeliverWebhook = `
+UPDATE webhook_deliveries d
+SET status = 'DELIVERY_PENDING',
+attempts = 0,
+next_attempt_timestamp = CURRENT_TIMESTAMP,
+updated_timestamp = CURRENT_TIMESTAMP
+FROM webhook_endpoints e, payment_status_history h
+WHERE d.delivery_id = $1
+AND e.endpoint_id = d.endpoint_id
+AND e.merchant_id = $2
+AND e.deleted_timestamp IS NULL
+AND h.id = d.status_history_id
+RETURNING d.delivery_id,
+d.endpoint_id,
+h.ref_id,
+h.old_status,
+h.new_status,
+d.status,
+d.attempts,
+d.last_response_code,
+d.last_error,
+d.next_attempt_timestamp,
+d.insert_timestamp,
+d.updated_timestamp
+`
+
+	// claimed deliveries are leased by moving their next attempt past the lease, a dispatcher that
+	// stops mid attempt leaves them to be claimed again once it runs out. Deliveries claimed by a
+	// concurrent dispatcher are skipped
+	_claimWebhookDeliveries = `
+UPDATE webhook_deliveries d
+SET next_attempt_timestamp = CURRENT_TIMESTAMP + $2::float8 * interval '1 millisecond'
+FROM webhook_endpoints e, payment_status_history h, payment_details p
+WHERE d.delivery_id IN (
+SELECT w.delivery_id
+FROM webhook_deliveries w
+JOIN webhook_endpoints we ON we.endpoint_id = w.endpoint_id
+WHERE w.status = 'DELIVERY_PENDING'
+AND w.next_attempt_timestamp <= CURRENT_TIMESTAMP
+AND we.deleted_timestamp IS NULL
+ORDER BY w.next_attempt_timestamp
+LIMIT $1
+FOR UPDATE OF w SKIP LOCKED)
+AND e.endpoint_id = d.endpoint_id
+AND h.id = d.status_history_id
+AND p.ref_id = h.ref_id
+RETURNING d.delivery_id,
+d.endpoint_id,
+e.url,
+e.secret,
+d.attempts,
+h.id,
+h.ref_id,
+p.merchant_reference,
+h.old_status,
+h.new_status,
+h.reason,
+h.source,
+h.insert_timestamp
+`
+
+	_completeWebhookDelivery = `
+UPDATE webhook_deliveries
+SET status = $2,
+attempts = attempts + 1,
+last_response_code = $3,
+last_error = $4,
+next_attempt_timestamp = CURRENT_TIMESTAMP + $5::float8 * interval '1 millisecond',
+updated_timestamp = CURRENT_TIMESTAMP
+WHERE delivery_id = $1
+`
+
+	// only one relay publishes at a time so the events of a payment are published in order
+	_lockOutboxRelay = `SELECT pg_try_advisory_xact_lock(hashtext('outbox_relay'))`
+
+	_getUnpublishedOutboxEvents = `
+SELECT id,
+aggregate_id,
+event_type,
+payload::text,
+insert_timestamp
+FROM outbox
+WHERE published_timestamp IS NULL
+ORDER BY id
+LIMIT $1
+`
+
+	_markOutboxEventsPublished = `
+UPDATE outbox
+SET published_timestamp = CURRENT_TIMESTAMP
+WHERE id = ANY($1::bigint[])
+`
+
+	// the payments are claimed by recording the inquiry or reversal, they are not claimed again before
+	// the inquiry interval passed or their status changed
+	_claimPendingPayments = `
+UPDATE payment_details p
+SET inquiry_attempts = p.inquiry_attempts + 1,
+last_inquiry_timestamp = CURRENT_TIMESTAMP
+WHERE p.ref_id IN (
+SELECT ref_id
+FROM payment_details
+WHERE ((status IN ('PENDING', 'UNKNOWN_RESPONSE_CODE') AND updated_timestamp <= CURRENT_TIMESTAMP - $2::float8 * interval '1 millisecond')
+OR (status = 'AUTHORIZING' AND updated_timestamp <= CURRENT_TIMESTAMP - $3::float8 * interval '1 millisecond'))
+AND (last_inquiry_timestamp IS NULL OR last_inquiry_timestamp <= CURRENT_TIMESTAMP - $4::float8 * interval '1 millisecond')
+ORDER BY updated_timestamp
+LIMIT $1
+FOR UPDATE SKIP LOCKED)
+RETURNING p.ref_id,
+p.acquirer,
+p.status,
+p.amount_minor_units,
+p.currency,
+p.inquiry_attempts,
+p.insert_timestamp <= CURRENT_TIMESTAMP - $5::float8 * interval '1 millisecond'
 `
 )
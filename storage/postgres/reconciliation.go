@@ -0,0 +1,142 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgtype"
+	"github.com/jackc/pgx/v4"
+
+	"payments_gateway/model"
+	"payments_gateway/money"
+	protos "payments_gateway/protos"
+)
+
+// GetSettlementAmounts returns the amount submitted for settlement by ref of the payments settled in the submitted
+// batches of an acquirer that its settlement file is expected to have: those no earlier file had, and
+// those the file has lines for. Lines for any other payment are unexpected
+func (p *PgxStorage) GetSettlementAmounts(ctx context.Context, acquirer string, refIDs []string) (map[string]money.Money, error) {
+	var refs pgtype.VarcharArray
+	if err := refs.Set(append([]string{}, refIDs...)); err != nil {
+		return nil, err
+	}
+
+	rows, err := p.pool.Query(ctx, _getSettlementAmounts, convertStringToPgType(acquirer), refs)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	amounts := map[string]money.Money{}
+
+	for rows.Next() {
+		var refID, currency pgtype.Varchar
+
+		var settled pgtype.Int8
+
+		if err := rows.Scan(&refID, &settled, &currency); err != nil {
+			return nil, err
+		}
+
+		amounts[refID.String] = money.New(settled.Int, currency.String)
+	}
+
+	return amounts, rows.Err()
+}
+
+// AddReconciliationReport stores a report and its records, CreatedAt is set to when it was stored
+func (p *PgxStorage) AddReconciliationReport(ctx context.Context, report *model.ReconciliationReport) error {
+	return p.pool.BeginFunc(ctx, func(tx pgx.Tx) error {
+		var insertTime pgtype.Timestamp
+
+		if err := tx.QueryRow(ctx, _insertReconciliationReport,
+			convertStringToPgType(report.ID),
+			convertStringToPgType(report.Acquirer),
+			convertStringToPgType(report.FileName),
+		).Scan(&insertTime); err != nil {
+			return err
+		}
+
+		batch := &pgx.Batch{}
+
+		for _, record := range report.Records {
+			batch.Queue(_insertReconciliationRecord,
+				convertStringToPgType(report.ID),
+				convertStringToPgType(record.RefID),
+				convertEnumToPgType(record.Result),
+				convertInt64ToPgType(record.GatewayAmount.Amount),
+				convertStringToPgType(record.GatewayAmount.Currency),
+				convertInt64ToPgType(record.BankAmount.Amount),
+				convertStringToPgType(record.BankAmount.Currency),
+			)
+		}
+
+		results := tx.SendBatch(ctx, batch)
+
+		for range report.Records {
+			if _, err := results.Exec(); err != nil {
+				_ = results.Close()
+
+				return err
+			}
+		}
+
+		if err := results.Close(); err != nil {
+			return err
+		}
+
+		report.CreatedAt = insertTime.Time
+
+		return nil
+	})
+}
+
+// GetReconciliationReport returns a report with its records, nil is returned when no report has the ID
+func (p *PgxStorage) GetReconciliationReport(ctx context.Context, reportID string) (*model.ReconciliationReport, error) {
+	var acquirer, fileName pgtype.Varchar
+
+	var insertTime pgtype.Timestamp
+
+	err := p.pool.QueryRow(ctx, _getReconciliationReport, convertStringToPgType(reportID)).Scan(&acquirer, &fileName, &insertTime)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	report := &model.ReconciliationReport{
+		ID:        reportID,
+		Acquirer:  acquirer.String,
+		FileName:  fileName.String,
+		CreatedAt: insertTime.Time,
+	}
+
+	rows, err := p.pool.Query(ctx, _getReconciliationRecords, convertStringToPgType(reportID))
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var refID, result, gatewayCurrency, bankCurrency pgtype.Varchar
+
+		var gatewayAmount, bankAmount pgtype.Int8
+
+		if err := rows.Scan(&refID, &result, &gatewayAmount, &gatewayCurrency, &bankAmount, &bankCurrency); err != nil {
+			return nil, err
+		}
+
+		report.Records = append(report.Records, model.ReconciliationRecord{
+			RefID:         refID.String,
+			Result:        protos.ReconciliationResult(protos.ReconciliationResult_value[result.String]),
+			GatewayAmount: money.New(gatewayAmount.Int, gatewayCurrency.String),
+			BankAmount:    money.New(bankAmount.Int, bankCurrency.String),
+		})
+	}
+
+	return report, rows.Err()
+}
@@ -0,0 +1,225 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgtype"
+	"github.com/jackc/pgx/v4"
+
+	"payments_gateway/model"
+	"payments_gateway/money"
+	protos "payments_gateway/protos"
+)
+
+// errEmptyBatch rolls back a batch no payment was claimed for
+var errEmptyBatch = errors.New("no payments to settle")
+
+// CreateSettlementBatch claims up to limit captured or partially refunded payments of an acquirer for a
+// new batch, each for its captured amount less its refunds. Payments whose previous settlement failed are claimed again unless it failed in one of the exclude batches.
+// Payments claimed by a concurrent run are skipped, nil is returned when there is nothing to settle
+func (p *PgxStorage) CreateSettlementBatch(ctx context.Context, batchID, acquirer string, exclude []string, limit int) (*model.SettlementBatch, error) {
+	var excluded pgtype.VarcharArray
+	if err := excluded.Set(append([]string{}, exclude...)); err != nil {
+		return nil, err
+	}
+
+	batch := &model.SettlementBatch{ID: batchID, Acquirer: acquirer}
+
+	err := p.pool.BeginFunc(ctx, func(tx pgx.Tx) error {
+		if _, err := tx.Exec(ctx, _insertSettlementBatch, convertStringToPgType(batchID), convertStringToPgType(acquirer)); err != nil {
+			return err
+		}
+
+		rows, err := tx.Query(ctx, _claimUnsettledPayments,
+			convertStringToPgType(batchID),
+			convertStringToPgType(acquirer),
+			excluded,
+			pgtype.Int8{Int: int64(limit), Status: pgtype.Present},
+		)
+		if err != nil {
+			return err
+		}
+
+		defer rows.Close()
+
+		for rows.Next() {
+			var refID, name, surname, postcode, cardNo, currency pgtype.Varchar
+
+			var amount pgtype.Int8
+
+			if err := rows.Scan(&refID, &name, &surname, &postcode, &cardNo, &currency, &amount); err != nil {
+				return err
+			}
+
+			batch.Transactions = append(batch.Transactions, &model.Transaction{
+				RefID: refID.String,
+				Card: model.Card{
+					Name:     name.String,
+					Surname:  surname.String,
+					Postcode: postcode.String,
+					CardNum:  cardNo.String,
+				},
+				Amount: money.New(amount.Int, currency.String),
+			})
+		}
+
+		if err := rows.Err(); err != nil {
+			return err
+		}
+
+		if len(batch.Transactions) == 0 {
+			return errEmptyBatch
+		}
+
+		return nil
+	})
+
+	if errors.Is(err, errEmptyBatch) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return batch, nil
+}
+
+// CompleteSettlementBatch records the outcome of a submitted batch. Captured payments the acquirer did
+// not fail are COMPLETED and partially refunded ones keep their status. Payments the acquirer failed, or
+// refunded since they were claimed, keep their status and store the failure reason
+func (p *PgxStorage) CompleteSettlementBatch(ctx context.Context, batchID string, failures map[string]string) (model.SettlementResult, error) {
+	var result model.SettlementResult
+
+	err := p.pool.BeginFunc(ctx, func(tx pgx.Tx) error {
+		result = model.SettlementResult{}
+
+		payments, err := lockBatchPayments(ctx, tx, batchID)
+		if err != nil {
+			return err
+		}
+
+		for refID, payment := range payments {
+			reason, failed := failures[refID]
+
+			// a refund made while the batch was submitted changed the amount to settle, the payment is
+			// claimed again for the new amount unless it was refunded in full
+			if !failed && payment.remaining != payment.submitted {
+				failed = true
+				reason = fmt.Sprintf("payment was %s when the batch was settled", payment.status)
+			}
+
+			if failed {
+				result.Failed++
+
+				if _, err := tx.Exec(ctx, _setSettlementFailure, convertStringToPgType(refID), convertStringToPgType(reason)); err != nil {
+					return err
+				}
+
+				continue
+			}
+
+			result.Settled++
+
+			if payment.status != protos.Status_CAPTURED {
+				continue
+			}
+
+			if err := updatePaymentStatus(ctx, tx, model.StatusUpdate{
+				RefID:  refID,
+				Status: protos.Status_COMPLETED,
+				Reason: fmt.Sprintf("settled in batch %s", batchID),
+				Source: protos.StatusSource_BANK,
+			}); err != nil {
+				return err
+			}
+		}
+
+		_, err = tx.Exec(ctx, _completeSettlementBatch,
+			convertStringToPgType(batchID),
+			pgtype.Int4{Int: int32(len(payments)), Status: pgtype.Present},
+			pgtype.Int4{Int: int32(result.Failed), Status: pgtype.Present},
+		)
+
+		return err
+	})
+
+	return result, err
+}
+
+// FailSettlementBatch records that a batch could not be submitted, its payments are claimed again by the next run
+func (p *PgxStorage) FailSettlementBatch(ctx context.Context, batchID, reason string) error {
+	_, err := p.pool.Exec(ctx, _failSettlementBatch, convertStringToPgType(batchID), convertStringToPgType(reason))
+
+	return err
+}
+
+// FailStaleSettlementBatches fails the batches that have been SUBMITTING for at least staleAfter, e.g.
+// because the job submitting them stopped, and returns their IDs. Their payments are claimed again by the
+// next run
+func (p *PgxStorage) FailStaleSettlementBatches(ctx context.Context, staleAfter time.Duration, reason string) ([]string, error) {
+	rows, err := p.pool.Query(ctx, _failStaleSettlementBatches,
+		pgtype.Int8{Int: staleAfter.Milliseconds(), Status: pgtype.Present},
+		convertStringToPgType(reason),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	var batchIDs []string
+
+	for rows.Next() {
+		var batchID pgtype.Varchar
+
+		if err := rows.Scan(&batchID); err != nil {
+			return nil, err
+		}
+
+		batchIDs = append(batchIDs, batchID.String)
+	}
+
+	return batchIDs, rows.Err()
+}
+
+// batchPayment is a payment of a settlement batch, submitted is the amount it was claimed for and
+// remaining its captured amount less the refunds made so far
+type batchPayment struct {
+	status    protos.Status
+	submitted int64
+	remaining int64
+}
+
+// lockBatchPayments locks the payments of a batch and returns them by ref
+func lockBatchPayments(ctx context.Context, tx pgx.Tx, batchID string) (map[string]batchPayment, error) {
+	rows, err := tx.Query(ctx, _lockSettlementBatchPayments, convertStringToPgType(batchID))
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	payments := map[string]batchPayment{}
+
+	for rows.Next() {
+		var refID, status pgtype.Varchar
+
+		var submitted, remaining pgtype.Int8
+
+		if err := rows.Scan(&refID, &status, &submitted, &remaining); err != nil {
+			return nil, err
+		}
+
+		payments[refID.String] = batchPayment{
+			status:    protos.Status(protos.Status_value[status.String]),
+			submitted: submitted.Int,
+			remaining: remaining.Int,
+		}
+	}
+
+	return payments, rows.Err()
+}
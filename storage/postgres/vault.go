@@ -0,0 +1,72 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgtype"
+	"github.com/jackc/pgx/v4"
+
+	"payments_gateway/model"
+)
+
+// AddVaultedCard stores an encrypted card under its token
+func (p *PgxStorage) AddVaultedCard(ctx context.Context, card model.VaultedCard) error {
+	_, err := p.pool.Exec(ctx,
+		_insertVaultedCard,
+		convertStringToPgType(card.Token),
+		convertStringToPgType(card.Name),
+		convertStringToPgType(card.Surname),
+		convertStringToPgType(card.Postcode),
+		convertStringToPgType(card.CardType),
+		convertStringToPgType(card.Expiry),
+		convertStringToPgType(card.MaskedCardNumber),
+		pgtype.Bytea{Bytes: card.EncryptedCardNumber, Status: pgtype.Present},
+		pgtype.Bytea{Bytes: card.WrappedKey, Status: pgtype.Present},
+		convertStringToPgType(card.KeyID),
+		convertMerchantToPgType(card.MerchantID),
+	)
+
+	return err
+}
+
+// GetVaultedCard retrieves the encrypted card of a token, nil is returned when the merchant has no card with the token
+func (p *PgxStorage) GetVaultedCard(ctx context.Context, merchantID, token string) (*model.VaultedCard, error) {
+	var name, surname, postcode, cardType, expiry, maskedCardNumber, keyID pgtype.Varchar
+
+	var encryptedCardNumber, wrappedKey pgtype.Bytea
+
+	err := p.pool.QueryRow(ctx, _getVaultedCard, convertStringToPgType(token), convertMerchantToPgType(merchantID)).
+		Scan(&name, &surname, &postcode, &cardType, &expiry, &maskedCardNumber, &encryptedCardNumber, &wrappedKey, &keyID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return &model.VaultedCard{
+		Token:               token,
+		MerchantID:          merchantID,
+		Name:                name.String,
+		Surname:             surname.String,
+		Postcode:            postcode.String,
+		CardType:            cardType.String,
+		Expiry:              expiry.String,
+		MaskedCardNumber:    maskedCardNumber.String,
+		EncryptedCardNumber: encryptedCardNumber.Bytes,
+		WrappedKey:          wrappedKey.Bytes,
+		KeyID:               keyID.String,
+	}, nil
+}
+
+// DeleteVaultedCard removes the card of a token, it reports false when the merchant has no card with the token
+func (p *PgxStorage) DeleteVaultedCard(ctx context.Context, merchantID, token string) (bool, error) {
+	tag, err := p.pool.Exec(ctx, _deleteVaultedCard, convertStringToPgType(token), convertMerchantToPgType(merchantID))
+	if err != nil {
+		return false, err
+	}
+
+	return tag.RowsAffected() == 1, nil
+}
@@ -0,0 +1,141 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgtype"
+	"github.com/jackc/pgx/v4"
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"payments_gateway/model"
+	protos "payments_gateway/protos"
+	"payments_gateway/storage"
+)
+
+// statusChangeNotification is the payload of a status change notification, see _notifyStatusChange
+type statusChangeNotification struct {
+	ID                int64  `json:"id"`
+	Ref               string `json:"ref"`
+	MerchantID        string `json:"merchant_id"`
+	MerchantReference string `json:"merchant_reference"`
+	OldStatus         string `json:"old_status"`
+	NewStatus         string `json:"new_status"`
+	Reason            string `json:"reason"`
+	Source            string `json:"source"`
+	Timestamp         string `json:"timestamp"`
+}
+
+// statusListener listens for status change notifications on a connection of its own, opened outside
+// the pool so the queries of the gateway never wait for it
+type statusListener struct {
+	conn *pgx.Conn
+}
+
+// GetLatestStatusChange retrieves the latest status change of a payment of a merchant, nil is
+// returned when the merchant has no such payment
+func (p *PgxStorage) GetLatestStatusChange(ctx context.Context, merchantID, refID string) (*model.StatusChangeEvent, error) {
+	var id pgtype.Int8
+
+	var ref, merchant, merchantReference, oldStatus, newStatus, reason, source pgtype.Varchar
+
+	var insertTime pgtype.Timestamp
+
+	err := p.pool.QueryRow(ctx, _getLatestStatusChange, convertStringToPgType(refID), convertMerchantToPgType(merchantID)).
+		Scan(&id, &ref, &merchant, &merchantReference, &oldStatus, &newStatus, &reason, &source, &insertTime)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.StatusChangeEvent{
+		ID:                id.Int,
+		RefID:             ref.String,
+		MerchantID:        merchant.String,
+		MerchantReference: merchantReference.String,
+		Change: &protos.PaymentStatusChange{
+			OldStatus: protos.Status(protos.Status_value[oldStatus.String]),
+			NewStatus: protos.Status(protos.Status_value[newStatus.String]),
+			Reason:    reason.String,
+			Source:    protos.StatusSource(protos.StatusSource_value[source.String]),
+			Timestamp: timestamppb.New(insertTime.Time),
+		},
+	}, nil
+}
+
+// ListenStatusChanges opens a connection with the config of the pool to listen for the status changes
+// committed by every gateway, the connection is closed with the listener
+func (p *PgxStorage) ListenStatusChanges(ctx context.Context) (storage.StatusListener, error) {
+	conn, err := pgx.ConnectConfig(ctx, p.pool.Config().ConnConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	listener := &statusListener{conn: conn}
+
+	if _, err := conn.Exec(ctx, _listenStatusChanges); err != nil {
+		listener.Close()
+
+		return nil, err
+	}
+
+	return listener, nil
+}
+
+// Next waits for the next status change notification, notifications that cannot be decoded are skipped
+func (l *statusListener) Next(ctx context.Context) (*model.StatusChangeEvent, error) {
+	for {
+		notification, err := l.conn.WaitForNotification(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		event, err := decodeStatusChange(notification.Payload)
+		if err != nil {
+			log.WithField("payload", notification.Payload).WithError(err).Error("decoding status change notification")
+
+			continue
+		}
+
+		return event, nil
+	}
+}
+
+// Close closes the listening connection
+func (l *statusListener) Close() {
+	_ = l.conn.Close(context.Background())
+}
+
+func decodeStatusChange(payload string) (*model.StatusChangeEvent, error) {
+	var notification statusChangeNotification
+
+	if err := json.Unmarshal([]byte(payload), &notification); err != nil {
+		return nil, err
+	}
+
+	// the insert timestamp has no time zone, like every timestamp read by pgx it is UTC
+	insertTime, err := time.Parse("2006-01-02T15:04:05.999999999", notification.Timestamp)
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.StatusChangeEvent{
+		ID:                notification.ID,
+		RefID:             notification.Ref,
+		MerchantID:        notification.MerchantID,
+		MerchantReference: notification.MerchantReference,
+		Change: &protos.PaymentStatusChange{
+			OldStatus: protos.Status(protos.Status_value[notification.OldStatus]),
+			NewStatus: protos.Status(protos.Status_value[notification.NewStatus]),
+			Reason:    notification.Reason,
+			Source:    protos.StatusSource(protos.StatusSource_value[notification.Source]),
+			Timestamp: timestamppb.New(insertTime),
+		},
+	}, nil
+}
@@ -0,0 +1,82 @@
+package postgres
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"payments_gateway/model"
+	protos "payments_gateway/protos"
+)
+
+func Test_decodeStatusChange(t *testing.T) {
+	tests := []struct {
+		name    string
+		payload string
+		want    *model.StatusChangeEvent
+		err     string
+	}{
+		{
+			name: "status change",
+			payload: `{"id" : 42, "ref" : "ref1", "merchant_id" : "merchant-1", "merchant_reference" : "order-1234", ` +
+				`"old_status" : "PENDING", "new_status" : "APPROVED", "reason" : "approved", "source" : "BANK", ` +
+				`"timestamp" : "2022-05-01T06:00:00.123456"}`,
+			want: &model.StatusChangeEvent{
+				ID:                42,
+				RefID:             "ref1",
+				MerchantID:        "merchant-1",
+				MerchantReference: "order-1234",
+				Change: &protos.PaymentStatusChange{
+					OldStatus: protos.Status_PENDING,
+					NewStatus: protos.Status_APPROVED,
+					Reason:    "approved",
+					Source:    protos.StatusSource_BANK,
+					Timestamp: timestamppb.New(time.Date(2022, 5, 1, 6, 0, 0, 123456000, time.UTC)),
+				},
+			},
+		},
+		{
+			name: "payment created",
+			payload: `{"id" : 41, "ref" : "ref1", "merchant_id" : "merchant-1", "merchant_reference" : null, ` +
+				`"old_status" : null, "new_status" : "PENDING", "reason" : null, "source" : "BANK", ` +
+				`"timestamp" : "2022-05-01T06:00:00"}`,
+			want: &model.StatusChangeEvent{
+				ID:         41,
+				RefID:      "ref1",
+				MerchantID: "merchant-1",
+				Change: &protos.PaymentStatusChange{
+					NewStatus: protos.Status_PENDING,
+					Source:    protos.StatusSource_BANK,
+					Timestamp: timestamppb.New(time.Date(2022, 5, 1, 6, 0, 0, 0, time.UTC)),
+				},
+			},
+		},
+		{
+			name:    "malformed timestamp",
+			payload: `{"id" : 41, "timestamp" : "yesterday"}`,
+			err:     `parsing time "yesterday" as "2006-01-02T15:04:05.999999999": cannot parse "yesterday" as "2006"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := decodeStatusChange(tt.payload)
+
+			if tt.err != "" {
+				assert.EqualError(t, err, tt.err)
+
+				return
+			}
+
+			if !assert.NoError(t, err) {
+				return
+			}
+
+			assert.Equal(t, tt.want.Change.String(), got.Change.String())
+
+			got.Change, tt.want.Change = nil, nil
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,231 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgtype"
+	"github.com/jackc/pgx/v4"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"payments_gateway/model"
+	protos "payments_gateway/protos"
+)
+
+// AddWebhookEndpoint stores a new webhook endpoint of a merchant
+func (p *PgxStorage) AddWebhookEndpoint(ctx context.Context, endpoint model.WebhookEndpoint) error {
+	statuses := make([]string, 0, len(endpoint.Statuses))
+	for _, status := range endpoint.Statuses {
+		statuses = append(statuses, status.String())
+	}
+
+	var pgStatuses pgtype.VarcharArray
+	if err := pgStatuses.Set(statuses); err != nil {
+		return err
+	}
+
+	_, err := p.pool.Exec(ctx,
+		_insertWebhookEndpoint,
+		convertStringToPgType(endpoint.ID),
+		convertMerchantToPgType(endpoint.MerchantID),
+		convertStringToPgType(endpoint.URL),
+		convertStringToPgType(endpoint.Secret),
+		pgStatuses,
+	)
+
+	return err
+}
+
+// DeleteWebhookEndpoint stops changes being sent to a webhook endpoint of a merchant, including
+// the ones waiting to be sent. It reports false when the merchant has no such endpoint
+func (p *PgxStorage) DeleteWebhookEndpoint(ctx context.Context, merchantID, endpointID string) (bool, error) {
+	tag, err := p.pool.Exec(ctx, _deleteWebhookEndpoint, convertStringToPgType(endpointID), convertMerchantToPgType(merchantID))
+	if err != nil {
+		return false, err
+	}
+
+	return tag.RowsAffected() == 1, nil
+}
+
+// ListWebhookDeliveries returns the webhook deliveries of a merchant matching a filter, newest first
+// and starting before its cursor
+func (p *PgxStorage) ListWebhookDeliveries(ctx context.Context, merchantID string, filter model.WebhookDeliveryFilter) ([]*protos.WebhookDelivery, error) {
+	query, args := listWebhookDeliveriesQuery(merchantID, filter)
+
+	rows, err := p.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var deliveries []*protos.WebhookDelivery
+
+	for rows.Next() {
+		delivery, err := scanWebhookDelivery(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		deliveries = append(deliveries, delivery)
+	}
+
+	return deliveries, rows.Err()
+}
+
+// listWebhookDeliveriesQuery adds a condition to _listWebhookDeliveries for every filter that is set
+func listWebhookDeliveriesQuery(merchantID string, filter model.WebhookDeliveryFilter) (string, []interface{}) {
+	args := []interface{}{convertMerchantToPgType(merchantID)}
+
+	var query strings.Builder
+
+	query.WriteString(_listWebhookDeliveries)
+
+	where := func(condition string, value interface{}) {
+		args = append(args, value)
+
+		query.WriteString("AND ")
+		query.WriteString(fmt.Sprintf(condition, fmt.Sprintf("$%d", len(args))))
+		query.WriteString("\n")
+	}
+
+	if filter.Status != protos.WebhookDeliveryStatus_UNSPECIFIED_DELIVERY_STATUS {
+		where("d.status = %s", convertEnumToPgType(filter.Status))
+	}
+
+	if filter.EndpointID != "" {
+		where("d.endpoint_id = %s", convertStringToPgType(filter.EndpointID))
+	}
+
+	if filter.RefID != "" {
+		where("h.ref_id = %s", convertStringToPgType(filter.RefID))
+	}
+
+	if filter.Before != 0 {
+		where("d.delivery_id < %s", convertInt64ToPgType(filter.Before))
+	}
+
+	args = append(args, filter.Limit)
+	query.WriteString(fmt.Sprintf("ORDER BY d.delivery_id DESC\nLIMIT $%d", len(args)))
+
+	return query.String(), args
+}
+
+// RedeliverWebhook makes a webhook delivery of a merchant pending again with a new set of retries,
+// nil is returned when the merchant has no such delivery or its endpoint was deleted
+func (p *PgxStorage) RedeliverWebhook(ctx context.Context, merchantID string, deliveryID int64) (*protos.WebhookDelivery, error) {
+	delivery, err := scanWebhookDelivery(p.pool.QueryRow(ctx, _redeliverWebhook, convertInt64ToPgType(deliveryID), convertMerchantToPgType(merchantID)))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return delivery, nil
+}
+
+// scanWebhookDelivery reads a delivery selected with the columns of _listWebhookDeliveries
+func scanWebhookDelivery(row pgx.Row) (*protos.WebhookDelivery, error) {
+	var deliveryID pgtype.Int8
+
+	var endpointID, refID, oldStatus, newStatus, status, lastError pgtype.Varchar
+
+	var attempts, responseCode pgtype.Int4
+
+	var nextAttempt, insertTime, updatedTime pgtype.Timestamp
+
+	if err := row.Scan(&deliveryID, &endpointID, &refID, &oldStatus, &newStatus, &status, &attempts, &responseCode, &lastError, &nextAttempt, &insertTime, &updatedTime); err != nil {
+		return nil, err
+	}
+
+	return &protos.WebhookDelivery{
+		DeliveryId:           strconv.FormatInt(deliveryID.Int, 10),
+		EndpointId:           endpointID.String,
+		Ref:                  refID.String,
+		OldStatus:            protos.Status(protos.Status_value[oldStatus.String]),
+		NewStatus:            protos.Status(protos.Status_value[newStatus.String]),
+		Status:               protos.WebhookDeliveryStatus(protos.WebhookDeliveryStatus_value[status.String]),
+		Attempts:             attempts.Int,
+		LastResponseCode:     responseCode.Int,
+		LastError:            lastError.String,
+		NextAttemptTimestamp: timestamppb.New(nextAttempt.Time),
+		InsertTimestamp:      timestamppb.New(insertTime.Time),
+		UpdatedTimestamp:     timestamppb.New(updatedTime.Time),
+	}, nil
+}
+
+// ClaimWebhookDeliveries claims up to limit pending deliveries that are due, oldest first. They are
+// not claimed again until the lease runs out or their attempt is completed
+func (p *PgxStorage) ClaimWebhookDeliveries(ctx context.Context, limit int, lease time.Duration) ([]*model.WebhookDelivery, error) {
+	var deliveries []*model.WebhookDelivery
+
+	err := p.pool.BeginFunc(ctx, func(tx pgx.Tx) error {
+		deliveries = nil
+
+		rows, err := tx.Query(ctx, _claimWebhookDeliveries,
+			pgtype.Int8{Int: int64(limit), Status: pgtype.Present},
+			pgtype.Int8{Int: lease.Milliseconds(), Status: pgtype.Present},
+		)
+		if err != nil {
+			return err
+		}
+
+		defer rows.Close()
+
+		for rows.Next() {
+			var deliveryID, attempts, historyID pgtype.Int8
+
+			var endpointID, url, secret, refID, merchantReference, oldStatus, newStatus, reason, source pgtype.Varchar
+
+			var changeTime pgtype.Timestamp
+
+			if err := rows.Scan(&deliveryID, &endpointID, &url, &secret, &attempts, &historyID, &refID, &merchantReference, &oldStatus, &newStatus, &reason, &source, &changeTime); err != nil {
+				return err
+			}
+
+			deliveries = append(deliveries, &model.WebhookDelivery{
+				ID:                deliveryID.Int,
+				EndpointID:        endpointID.String,
+				URL:               url.String,
+				Secret:            secret.String,
+				Attempts:          int(attempts.Int),
+				EventID:           historyID.Int,
+				RefID:             refID.String,
+				MerchantReference: merchantReference.String,
+				OldStatus:         protos.Status(protos.Status_value[oldStatus.String]),
+				NewStatus:         protos.Status(protos.Status_value[newStatus.String]),
+				Reason:            reason.String,
+				Source:            protos.StatusSource(protos.StatusSource_value[source.String]),
+				Time:              changeTime.Time,
+			})
+		}
+
+		return rows.Err()
+	})
+
+	return deliveries, err
+}
+
+// CompleteWebhookDelivery records the outcome of an attempt of a claimed delivery
+func (p *PgxStorage) CompleteWebhookDelivery(ctx context.Context, attempt model.WebhookAttempt) error {
+	responseCode := pgtype.Int4{Int: int32(attempt.ResponseCode), Status: pgtype.Present}
+	if attempt.ResponseCode == 0 {
+		responseCode.Status = pgtype.Null
+	}
+
+	_, err := p.pool.Exec(ctx,
+		_completeWebhookDelivery,
+		convertInt64ToPgType(attempt.DeliveryID),
+		convertEnumToPgType(attempt.Status),
+		responseCode,
+		convertStringToPgType(attempt.Error),
+		pgtype.Int8{Int: attempt.RetryAfter.Milliseconds(), Status: pgtype.Present},
+	)
+
+	return err
+}
@@ -0,0 +1,57 @@
+package postgres
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"payments_gateway/model"
+	protos "payments_gateway/protos"
+)
+
+func Test_listWebhookDeliveriesQuery(t *testing.T) {
+	tests := []struct {
+		name       string
+		filter     model.WebhookDeliveryFilter
+		conditions string
+		args       []interface{}
+	}{
+		{
+			name:       "no filters",
+			filter:     model.WebhookDeliveryFilter{Limit: 51},
+			conditions: "ORDER BY d.delivery_id DESC\nLIMIT $2",
+			args:       []interface{}{convertMerchantToPgType("merchant-1"), 51},
+		},
+		{
+			name: "every filter",
+			filter: model.WebhookDeliveryFilter{
+				Status:     protos.WebhookDeliveryStatus_DEAD_LETTER,
+				EndpointID: "endpoint-1",
+				RefID:      "ref1",
+				Before:     42,
+				Limit:      11,
+			},
+			conditions: "AND d.status = $2\n" +
+				"AND d.endpoint_id = $3\n" +
+				"AND h.ref_id = $4\n" +
+				"AND d.delivery_id < $5\n" +
+				"ORDER BY d.delivery_id DESC\nLIMIT $6",
+			args: []interface{}{
+				convertMerchantToPgType("merchant-1"),
+				convertEnumToPgType(protos.WebhookDeliveryStatus_DEAD_LETTER),
+				convertStringToPgType("endpoint-1"),
+				convertStringToPgType("ref1"),
+				convertInt64ToPgType(42),
+				11,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query, args := listWebhookDeliveriesQuery("merchant-1", tt.filter)
+
+			assert.Equal(t, _listWebhookDeliveries+tt.conditions, query)
+			assert.Equal(t, tt.args, args)
+		})
+	}
+}
@@ -2,11 +2,77 @@ package storage
 
 import (
 	"context"
+	"errors"
+	"time"
+
+	"payments_gateway/model"
+	"payments_gateway/money"
 	protos "payments_gateway/protos"
 )
 
+var (
+	// ErrPaymentNotFound is returned when an operation references a payment that has not been stored
+	ErrPaymentNotFound = errors.New("payment not found")
+	// ErrNotAuthorizing is returned when the outcome of an authorization is stored for a payment that is
+	// no longer AUTHORIZING, e.g. its authorization was reversed meanwhile
+	ErrNotAuthorizing = errors.New("payment is not being authorized")
+	// ErrRefundExceedsCaptured is returned when a refund would bring the refunded amount of a payment
+	// above its captured amount, e.g. a concurrent refund was stored first
+	ErrRefundExceedsCaptured = errors.New("refund exceeds the captured amount of the payment")
+	// ErrRefundInProgress is returned when a refund is reserved for a payment another refund is reserved for
+	ErrRefundInProgress = errors.New("another refund of the payment is in progress")
+	// ErrOutboxLocked is returned when another relay is publishing the outbox
+	ErrOutboxLocked = errors.New("outbox is locked by another relay")
+)
+
 // Client is the interface for storage operations
 type Client interface {
-	AddPaymentInfo(ctx context.Context, refID string, request *protos.ProcessPaymentRequest, code protos.Status, reason string) error
-	GetPaymentInfo(ctx context.Context, refId string) (*protos.GetPaymentResponse, error)
+	AddPaymentInfo(ctx context.Context, merchantID, refID string, request *protos.ProcessPaymentRequest, amount money.Money, auth model.Authorization) error
+	GetPaymentInfo(ctx context.Context, merchantID, refID string) (*protos.GetPaymentResponse, error)
+	GetPaymentByReference(ctx context.Context, merchantID, reference string) (*protos.GetPaymentResponse, error)
+	ListPayments(ctx context.Context, merchantID string, filter model.PaymentFilter) ([]*protos.GetPaymentResponse, error)
+	UpdateAuthorization(ctx context.Context, refID string, auth model.Authorization) error
+	UpdatePaymentStatus(ctx context.Context, update model.StatusUpdate) error
+	ReserveRefund(ctx context.Context, refID string, amount int64) (int64, error)
+	ReleaseRefund(ctx context.Context, refID string) error
+	GetPaymentHistory(ctx context.Context, merchantID, refID string) ([]*protos.PaymentStatusChange, error)
+	CreateIdempotencyKey(ctx context.Context, merchantID, key, requestHash string) (bool, error)
+	GetIdempotencyKey(ctx context.Context, merchantID, key string) (*model.IdempotencyRecord, error)
+	SaveIdempotentResponse(ctx context.Context, merchantID, key string, response *protos.ProcessPaymentResponse) error
+	DeleteIdempotencyKey(ctx context.Context, merchantID, key string) error
+	AddVaultedCard(ctx context.Context, card model.VaultedCard) error
+	GetVaultedCard(ctx context.Context, merchantID, token string) (*model.VaultedCard, error)
+	DeleteVaultedCard(ctx context.Context, merchantID, token string) (bool, error)
+	CreateSettlementBatch(ctx context.Context, batchID, acquirer string, exclude []string, limit int) (*model.SettlementBatch, error)
+	CompleteSettlementBatch(ctx context.Context, batchID string, failures map[string]string) (model.SettlementResult, error)
+	FailSettlementBatch(ctx context.Context, batchID, reason string) error
+	FailStaleSettlementBatches(ctx context.Context, staleAfter time.Duration, reason string) ([]string, error)
+	GetSettlementAmounts(ctx context.Context, acquirer string, refIDs []string) (map[string]money.Money, error)
+	AddReconciliationReport(ctx context.Context, report *model.ReconciliationReport) error
+	GetReconciliationReport(ctx context.Context, reportID string) (*model.ReconciliationReport, error)
+	AddMerchant(ctx context.Context, merchant model.Merchant) error
+	AddAPIKey(ctx context.Context, merchantID, keyHash string) error
+	RevokeAPIKey(ctx context.Context, keyHash string) (bool, error)
+	GetMerchantByAPIKey(ctx context.Context, keyHash string) (*model.Merchant, error)
+	GetMerchant(ctx context.Context, merchantID string) (*model.Merchant, error)
+	ReserveMerchantReference(ctx context.Context, merchantID, reference string) (bool, error)
+	ReleaseMerchantReference(ctx context.Context, merchantID, reference string) error
+	AddWebhookEndpoint(ctx context.Context, endpoint model.WebhookEndpoint) error
+	DeleteWebhookEndpoint(ctx context.Context, merchantID, endpointID string) (bool, error)
+	ListWebhookDeliveries(ctx context.Context, merchantID string, filter model.WebhookDeliveryFilter) ([]*protos.WebhookDelivery, error)
+	RedeliverWebhook(ctx context.Context, merchantID string, deliveryID int64) (*protos.WebhookDelivery, error)
+	ClaimWebhookDeliveries(ctx context.Context, limit int, lease time.Duration) ([]*model.WebhookDelivery, error)
+	CompleteWebhookDelivery(ctx context.Context, attempt model.WebhookAttempt) error
+	RelayOutbox(ctx context.Context, limit int, publish func(events []*model.OutboxEvent) int) (int, error)
+	GetLatestStatusChange(ctx context.Context, merchantID, refID string) (*model.StatusChangeEvent, error)
+	ListenStatusChanges(ctx context.Context) (StatusListener, error)
+	ClaimPendingPayments(ctx context.Context, minAge, reverseAfter, inquiryInterval, maxAge time.Duration, limit int) ([]*model.PendingPayment, error)
+}
+
+// StatusListener receives the payment status changes committed by every gateway, in commit order,
+// from the moment it was created
+type StatusListener interface {
+	// Next blocks until the next status change is committed
+	Next(ctx context.Context) (*model.StatusChangeEvent, error)
+	Close()
 }
@@ -0,0 +1,175 @@
+package tlsconfig
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// Reloader serves the certificate of the gRPC server and, for mutual TLS, the CAs client
+// certificates are verified against. Both are read again when their files change so certificates
+// can be rotated without restarting the server
+type Reloader struct {
+	certFile, keyFile, clientCAFile string
+
+	mu        sync.RWMutex
+	cert      *tls.Certificate
+	clientCAs *x509.CertPool
+	modTimes  map[string]time.Time
+}
+
+// NewReloader loads the server certificate, clientCAFile is optional and enables mutual TLS
+func NewReloader(certFile, keyFile, clientCAFile string) (*Reloader, error) {
+	if certFile == "" || keyFile == "" {
+		return nil, fmt.Errorf("server certificate needs both a cert and a key file")
+	}
+
+	r := &Reloader{certFile: certFile, keyFile: keyFile, clientCAFile: clientCAFile}
+
+	if err := r.Reload(); err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+// MutualTLS reports whether client certificates are required
+func (r *Reloader) MutualTLS() bool {
+	return r.clientCAFile != ""
+}
+
+// Config returns the TLS config of the server, every handshake uses the latest certificate and CAs
+func (r *Reloader) Config() *tls.Config {
+	return &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		GetConfigForClient: func(*tls.ClientHelloInfo) (*tls.Config, error) {
+			r.mu.RLock()
+			defer r.mu.RUnlock()
+
+			config := &tls.Config{
+				MinVersion:   tls.VersionTLS12,
+				Certificates: []tls.Certificate{*r.cert},
+			}
+
+			if r.clientCAs != nil {
+				config.ClientAuth = tls.RequireAndVerifyClientCert
+				config.ClientCAs = r.clientCAs
+			}
+
+			return config, nil
+		},
+	}
+}
+
+// Reload reads the certificate and CA files, the previous ones are kept when they are invalid
+func (r *Reloader) Reload() error {
+	modTimes, err := r.readModTimes()
+	if err != nil {
+		return err
+	}
+
+	cert, err := tls.LoadX509KeyPair(r.certFile, r.keyFile)
+	if err != nil {
+		return fmt.Errorf("loading server certificate: %w", err)
+	}
+
+	var clientCAs *x509.CertPool
+
+	if r.clientCAFile != "" {
+		pem, err := os.ReadFile(r.clientCAFile)
+		if err != nil {
+			return fmt.Errorf("reading client CA: %w", err)
+		}
+
+		clientCAs = x509.NewCertPool()
+		if !clientCAs.AppendCertsFromPEM(pem) {
+			return fmt.Errorf("no certificates found in %s", r.clientCAFile)
+		}
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.cert = &cert
+	r.clientCAs = clientCAs
+	r.modTimes = modTimes
+
+	return nil
+}
+
+// Watch checks the files every interval until ctx is done and reloads them when one has changed
+func (r *Reloader) Watch(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		changed, err := r.changed()
+		if err != nil {
+			log.WithError(err).Error("checking TLS certificate files")
+
+			continue
+		}
+
+		if !changed {
+			continue
+		}
+
+		if err := r.Reload(); err != nil {
+			log.WithError(err).Error("reloading TLS certificates, keeping the previous ones")
+
+			continue
+		}
+
+		log.WithField("cert_file", r.certFile).Info("reloaded TLS certificates")
+	}
+}
+
+// changed reports whether a file was modified since it was last loaded
+func (r *Reloader) changed() (bool, error) {
+	modTimes, err := r.readModTimes()
+	if err != nil {
+		return false, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for file, modTime := range modTimes {
+		if !modTime.Equal(r.modTimes[file]) {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+func (r *Reloader) readModTimes() (map[string]time.Time, error) {
+	modTimes := make(map[string]time.Time, 3)
+
+	for _, file := range []string{r.certFile, r.keyFile, r.clientCAFile} {
+		if file == "" {
+			continue
+		}
+
+		info, err := os.Stat(file)
+		if err != nil {
+			return nil, err
+		}
+
+		modTimes[file] = info.ModTime()
+	}
+
+	return modTimes, nil
+}
@@ -0,0 +1,258 @@
+package tlsconfig
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type certificate struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+	pem  []byte
+}
+
+// newCertificate creates a certificate signed by parent, or a self-signed CA when parent is nil
+func newCertificate(t *testing.T, commonName string, parent *certificate) *certificate {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: commonName},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		DNSNames:     []string{"localhost"},
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}
+
+	signer, signerKey := template, key
+	if parent == nil {
+		template.IsCA = true
+		template.BasicConstraintsValid = true
+		template.KeyUsage = x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature
+	} else {
+		signer, signerKey = parent.cert, parent.key
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, signer, &key.PublicKey, signerKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return &certificate{cert: cert, key: key, pem: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})}
+}
+
+func (c *certificate) keyPEM(t *testing.T) []byte {
+	der, err := x509.MarshalECPrivateKey(c.key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: der})
+}
+
+func (c *certificate) tlsCertificate(t *testing.T) tls.Certificate {
+	cert, err := tls.X509KeyPair(c.pem, c.keyPEM(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return cert
+}
+
+// writeFile writes a file with a modification time of modTime, so changes are seen regardless of
+// the resolution of the file system clock
+func writeFile(t *testing.T, file string, data []byte, modTime time.Time) {
+	if err := os.WriteFile(file, data, 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.Chtimes(file, modTime, modTime); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// handshake connects a client to a server using config and returns the server certificate the
+// client saw and the verified client certificate the server saw
+func handshake(serverConfig *tls.Config, clientConfig *tls.Config) (string, string, error) {
+	// a TCP connection buffers the alerts of failed handshakes, net.Pipe would block on them
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return "", "", err
+	}
+	defer lis.Close()
+
+	type result struct {
+		clientName string
+		err        error
+	}
+
+	serverResult := make(chan result, 1)
+
+	go func() {
+		conn, err := lis.Accept()
+		if err != nil {
+			serverResult <- result{err: err}
+
+			return
+		}
+		defer conn.Close()
+
+		server := tls.Server(conn, serverConfig)
+		if err := server.Handshake(); err != nil {
+			serverResult <- result{err: err}
+
+			return
+		}
+
+		var clientName string
+		if chains := server.ConnectionState().VerifiedChains; len(chains) > 0 {
+			clientName = chains[0][0].Subject.CommonName
+		}
+
+		serverResult <- result{clientName: clientName}
+	}()
+
+	client, err := tls.Dial("tcp", lis.Addr().String(), clientConfig)
+	if err != nil {
+		<-serverResult
+
+		return "", "", err
+	}
+	defer client.Close()
+
+	res := <-serverResult
+	if res.err != nil {
+		return "", "", res.err
+	}
+
+	return client.ConnectionState().PeerCertificates[0].Subject.CommonName, res.clientName, nil
+}
+
+func TestReloader(t *testing.T) {
+	dir := t.TempDir()
+	certFile, keyFile, caFile := filepath.Join(dir, "server.pem"), filepath.Join(dir, "server.key"), filepath.Join(dir, "ca.pem")
+
+	ca := newCertificate(t, "gateway CA", nil)
+	first := newCertificate(t, "gateway-1", ca)
+	client := newCertificate(t, "merchant-1", ca)
+
+	modTime := time.Now().Add(-time.Minute)
+
+	writeFile(t, certFile, first.pem, modTime)
+	writeFile(t, keyFile, first.keyPEM(t), modTime)
+	writeFile(t, caFile, ca.pem, modTime)
+
+	r, err := NewReloader(certFile, keyFile, caFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.True(t, r.MutualTLS())
+
+	roots := x509.NewCertPool()
+	roots.AddCert(ca.cert)
+
+	clientConfig := &tls.Config{RootCAs: roots, ServerName: "localhost", Certificates: []tls.Certificate{client.tlsCertificate(t)}}
+
+	serverName, clientName, err := handshake(r.Config(), clientConfig)
+	assert.NoError(t, err)
+	assert.Equal(t, "gateway-1", serverName)
+	assert.Equal(t, "merchant-1", clientName)
+
+	// clients without a certificate signed by the client CA are rejected
+	_, _, err = handshake(r.Config(), &tls.Config{RootCAs: roots, ServerName: "localhost"})
+	assert.Error(t, err)
+
+	stranger := newCertificate(t, "merchant-1", newCertificate(t, "another CA", nil))
+	_, _, err = handshake(r.Config(), &tls.Config{RootCAs: roots, ServerName: "localhost", Certificates: []tls.Certificate{stranger.tlsCertificate(t)}})
+	assert.Error(t, err)
+
+	changed, err := r.changed()
+	assert.NoError(t, err)
+	assert.False(t, changed)
+
+	// a rotated certificate is used by new connections once reloaded
+	second := newCertificate(t, "gateway-2", ca)
+
+	writeFile(t, certFile, second.pem, modTime.Add(time.Second))
+	writeFile(t, keyFile, second.keyPEM(t), modTime.Add(time.Second))
+
+	changed, err = r.changed()
+	assert.NoError(t, err)
+	assert.True(t, changed)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	go r.Watch(ctx, 10*time.Millisecond)
+
+	assert.Eventually(t, func() bool {
+		serverName, _, err := handshake(r.Config(), clientConfig)
+
+		return err == nil && serverName == "gateway-2"
+	}, time.Second, 10*time.Millisecond)
+
+	// invalid files are not loaded, the previous certificate keeps being served
+	writeFile(t, keyFile, []byte("not a key"), modTime.Add(2*time.Second))
+
+	assert.Error(t, r.Reload())
+
+	serverName, _, err = handshake(r.Config(), clientConfig)
+	assert.NoError(t, err)
+	assert.Equal(t, "gateway-2", serverName)
+}
+
+func TestNewReloader(t *testing.T) {
+	dir := t.TempDir()
+	certFile, keyFile := filepath.Join(dir, "server.pem"), filepath.Join(dir, "server.key")
+
+	cert := newCertificate(t, "gateway", nil)
+
+	writeFile(t, certFile, cert.pem, time.Now())
+	writeFile(t, keyFile, cert.keyPEM(t), time.Now())
+
+	r, err := NewReloader(certFile, keyFile, "")
+	assert.NoError(t, err)
+	assert.False(t, r.MutualTLS())
+
+	roots := x509.NewCertPool()
+	roots.AddCert(cert.cert)
+
+	serverName, clientName, err := handshake(r.Config(), &tls.Config{RootCAs: roots, ServerName: "localhost"})
+	assert.NoError(t, err)
+	assert.Equal(t, "gateway", serverName)
+	assert.Equal(t, "", clientName)
+
+	_, err = NewReloader(certFile, "", "")
+	assert.Equal(t, "server certificate needs both a cert and a key file", err.Error())
+
+	_, err = NewReloader(certFile, keyFile, filepath.Join(dir, "missing.pem"))
+	assert.Error(t, err)
+
+	writeFile(t, filepath.Join(dir, "empty.pem"), []byte{}, time.Now())
+
+	_, err = NewReloader(certFile, keyFile, filepath.Join(dir, "empty.pem"))
+	assert.Equal(t, "no certificates found in "+filepath.Join(dir, "empty.pem"), err.Error())
+}
@@ -0,0 +1,240 @@
+package vault
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"payments_gateway/model"
+	"payments_gateway/redact"
+)
+
+// _tokenPrefix marks card tokens so they cannot be mistaken for card numbers
+const _tokenPrefix = "tok_"
+
+var (
+	ErrTokenNotFound = errors.New("card token not found")
+	ErrInvalidKey    = errors.New("key encryption key must be 32 bytes")
+	ErrKeyMismatch   = errors.New("card was encrypted with a different key encryption key")
+)
+
+// Store persists vaulted cards. Cards are only returned and deleted for the merchant that stored
+// them, GetVaultedCard returns nil when the merchant has no card with the token
+type Store interface {
+	AddVaultedCard(ctx context.Context, card model.VaultedCard) error
+	GetVaultedCard(ctx context.Context, merchantID, token string) (*model.VaultedCard, error)
+	DeleteVaultedCard(ctx context.Context, merchantID, token string) (bool, error)
+}
+
+// Vault stores card numbers encrypted and hands out opaque tokens in their place. Every card
+// number is encrypted with its own AES-256-GCM data key, which is encrypted with the local
+// key encryption key (KEK) before it is stored
+type Vault struct {
+	store Store
+	kek   cipher.AEAD
+	keyID string
+}
+
+// New creates a vault that encrypts cards with a 32 byte key encryption key
+func New(store Store, kek []byte) (*Vault, error) {
+	if len(kek) != 32 {
+		return nil, ErrInvalidKey
+	}
+
+	aead, err := newAEAD(kek)
+	if err != nil {
+		return nil, err
+	}
+
+	sum := sha256.Sum256(kek)
+
+	return &Vault{
+		store: store,
+		kek:   aead,
+		keyID: hex.EncodeToString(sum[:8]),
+	}, nil
+}
+
+// LoadKey reads a hex encoded 32 byte key encryption key from a file, e.g. one created with
+// `openssl rand -hex 32`
+func LoadKey(path string) ([]byte, error) {
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading key encryption key: %w", err)
+	}
+
+	key, err := hex.DecodeString(strings.TrimSpace(string(contents)))
+	if err != nil {
+		return nil, fmt.Errorf("decoding key encryption key: %w", err)
+	}
+
+	if len(key) != 32 {
+		return nil, ErrInvalidKey
+	}
+
+	return key, nil
+}
+
+// Tokenize encrypts and stores a card of a merchant and returns it with its new token. The CVV is dropped
+func (v *Vault) Tokenize(ctx context.Context, merchantID string, card model.Card) (*model.VaultedCard, error) {
+	token, err := newToken()
+	if err != nil {
+		return nil, err
+	}
+
+	dataKey := make([]byte, 32)
+	if _, err := io.ReadFull(rand.Reader, dataKey); err != nil {
+		return nil, err
+	}
+
+	encryptedCardNumber, err := seal(dataKey, []byte(card.CardNum), token)
+	if err != nil {
+		return nil, err
+	}
+
+	wrappedKey, err := sealWith(v.kek, dataKey, token)
+	if err != nil {
+		return nil, err
+	}
+
+	vaulted := model.VaultedCard{
+		Token:               token,
+		MerchantID:          merchantID,
+		Name:                card.Name,
+		Surname:             card.Surname,
+		Postcode:            card.Postcode,
+		CardType:            card.CardType,
+		Expiry:              card.Expiry,
+		MaskedCardNumber:    redact.MaskPAN(card.CardNum),
+		EncryptedCardNumber: encryptedCardNumber,
+		WrappedKey:          wrappedKey,
+		KeyID:               v.keyID,
+	}
+
+	if err := v.store.AddVaultedCard(ctx, vaulted); err != nil {
+		return nil, fmt.Errorf("storing card: %w", err)
+	}
+
+	return &vaulted, nil
+}
+
+// Detokenize decrypts the card of a token of a merchant. Only call it right before the card is sent
+// to the acquiring bank, the card number must not be kept after that
+func (v *Vault) Detokenize(ctx context.Context, merchantID, token string) (model.Card, error) {
+	vaulted, err := v.store.GetVaultedCard(ctx, merchantID, token)
+	if err != nil {
+		return model.Card{}, fmt.Errorf("getting card: %w", err)
+	}
+
+	if vaulted == nil {
+		return model.Card{}, ErrTokenNotFound
+	}
+
+	if vaulted.KeyID != v.keyID {
+		return model.Card{}, ErrKeyMismatch
+	}
+
+	dataKey, err := openWith(v.kek, vaulted.WrappedKey, token)
+	if err != nil {
+		return model.Card{}, fmt.Errorf("unwrapping data key: %w", err)
+	}
+
+	cardNumber, err := open(dataKey, vaulted.EncryptedCardNumber, token)
+	if err != nil {
+		return model.Card{}, fmt.Errorf("decrypting card number: %w", err)
+	}
+
+	return model.Card{
+		Name:     vaulted.Name,
+		Surname:  vaulted.Surname,
+		Postcode: vaulted.Postcode,
+		CardType: vaulted.CardType,
+		CardNum:  string(cardNumber),
+		Expiry:   vaulted.Expiry,
+	}, nil
+}
+
+// Delete removes a card of a merchant from the vault, its card number cannot be recovered afterwards
+func (v *Vault) Delete(ctx context.Context, merchantID, token string) error {
+	deleted, err := v.store.DeleteVaultedCard(ctx, merchantID, token)
+	if err != nil {
+		return fmt.Errorf("deleting card: %w", err)
+	}
+
+	if !deleted {
+		return ErrTokenNotFound
+	}
+
+	return nil
+}
+
+// IsToken reports whether s looks like a token issued by the vault
+func IsToken(s string) bool {
+	return strings.HasPrefix(s, _tokenPrefix)
+}
+
+func newToken() (string, error) {
+	b := make([]byte, 16)
+	if _, err := io.ReadFull(rand.Reader, b); err != nil {
+		return "", err
+	}
+
+	return _tokenPrefix + hex.EncodeToString(b), nil
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return cipher.NewGCM(block)
+}
+
+// seal encrypts plaintext with key, the token is authenticated so ciphertexts cannot be
+// swapped between tokens
+func seal(key, plaintext []byte, token string) ([]byte, error) {
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return sealWith(aead, plaintext, token)
+}
+
+func open(key, ciphertext []byte, token string) ([]byte, error) {
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return openWith(aead, ciphertext, token)
+}
+
+// sealWith encrypts plaintext and prefixes the ciphertext with its random nonce
+func sealWith(aead cipher.AEAD, plaintext []byte, token string) ([]byte, error) {
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, err
+	}
+
+	return aead.Seal(nonce, nonce, plaintext, []byte(token)), nil
+}
+
+func openWith(aead cipher.AEAD, ciphertext []byte, token string) ([]byte, error) {
+	if len(ciphertext) < aead.NonceSize() {
+		return nil, errors.New("ciphertext is too short")
+	}
+
+	nonce, sealed := ciphertext[:aead.NonceSize()], ciphertext[aead.NonceSize():]
+
+	return aead.Open(nil, nonce, sealed, []byte(token))
+}
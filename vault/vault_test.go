@@ -0,0 +1,203 @@
+package vault
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"payments_gateway/model"
+	"payments_gateway/storage/mocks"
+)
+
+var _card = model.Card{
+	Name:     "Bruce",
+	Surname:  "Wayne",
+	Postcode: "G15 2DN",
+	CardType: "AMERICAN_EXPRESS",
+	CardNum:  "378282246310005",
+	Expiry:   "04/30",
+	Cvv:      3421,
+}
+
+func testKey(b byte) []byte {
+	return bytes.Repeat([]byte{b}, 32)
+}
+
+// tokenize stores _card in a vault and returns what was stored
+func tokenize(t *testing.T, v *Vault, storageMock *mock_storage.MockClient) model.VaultedCard {
+	var stored model.VaultedCard
+
+	storageMock.EXPECT().
+		AddVaultedCard(gomock.Any(), gomock.Any()).
+		Times(1).
+		DoAndReturn(func(_ context.Context, c model.VaultedCard) error {
+			stored = c
+
+			return nil
+		})
+
+	vaulted, err := v.Tokenize(context.Background(), "", _card)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, stored, *vaulted)
+
+	return stored
+}
+
+func TestVault_Tokenize(t *testing.T) {
+	mockController := gomock.NewController(t)
+
+	storageMock := mock_storage.NewMockClient(mockController)
+
+	defer mockController.Finish()
+
+	v, err := New(storageMock, testKey(1))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	stored := tokenize(t, v, storageMock)
+
+	assert.True(t, IsToken(stored.Token))
+	assert.Equal(t, "3782XXXXXXX0005", stored.MaskedCardNumber)
+	assert.False(t, bytes.Contains(stored.EncryptedCardNumber, []byte(_card.CardNum)))
+	assert.NotContains(t, stored.Token, _card.CardNum)
+
+	storageMock.EXPECT().
+		GetVaultedCard(gomock.Any(), "", stored.Token).
+		Times(1).
+		Return(&stored, nil)
+
+	got, err := v.Detokenize(context.Background(), "", stored.Token)
+
+	assert.NoError(t, err)
+
+	// the CVV is never stored
+	want := _card
+	want.Cvv = 0
+	assert.Equal(t, want, got)
+}
+
+func TestVault_Detokenize(t *testing.T) {
+	mockController := gomock.NewController(t)
+
+	storageMock := mock_storage.NewMockClient(mockController)
+
+	defer mockController.Finish()
+
+	v, err := New(storageMock, testKey(1))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	stored := tokenize(t, v, storageMock)
+	other := tokenize(t, v, storageMock)
+
+	otherKey, err := New(storageMock, testKey(2))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tampered := stored
+	tampered.EncryptedCardNumber = append([]byte(nil), stored.EncryptedCardNumber...)
+	tampered.EncryptedCardNumber[len(tampered.EncryptedCardNumber)-1] ^= 1
+
+	// a ciphertext copied from another token does not decrypt
+	swapped := stored
+	swapped.EncryptedCardNumber = other.EncryptedCardNumber
+	swapped.WrappedKey = other.WrappedKey
+
+	tests := []struct {
+		name  string
+		vault *Vault
+		card  *model.VaultedCard
+		err   string
+	}{
+		{
+			name:  "unknown token",
+			vault: v,
+			err:   ErrTokenNotFound.Error(),
+		},
+		{
+			name:  "different key encryption key",
+			vault: otherKey,
+			card:  &stored,
+			err:   ErrKeyMismatch.Error(),
+		},
+		{
+			name:  "tampered card number",
+			vault: v,
+			card:  &tampered,
+			err:   "decrypting card number: cipher: message authentication failed",
+		},
+		{
+			name:  "ciphertext of another token",
+			vault: v,
+			card:  &swapped,
+			err:   "unwrapping data key: cipher: message authentication failed",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			storageMock.EXPECT().
+				GetVaultedCard(gomock.Any(), "", stored.Token).
+				Times(1).
+				Return(tt.card, nil)
+
+			_, err := tt.vault.Detokenize(context.Background(), "", stored.Token)
+
+			assert.EqualError(t, err, tt.err)
+		})
+	}
+}
+
+func TestVault_Delete(t *testing.T) {
+	mockController := gomock.NewController(t)
+
+	storageMock := mock_storage.NewMockClient(mockController)
+
+	defer mockController.Finish()
+
+	v, err := New(storageMock, testKey(1))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	storageMock.EXPECT().DeleteVaultedCard(gomock.Any(), "", "tok_1").Times(1).Return(true, nil)
+	storageMock.EXPECT().DeleteVaultedCard(gomock.Any(), "", "tok_2").Times(1).Return(false, nil)
+
+	assert.NoError(t, v.Delete(context.Background(), "", "tok_1"))
+	assert.Equal(t, ErrTokenNotFound, v.Delete(context.Background(), "", "tok_2"))
+}
+
+func TestLoadKey(t *testing.T) {
+	dir := t.TempDir()
+
+	valid := filepath.Join(dir, "valid")
+	if err := os.WriteFile(valid, []byte(strings.Repeat("ab", 32)+"\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	short := filepath.Join(dir, "short")
+	if err := os.WriteFile(short, []byte("abab"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	key, err := LoadKey(valid)
+	assert.NoError(t, err)
+	assert.Equal(t, bytes.Repeat([]byte{0xab}, 32), key)
+
+	_, err = LoadKey(short)
+	assert.Equal(t, ErrInvalidKey, err)
+
+	_, err = New(nil, []byte("short"))
+	assert.Equal(t, ErrInvalidKey, err)
+}
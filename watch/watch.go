@@ -0,0 +1,211 @@
+package watch
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"payments_gateway/model"
+	"payments_gateway/storage"
+)
+
+var (
+	// ErrNotListening is returned when a watch is started while the hub is not listening for status changes
+	ErrNotListening = errors.New("not listening for payment status changes")
+	// ErrInterrupted stops the watchers when the hub stops listening, changes may have been missed
+	ErrInterrupted = errors.New("listening for payment status changes was interrupted")
+	// ErrTooSlow stops a watcher whose buffer is full, it missed a change
+	ErrTooSlow = errors.New("watcher fell behind the payment status changes")
+)
+
+// Options configures a hub
+type Options struct {
+	// Buffer is the number of changes a watcher may fall behind before it is stopped
+	Buffer int
+	// RetryWait is the wait before listening again after the listener failed
+	RetryWait time.Duration
+}
+
+// DefaultOptions buffer 64 changes per watcher and listen again a second after the listener failed
+func DefaultOptions() Options {
+	return Options{
+		Buffer:    64,
+		RetryWait: time.Second,
+	}
+}
+
+// Hub listens for the payment status changes committed by every gateway and sends each to the
+// watchers of this gateway whose filter matches it
+type Hub struct {
+	store storage.Client
+	opts  Options
+
+	mu        sync.Mutex
+	listening bool
+	watchers  map[*Watcher]struct{}
+}
+
+// Watcher receives the status changes matching its filter, in the order they were committed
+type Watcher struct {
+	hub    *Hub
+	filter func(event *model.StatusChangeEvent) bool
+	events chan *model.StatusChangeEvent
+	// err is set with the lock of the hub held when the watcher is stopped
+	err error
+}
+
+// NewHub creates a hub, Run must be called for it to listen
+func NewHub(store storage.Client, opts Options) *Hub {
+	return &Hub{
+		store:    store,
+		opts:     opts,
+		watchers: make(map[*Watcher]struct{}),
+	}
+}
+
+// Run listens for status changes until ctx is done, listening again whenever the listener fails
+func (h *Hub) Run(ctx context.Context) {
+	for {
+		err := h.listen(ctx)
+
+		h.stopAll(ErrInterrupted)
+
+		if ctx.Err() != nil {
+			return
+		}
+
+		log.WithError(err).Error("listening for payment status changes")
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(h.opts.RetryWait):
+		}
+	}
+}
+
+// Listening tells whether the hub is listening for status changes
+func (h *Hub) Listening() bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	return h.listening
+}
+
+// Watchers returns the number of running watchers
+func (h *Hub) Watchers() int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	return len(h.watchers)
+}
+
+// Watch starts a watcher of the status changes filter matches. Every change committed after Watch
+// returns is sent to the watcher until it is stopped, Close must be called once it is not needed
+func (h *Hub) Watch(filter func(event *model.StatusChangeEvent) bool) (*Watcher, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if !h.listening {
+		return nil, ErrNotListening
+	}
+
+	w := &Watcher{
+		hub:    h,
+		filter: filter,
+		events: make(chan *model.StatusChangeEvent, h.opts.Buffer),
+	}
+
+	h.watchers[w] = struct{}{}
+
+	return w, nil
+}
+
+func (h *Hub) listen(ctx context.Context) error {
+	listener, err := h.store.ListenStatusChanges(ctx)
+	if err != nil {
+		return err
+	}
+
+	defer listener.Close()
+
+	h.mu.Lock()
+	h.listening = true
+	h.mu.Unlock()
+
+	for {
+		event, err := listener.Next(ctx)
+		if err != nil {
+			return err
+		}
+
+		h.dispatch(event)
+	}
+}
+
+// dispatch sends a change to the watchers matching it, a watcher that has no room for it is stopped
+// so it never silently misses a change
+func (h *Hub) dispatch(event *model.StatusChangeEvent) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for w := range h.watchers {
+		if !w.filter(event) {
+			continue
+		}
+
+		select {
+		case w.events <- event:
+		default:
+			h.stop(w, ErrTooSlow)
+		}
+	}
+}
+
+// stopAll marks the hub as not listening and stops every watcher
+func (h *Hub) stopAll(err error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.listening = false
+
+	for w := range h.watchers {
+		h.stop(w, err)
+	}
+}
+
+// stop stops a watcher with the lock held
+func (h *Hub) stop(w *Watcher, err error) {
+	if _, ok := h.watchers[w]; !ok {
+		return
+	}
+
+	delete(h.watchers, w)
+
+	w.err = err
+	close(w.events)
+}
+
+// Events returns the changes sent to the watcher, it is closed when the watcher is stopped
+func (w *Watcher) Events() <-chan *model.StatusChangeEvent {
+	return w.events
+}
+
+// Err returns why the watcher was stopped, nil while it is running or after Close
+func (w *Watcher) Err() error {
+	w.hub.mu.Lock()
+	defer w.hub.mu.Unlock()
+
+	return w.err
+}
+
+// Close stops the watcher
+func (w *Watcher) Close() {
+	w.hub.mu.Lock()
+	defer w.hub.mu.Unlock()
+
+	w.hub.stop(w, nil)
+}
@@ -0,0 +1,186 @@
+package watch
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"payments_gateway/model"
+	"payments_gateway/storage"
+	"payments_gateway/storage/mocks"
+)
+
+// fakeListener returns the changes sent to it, or the error sent to fail, from Next
+type fakeListener struct {
+	changes chan *model.StatusChangeEvent
+	fail    chan error
+	closed  chan struct{}
+}
+
+func newFakeListener() *fakeListener {
+	return &fakeListener{
+		changes: make(chan *model.StatusChangeEvent),
+		fail:    make(chan error),
+		closed:  make(chan struct{}),
+	}
+}
+
+func (l *fakeListener) Next(ctx context.Context) (*model.StatusChangeEvent, error) {
+	select {
+	case event := <-l.changes:
+		return event, nil
+	case err := <-l.fail:
+		return nil, err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+func (l *fakeListener) Close() {
+	close(l.closed)
+}
+
+func change(id int64, refID string) *model.StatusChangeEvent {
+	return &model.StatusChangeEvent{ID: id, RefID: refID, MerchantID: "merchant-1"}
+}
+
+func ref(refID string) func(event *model.StatusChangeEvent) bool {
+	return func(event *model.StatusChangeEvent) bool {
+		return event.RefID == refID
+	}
+}
+
+// startHub runs a hub on the listeners in turn until the test ends
+func startHub(t *testing.T, opts Options, listeners ...*fakeListener) *Hub {
+	mockController := gomock.NewController(t)
+
+	storageMock := mock_storage.NewMockClient(mockController)
+
+	var calls []*gomock.Call
+	for _, listener := range listeners {
+		calls = append(calls, storageMock.EXPECT().ListenStatusChanges(gomock.Any()).Return(storage.StatusListener(listener), nil))
+	}
+
+	gomock.InOrder(calls...)
+
+	hub := NewHub(storageMock, opts)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+
+	go func() {
+		hub.Run(ctx)
+		close(done)
+	}()
+
+	t.Cleanup(func() {
+		cancel()
+		<-done
+		mockController.Finish()
+	})
+
+	assert.Eventually(t, hub.Listening, time.Second, time.Millisecond)
+
+	return hub
+}
+
+func collect(w *Watcher) []int64 {
+	var ids []int64
+
+	for event := range w.Events() {
+		ids = append(ids, event.ID)
+	}
+
+	return ids
+}
+
+func TestHub_Watch_NotListening(t *testing.T) {
+	hub := NewHub(nil, DefaultOptions())
+
+	_, err := hub.Watch(ref("ref1"))
+
+	assert.Equal(t, ErrNotListening, err)
+}
+
+func TestHub_Watch(t *testing.T) {
+	listener := newFakeListener()
+
+	hub := startHub(t, DefaultOptions(), listener)
+
+	ref1, err := hub.Watch(ref("ref1"))
+	assert.NoError(t, err)
+
+	ref2, err := hub.Watch(ref("ref2"))
+	assert.NoError(t, err)
+
+	for _, event := range []*model.StatusChangeEvent{change(1, "ref1"), change(2, "ref2"), change(3, "ref1")} {
+		listener.changes <- event
+	}
+
+	// the last change is only dispatched once the next one is read
+	listener.changes <- change(4, "ref3")
+
+	assert.Equal(t, 2, hub.Watchers())
+
+	ref1.Close()
+	ref2.Close()
+
+	assert.Equal(t, 0, hub.Watchers())
+
+	assert.Equal(t, []int64{1, 3}, collect(ref1))
+	assert.Equal(t, []int64{2}, collect(ref2))
+	assert.NoError(t, ref1.Err())
+}
+
+func TestHub_Watch_TooSlow(t *testing.T) {
+	listener := newFakeListener()
+
+	hub := startHub(t, Options{Buffer: 1, RetryWait: time.Millisecond}, listener)
+
+	slow, err := hub.Watch(ref("ref1"))
+	assert.NoError(t, err)
+
+	listener.changes <- change(1, "ref1")
+	listener.changes <- change(2, "ref1")
+	listener.changes <- change(3, "ref2")
+
+	assert.Equal(t, []int64{1}, collect(slow))
+	assert.Equal(t, ErrTooSlow, slow.Err())
+
+	// closing a stopped watcher keeps why it was stopped
+	slow.Close()
+
+	assert.Equal(t, ErrTooSlow, slow.Err())
+}
+
+func TestHub_Run_ListensAgain(t *testing.T) {
+	first, second := newFakeListener(), newFakeListener()
+
+	hub := startHub(t, Options{Buffer: 1, RetryWait: time.Millisecond}, first, second)
+
+	interrupted, err := hub.Watch(ref("ref1"))
+	assert.NoError(t, err)
+
+	first.fail <- errors.New("connection reset by peer")
+
+	assert.Empty(t, collect(interrupted))
+	assert.Equal(t, ErrInterrupted, interrupted.Err())
+
+	<-first.closed
+
+	assert.Eventually(t, hub.Listening, time.Second, time.Millisecond)
+
+	resumed, err := hub.Watch(ref("ref1"))
+	assert.NoError(t, err)
+
+	second.changes <- change(5, "ref1")
+	second.changes <- change(6, "ref2")
+
+	resumed.Close()
+
+	assert.Equal(t, []int64{5}, collect(resumed))
+}
@@ -0,0 +1,117 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"syscall"
+	"time"
+)
+
+// ErrNonPublicAddress is returned when an endpoint is or resolves to an address events must not be sent
+// to, e.g. a loopback, private or cloud metadata address of the network the gateway runs in
+var ErrNonPublicAddress = errors.New("address is not public")
+
+// _nonPublicNetworks are the special purpose ranges the net.IP methods used by PublicIP do not cover
+var _nonPublicNetworks = parseCIDRs(
+	"0.0.0.0/8",       // this network
+	"100.64.0.0/10",   // carrier-grade NAT
+	"192.0.0.0/24",    // IETF protocol assignments
+	"192.0.2.0/24",    // documentation
+	"198.18.0.0/15",   // benchmarking
+	"198.51.100.0/24", // documentation
+	"203.0.113.0/24",  // documentation
+	"240.0.0.0/4",     // reserved, including the broadcast address
+	"64:ff9b::/96",    // NAT64, embeds any IPv4 address
+	"64:ff9b:1::/48",  // local NAT64
+	"2001:db8::/32",   // documentation
+)
+
+// Resolver looks up the addresses of a host, *net.Resolver implements it
+type Resolver interface {
+	LookupIPAddr(ctx context.Context, host string) ([]net.IPAddr, error)
+}
+
+// PublicIP reports whether events may be sent to ip. Unspecified, loopback, private, link-local,
+// which includes the cloud metadata address 169.254.169.254, multicast and reserved addresses are not public
+func PublicIP(ip net.IP) bool {
+	if ip4 := ip.To4(); ip4 != nil {
+		ip = ip4
+	}
+
+	if ip.IsUnspecified() || ip.IsLoopback() || ip.IsPrivate() || ip.IsLinkLocalUnicast() || ip.IsMulticast() {
+		return false
+	}
+
+	for _, network := range _nonPublicNetworks {
+		if network.Contains(ip) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// CheckHost returns ErrNonPublicAddress when host is, or resolves to, an address that is not public.
+// Hosts are checked when their endpoint is registered and again by the dispatcher before every
+// connection, as they may resolve to other addresses by then
+func CheckHost(ctx context.Context, resolver Resolver, host string) error {
+	if ip := net.ParseIP(host); ip != nil {
+		if !PublicIP(ip) {
+			return fmt.Errorf("%s: %w", host, ErrNonPublicAddress)
+		}
+
+		return nil
+	}
+
+	addrs, err := resolver.LookupIPAddr(ctx, host)
+	if err != nil {
+		return err
+	}
+
+	for _, addr := range addrs {
+		if !PublicIP(addr.IP) {
+			return fmt.Errorf("%s resolves to %s: %w", host, addr.IP, ErrNonPublicAddress)
+		}
+	}
+
+	return nil
+}
+
+// publicDialer only connects to public addresses. The address is checked once it is resolved, right
+// before connecting, so a host resolving to another address than when its endpoint was registered,
+// e.g. by DNS rebinding, cannot reach the network of the gateway
+func publicDialer() *net.Dialer {
+	return &net.Dialer{
+		Timeout:   30 * time.Second,
+		KeepAlive: 30 * time.Second,
+		Control: func(_, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+
+			if ip := net.ParseIP(host); ip == nil || !PublicIP(ip) {
+				return fmt.Errorf("dialing %s: %w", address, ErrNonPublicAddress)
+			}
+
+			return nil
+		},
+	}
+}
+
+func parseCIDRs(cidrs ...string) []*net.IPNet {
+	networks := make([]*net.IPNet, 0, len(cidrs))
+
+	for _, cidr := range cidrs {
+		_, network, err := net.ParseCIDR(cidr)
+		if err != nil {
+			panic(err)
+		}
+
+		networks = append(networks, network)
+	}
+
+	return networks
+}
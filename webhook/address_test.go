@@ -0,0 +1,73 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type staticResolver map[string][]string
+
+func (r staticResolver) LookupIPAddr(_ context.Context, host string) ([]net.IPAddr, error) {
+	ips, ok := r[host]
+	if !ok {
+		return nil, &net.DNSError{Err: "no such host", Name: host, IsNotFound: true}
+	}
+
+	addrs := make([]net.IPAddr, 0, len(ips))
+	for _, ip := range ips {
+		addrs = append(addrs, net.IPAddr{IP: net.ParseIP(ip)})
+	}
+
+	return addrs, nil
+}
+
+func TestPublicIP(t *testing.T) {
+	for _, ip := range []string{"93.184.216.34", "8.8.8.8", "2606:2800:220:1:248:1893:25c8:1946"} {
+		assert.True(t, PublicIP(net.ParseIP(ip)), ip)
+	}
+
+	for _, ip := range []string{
+		"127.0.0.1", "10.1.2.3", "172.16.0.1", "192.168.1.1", "169.254.169.254", "0.0.0.0", "100.64.0.1",
+		"255.255.255.255", "224.0.0.1", "::1", "::", "fd00:ec2::254", "fe80::1", "::ffff:127.0.0.1", "64:ff9b::a00:1",
+	} {
+		assert.False(t, PublicIP(net.ParseIP(ip)), ip)
+	}
+}
+
+func TestCheckHost(t *testing.T) {
+	resolver := staticResolver{
+		"merchant.example.com": {"93.184.216.34"},
+		"internal.example.com": {"93.184.216.34", "10.0.0.8"},
+	}
+
+	tests := []struct {
+		name string
+		host string
+		err  error
+	}{
+		{name: "public host", host: "merchant.example.com"},
+		{name: "public address", host: "93.184.216.34"},
+		{name: "host resolving to a private address", host: "internal.example.com", err: ErrNonPublicAddress},
+		{name: "loopback address", host: "127.0.0.1", err: ErrNonPublicAddress},
+		{name: "metadata address", host: "169.254.169.254", err: ErrNonPublicAddress},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := CheckHost(context.Background(), resolver, tt.host)
+			if tt.err != nil {
+				assert.True(t, errors.Is(err, tt.err), err)
+
+				return
+			}
+
+			assert.NoError(t, err)
+		})
+	}
+
+	var dnsErr *net.DNSError
+	assert.True(t, errors.As(CheckHost(context.Background(), resolver, "unknown.example.com"), &dnsErr))
+}
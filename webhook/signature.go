@@ -0,0 +1,93 @@
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// _secretPrefix makes webhook secrets recognisable, e.g. by secret scanners
+const _secretPrefix = "whsec_"
+
+var (
+	// ErrInvalidSignature is returned when no signature of the header matches the body
+	ErrInvalidSignature = errors.New("invalid webhook signature")
+	// ErrSignatureExpired is returned when the signature was made longer ago than the tolerance
+	ErrSignatureExpired = errors.New("webhook signature expired")
+)
+
+// GenerateSecret returns a new random secret for a webhook endpoint
+func GenerateSecret() (string, error) {
+	secret := make([]byte, 32)
+	if _, err := io.ReadFull(rand.Reader, secret); err != nil {
+		return "", err
+	}
+
+	return _secretPrefix + hex.EncodeToString(secret), nil
+}
+
+// Sign returns the SignatureHeader of a body sent at a time, "t=<unix seconds>,v1=<signature>".
+// The signature is the hex HMAC-SHA256 of "<unix seconds>.<body>" keyed with the endpoint secret,
+// signing the time stops a captured event being replayed later
+func Sign(secret string, at time.Time, body []byte) string {
+	timestamp := strconv.FormatInt(at.Unix(), 10)
+
+	return fmt.Sprintf("t=%s,v1=%s", timestamp, signature(secret, timestamp, body))
+}
+
+// Verify checks the SignatureHeader of a received body, the signature must have been made within
+// tolerance of now
+func Verify(secret, header string, body []byte, tolerance time.Duration, now time.Time) error {
+	var timestamp string
+
+	var signatures []string
+
+	for _, part := range strings.Split(header, ",") {
+		pair := strings.SplitN(strings.TrimSpace(part), "=", 2)
+		if len(pair) != 2 {
+			continue
+		}
+
+		switch pair[0] {
+		case "t":
+			timestamp = pair[1]
+		case "v1":
+			signatures = append(signatures, pair[1])
+		}
+	}
+
+	seconds, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil || len(signatures) == 0 {
+		return ErrInvalidSignature
+	}
+
+	expected := signature(secret, timestamp, body)
+
+	for _, candidate := range signatures {
+		if hmac.Equal([]byte(candidate), []byte(expected)) {
+			if age := now.Sub(time.Unix(seconds, 0)); age > tolerance || age < -tolerance {
+				return ErrSignatureExpired
+			}
+
+			return nil
+		}
+	}
+
+	return ErrInvalidSignature
+}
+
+func signature(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,92 @@
+package webhook
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVerify(t *testing.T) {
+	secret := "whsec_test"
+
+	body := []byte(`{"id":"1","type":"payment.status_changed"}`)
+
+	signedAt := time.Date(2022, 5, 1, 6, 0, 0, 0, time.UTC)
+
+	header := Sign(secret, signedAt, body)
+
+	tests := []struct {
+		name   string
+		secret string
+		header string
+		body   []byte
+		now    time.Time
+		err    error
+	}{
+		{
+			name:   "valid signature",
+			secret: secret,
+			header: header,
+			body:   body,
+			now:    signedAt.Add(time.Minute),
+		},
+		{
+			name:   "any of several signatures",
+			secret: secret,
+			header: "t=" + strings.TrimPrefix(strings.Split(header, ",")[0], "t=") + ",v1=deadbeef," + strings.Split(header, ",")[1],
+			body:   body,
+			now:    signedAt,
+		},
+		{
+			name:   "body changed",
+			secret: secret,
+			header: header,
+			body:   []byte(`{"id":"2","type":"payment.status_changed"}`),
+			now:    signedAt,
+			err:    ErrInvalidSignature,
+		},
+		{
+			name:   "different secret",
+			secret: "whsec_other",
+			header: header,
+			body:   body,
+			now:    signedAt,
+			err:    ErrInvalidSignature,
+		},
+		{
+			name:   "signature replayed later",
+			secret: secret,
+			header: header,
+			body:   body,
+			now:    signedAt.Add(10 * time.Minute),
+			err:    ErrSignatureExpired,
+		},
+		{
+			name:   "malformed header",
+			secret: secret,
+			header: "v1=abc",
+			body:   body,
+			now:    signedAt,
+			err:    ErrInvalidSignature,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.err, Verify(tt.secret, tt.header, tt.body, 5*time.Minute, tt.now))
+		})
+	}
+}
+
+func TestGenerateSecret(t *testing.T) {
+	first, err := GenerateSecret()
+	assert.NoError(t, err)
+
+	second, err := GenerateSecret()
+	assert.NoError(t, err)
+
+	assert.True(t, strings.HasPrefix(first, _secretPrefix))
+	assert.Len(t, first, len(_secretPrefix)+64)
+	assert.NotEqual(t, first, second)
+}
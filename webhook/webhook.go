@@ -0,0 +1,320 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/go-cleanhttp"
+	log "github.com/sirupsen/logrus"
+
+	"payments_gateway/model"
+	protos "payments_gateway/protos"
+	"payments_gateway/storage"
+)
+
+const (
+	// SignatureHeader holds the signature of an event, see Sign
+	SignatureHeader = "Webhook-Signature"
+	// DeliveryHeader holds the ID of the delivery, the ID stays the same when it is retried
+	DeliveryHeader = "Webhook-Delivery"
+	// EventStatusChanged is the type of the event sent for every payment status change
+	EventStatusChanged = "payment.status_changed"
+)
+
+// _maxResponseBody is how much of a response body is read so the connection can be reused
+const _maxResponseBody = 64 << 10
+
+// Event is the JSON body POSTed to a webhook endpoint. ID identifies the status change, it is
+// the same for every endpoint the change is sent to and when the change is redelivered
+type Event struct {
+	ID      string    `json:"id"`
+	Type    string    `json:"type"`
+	Created time.Time `json:"created"`
+	Data    EventData `json:"data"`
+}
+
+// EventData is a payment status change, OldStatus is empty for a new payment
+type EventData struct {
+	Ref               string `json:"ref"`
+	MerchantReference string `json:"merchant_reference,omitempty"`
+	OldStatus         string `json:"old_status,omitempty"`
+	NewStatus         string `json:"new_status"`
+	Reason            string `json:"reason,omitempty"`
+	Source            string `json:"source"`
+}
+
+// Options configures how deliveries are attempted and retried
+type Options struct {
+	// MaxAttempts is the number of failed attempts after which a delivery is dead lettered
+	MaxAttempts int
+	// RetryWaitMin is the wait before the first retry, it doubles with every retry up to RetryWaitMax
+	RetryWaitMin time.Duration
+	RetryWaitMax time.Duration
+	// Timeout is how long an endpoint has to respond to an attempt
+	Timeout time.Duration
+	// BatchSize is the number of due deliveries claimed at a time
+	BatchSize int
+	// Workers is the number of deliveries of a batch attempted at the same time
+	Workers int
+}
+
+// DefaultOptions retry a delivery for about 5 hours, waiting from 30 seconds up to an hour between attempts
+func DefaultOptions() Options {
+	return Options{
+		MaxAttempts:  12,
+		RetryWaitMin: 30 * time.Second,
+		RetryWaitMax: time.Hour,
+		Timeout:      10 * time.Second,
+		BatchSize:    100,
+		Workers:      10,
+	}
+}
+
+// Summary counts what a dispatcher run did
+type Summary struct {
+	Delivered    int
+	Retried      int
+	DeadLettered int
+}
+
+// Dispatcher POSTs the pending payment status changes to the webhook endpoints of merchants
+type Dispatcher struct {
+	store  storage.Client
+	client *http.Client
+	opts   Options
+	now    func() time.Time
+}
+
+// New creates a dispatcher, redirects are not followed so an endpoint must respond itself and only
+// public addresses are connected to. Options that are not positive are taken from DefaultOptions
+func New(store storage.Client, opts Options) *Dispatcher {
+	defaults := DefaultOptions()
+
+	if opts.MaxAttempts <= 0 {
+		opts.MaxAttempts = defaults.MaxAttempts
+	}
+
+	if opts.RetryWaitMin <= 0 {
+		opts.RetryWaitMin = defaults.RetryWaitMin
+	}
+
+	if opts.RetryWaitMax < opts.RetryWaitMin {
+		opts.RetryWaitMax = opts.RetryWaitMin
+	}
+
+	if opts.Timeout <= 0 {
+		opts.Timeout = defaults.Timeout
+	}
+
+	if opts.BatchSize <= 0 {
+		opts.BatchSize = defaults.BatchSize
+	}
+
+	if opts.Workers <= 0 {
+		opts.Workers = defaults.Workers
+	}
+
+	// no proxy is used, it would connect to the endpoint instead of the checked dialer
+	transport := cleanhttp.DefaultPooledTransport()
+	transport.Proxy = nil
+	transport.DialContext = publicDialer().DialContext
+
+	return &Dispatcher{
+		store: store,
+		client: &http.Client{
+			Transport: transport,
+			Timeout:   opts.Timeout,
+			CheckRedirect: func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+		opts: opts,
+		now:  time.Now,
+	}
+}
+
+// Run attempts the deliveries that are due until none are left
+func (d *Dispatcher) Run(ctx context.Context) (Summary, error) {
+	var summary Summary
+
+	for {
+		deliveries, err := d.store.ClaimWebhookDeliveries(ctx, d.opts.BatchSize, d.lease())
+		if err != nil {
+			return summary, fmt.Errorf("claiming webhook deliveries: %w", err)
+		}
+
+		if err := d.dispatch(ctx, deliveries, &summary); err != nil {
+			return summary, err
+		}
+
+		if len(deliveries) < d.opts.BatchSize {
+			return summary, nil
+		}
+	}
+}
+
+// lease is long enough for every delivery of a batch to be attempted, even when every endpoint times out
+func (d *Dispatcher) lease() time.Duration {
+	rounds := (d.opts.BatchSize + d.opts.Workers - 1) / d.opts.Workers
+
+	return time.Duration(rounds)*d.opts.Timeout + time.Minute
+}
+
+// dispatch attempts a batch of deliveries with the workers of the dispatcher and records every outcome
+func (d *Dispatcher) dispatch(ctx context.Context, deliveries []*model.WebhookDelivery, summary *Summary) error {
+	work := make(chan *model.WebhookDelivery)
+
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		firstErr error
+	)
+
+	for i := 0; i < d.opts.Workers; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for delivery := range work {
+				attempt := d.attempt(ctx, delivery)
+
+				err := d.store.CompleteWebhookDelivery(ctx, attempt)
+
+				mu.Lock()
+				if err != nil && firstErr == nil {
+					firstErr = fmt.Errorf("completing webhook delivery %d: %w", delivery.ID, err)
+				}
+
+				switch attempt.Status {
+				case protos.WebhookDeliveryStatus_DELIVERED:
+					summary.Delivered++
+				case protos.WebhookDeliveryStatus_DEAD_LETTER:
+					summary.DeadLettered++
+				default:
+					summary.Retried++
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+
+	for _, delivery := range deliveries {
+		work <- delivery
+	}
+
+	close(work)
+	wg.Wait()
+
+	return firstErr
+}
+
+// attempt POSTs a delivery to its endpoint, any response other than 2xx is a failed attempt
+func (d *Dispatcher) attempt(ctx context.Context, delivery *model.WebhookDelivery) model.WebhookAttempt {
+	logger := log.WithField("delivery_id", delivery.ID).WithField("endpoint_id", delivery.EndpointID).WithField("ref", delivery.RefID)
+
+	responseCode, err := d.post(ctx, delivery)
+	if err == nil {
+		return model.WebhookAttempt{DeliveryID: delivery.ID, Status: protos.WebhookDeliveryStatus_DELIVERED, ResponseCode: responseCode}
+	}
+
+	attempt := model.WebhookAttempt{
+		DeliveryID:   delivery.ID,
+		Status:       protos.WebhookDeliveryStatus_DELIVERY_PENDING,
+		ResponseCode: responseCode,
+		Error:        err.Error(),
+	}
+
+	attempts := delivery.Attempts + 1
+
+	if attempts >= d.opts.MaxAttempts {
+		logger.WithField("attempts", attempts).WithError(err).Error("webhook delivery dead lettered")
+
+		attempt.Status = protos.WebhookDeliveryStatus_DEAD_LETTER
+
+		return attempt
+	}
+
+	attempt.RetryAfter = d.backoff(attempts)
+
+	logger.WithField("attempts", attempts).WithField("retry_after", attempt.RetryAfter.String()).WithError(err).Warn("webhook delivery failed")
+
+	return attempt
+}
+
+// post sends the event of a delivery, returning the response code when there was a response
+func (d *Dispatcher) post(ctx context.Context, delivery *model.WebhookDelivery) (int, error) {
+	body, err := json.Marshal(NewEvent(delivery))
+	if err != nil {
+		return 0, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, delivery.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "payments-gateway-webhooks")
+	req.Header.Set(DeliveryHeader, strconv.FormatInt(delivery.ID, 10))
+	req.Header.Set(SignatureHeader, Sign(delivery.Secret, d.now(), body))
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+
+	defer resp.Body.Close()
+
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, _maxResponseBody))
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp.StatusCode, fmt.Errorf("endpoint responded %s", resp.Status)
+	}
+
+	return resp.StatusCode, nil
+}
+
+// backoff is the wait before the retry following a number of failed attempts
+func (d *Dispatcher) backoff(attempts int) time.Duration {
+	wait := d.opts.RetryWaitMin
+
+	for i := 1; i < attempts && wait < d.opts.RetryWaitMax; i++ {
+		wait *= 2
+	}
+
+	if wait > d.opts.RetryWaitMax {
+		wait = d.opts.RetryWaitMax
+	}
+
+	return wait
+}
+
+// NewEvent returns the event sent for a delivery
+func NewEvent(delivery *model.WebhookDelivery) Event {
+	data := EventData{
+		Ref:               delivery.RefID,
+		MerchantReference: delivery.MerchantReference,
+		NewStatus:         delivery.NewStatus.String(),
+		Reason:            delivery.Reason,
+		Source:            delivery.Source.String(),
+	}
+
+	if delivery.OldStatus != protos.Status_UNKNOWN {
+		data.OldStatus = delivery.OldStatus.String()
+	}
+
+	return Event{
+		ID:      strconv.FormatInt(delivery.EventID, 10),
+		Type:    EventStatusChanged,
+		Created: delivery.Time.UTC(),
+		Data:    data,
+	}
+}
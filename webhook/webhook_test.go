@@ -0,0 +1,217 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"payments_gateway/model"
+	protos "payments_gateway/protos"
+	"payments_gateway/storage/mocks"
+)
+
+func TestDispatcher_Run(t *testing.T) {
+	mockController := gomock.NewController(t)
+
+	storageMock := mock_storage.NewMockClient(mockController)
+
+	defer mockController.Finish()
+
+	now := time.Date(2022, 5, 1, 6, 0, 0, 0, time.UTC)
+
+	var received []Event
+
+	endpoint := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+
+		if err := Verify("whsec_test", r.Header.Get(SignatureHeader), body, time.Minute, now); err != nil {
+			w.WriteHeader(http.StatusUnauthorized)
+
+			return
+		}
+
+		var event Event
+		_ = json.Unmarshal(body, &event)
+		received = append(received, event)
+
+		switch r.URL.Path {
+		case "/ok":
+			w.WriteHeader(http.StatusNoContent)
+		case "/moved":
+			http.Redirect(w, r, "/ok", http.StatusFound)
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer endpoint.Close()
+
+	delivery := func(path string, attempts int) *model.WebhookDelivery {
+		return &model.WebhookDelivery{
+			ID:                7,
+			EndpointID:        "endpoint-1",
+			URL:               endpoint.URL + path,
+			Secret:            "whsec_test",
+			Attempts:          attempts,
+			EventID:           42,
+			RefID:             "ref1",
+			MerchantReference: "order-1234",
+			OldStatus:         protos.Status_APPROVED,
+			NewStatus:         protos.Status_CAPTURED,
+			Reason:            "captured",
+			Source:            protos.StatusSource_OPERATOR,
+			Time:              now,
+		}
+	}
+
+	tests := []struct {
+		name     string
+		delivery *model.WebhookDelivery
+		want     model.WebhookAttempt
+		summary  Summary
+	}{
+		{
+			name:     "delivered",
+			delivery: delivery("/ok", 0),
+			want:     model.WebhookAttempt{DeliveryID: 7, Status: protos.WebhookDeliveryStatus_DELIVERED, ResponseCode: 204},
+			summary:  Summary{Delivered: 1},
+		},
+		{
+			name:     "failed attempt is retried with backoff",
+			delivery: delivery("/fail", 2),
+			want: model.WebhookAttempt{
+				DeliveryID:   7,
+				Status:       protos.WebhookDeliveryStatus_DELIVERY_PENDING,
+				ResponseCode: 500,
+				Error:        "endpoint responded 500 Internal Server Error",
+				RetryAfter:   4 * time.Second,
+			},
+			summary: Summary{Retried: 1},
+		},
+		{
+			name:     "redirects are not followed",
+			delivery: delivery("/moved", 0),
+			want: model.WebhookAttempt{
+				DeliveryID:   7,
+				Status:       protos.WebhookDeliveryStatus_DELIVERY_PENDING,
+				ResponseCode: 302,
+				Error:        "endpoint responded 302 Found",
+				RetryAfter:   time.Second,
+			},
+			summary: Summary{Retried: 1},
+		},
+		{
+			name:     "last attempt is dead lettered",
+			delivery: delivery("/fail", 4),
+			want: model.WebhookAttempt{
+				DeliveryID:   7,
+				Status:       protos.WebhookDeliveryStatus_DEAD_LETTER,
+				ResponseCode: 500,
+				Error:        "endpoint responded 500 Internal Server Error",
+			},
+			summary: Summary{DeadLettered: 1},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			received = nil
+
+			storageMock.EXPECT().ClaimWebhookDeliveries(gomock.Any(), 10, gomock.Any()).Times(1).Return([]*model.WebhookDelivery{tt.delivery}, nil)
+			storageMock.EXPECT().CompleteWebhookDelivery(gomock.Any(), tt.want).Times(1).Return(nil)
+
+			d := New(storageMock, Options{MaxAttempts: 5, RetryWaitMin: time.Second, RetryWaitMax: 5 * time.Second, Timeout: time.Second, BatchSize: 10, Workers: 2})
+			d.client.Transport = endpoint.Client().Transport
+			d.now = func() time.Time { return now }
+
+			summary, err := d.Run(context.Background())
+			assert.NoError(t, err)
+			assert.Equal(t, tt.summary, summary)
+
+			assert.Equal(t, []Event{{
+				ID:      "42",
+				Type:    EventStatusChanged,
+				Created: now,
+				Data: EventData{
+					Ref:               "ref1",
+					MerchantReference: "order-1234",
+					OldStatus:         "APPROVED",
+					NewStatus:         "CAPTURED",
+					Reason:            "captured",
+					Source:            "OPERATOR",
+				},
+			}}, received)
+		})
+	}
+}
+
+func TestDispatcher_Run_ClaimsUntilNoneAreLeft(t *testing.T) {
+	mockController := gomock.NewController(t)
+
+	storageMock := mock_storage.NewMockClient(mockController)
+
+	defer mockController.Finish()
+
+	endpoint := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer endpoint.Close()
+
+	full := []*model.WebhookDelivery{{ID: 1, URL: endpoint.URL}, {ID: 2, URL: endpoint.URL}}
+
+	gomock.InOrder(
+		storageMock.EXPECT().ClaimWebhookDeliveries(gomock.Any(), 2, time.Minute+time.Second).Times(1).Return(full, nil),
+		storageMock.EXPECT().ClaimWebhookDeliveries(gomock.Any(), 2, time.Minute+time.Second).Times(1).Return(nil, nil),
+	)
+	storageMock.EXPECT().CompleteWebhookDelivery(gomock.Any(), gomock.Any()).Times(2).Return(nil)
+
+	d := New(storageMock, Options{Timeout: time.Second, BatchSize: 2, Workers: 2})
+	d.client.Transport = endpoint.Client().Transport
+
+	summary, err := d.Run(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, Summary{Delivered: 2}, summary)
+
+	storageMock.EXPECT().ClaimWebhookDeliveries(gomock.Any(), 2, gomock.Any()).Times(1).Return(nil, errors.New("connection refused"))
+
+	_, err = d.Run(context.Background())
+	assert.EqualError(t, err, "claiming webhook deliveries: connection refused")
+}
+
+func TestDispatcher_Run_RefusesNonPublicAddresses(t *testing.T) {
+	mockController := gomock.NewController(t)
+
+	storageMock := mock_storage.NewMockClient(mockController)
+
+	defer mockController.Finish()
+
+	var requests int
+
+	// the endpoint listens on a loopback address, e.g. a host that resolved to a public address when
+	// its endpoint was registered
+	endpoint := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+	}))
+	defer endpoint.Close()
+
+	storageMock.EXPECT().ClaimWebhookDeliveries(gomock.Any(), 10, gomock.Any()).Times(1).
+		Return([]*model.WebhookDelivery{{ID: 7, URL: endpoint.URL}}, nil)
+	storageMock.EXPECT().CompleteWebhookDelivery(gomock.Any(), gomock.Any()).Times(1).
+		DoAndReturn(func(_ context.Context, attempt model.WebhookAttempt) error {
+			assert.Equal(t, protos.WebhookDeliveryStatus_DELIVERY_PENDING, attempt.Status)
+			assert.Contains(t, attempt.Error, ErrNonPublicAddress.Error())
+
+			return nil
+		})
+
+	d := New(storageMock, Options{Timeout: time.Second, BatchSize: 10})
+
+	summary, err := d.Run(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, Summary{Retried: 1}, summary)
+	assert.Zero(t, requests)
+}